@@ -6,10 +6,22 @@ var (
 	// ErrPackRequired is returned when an empty pack is passed.
 	ErrPackRequired = errors.New("pack required")
 
+	// ErrDocumentKeyRequired is returned when an empty document key is
+	// passed.
+	ErrDocumentKeyRequired = errors.New("document key required")
+
 	// ErrCheckpointRequired is returned when a pack with an empty checkpoint is
 	// passed.
 	ErrCheckpointRequired = errors.New("checkpoint required")
 
+	// ErrChangeIDRequired is returned when a change with an empty ID is
+	// passed.
+	ErrChangeIDRequired = errors.New("change id required")
+
+	// ErrInvalidOperation is returned when the given operation or a node of
+	// it lacks a field that it requires.
+	ErrInvalidOperation = errors.New("invalid operation")
+
 	// ErrUnsupportedOperation is returned when the given operation is not
 	// supported yet.
 	ErrUnsupportedOperation = errors.New("unsupported operation")
@@ -17,15 +17,18 @@
 package converter_test
 
 import (
+	"encoding/binary"
 	"math"
 	"testing"
 	gotime "time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/yorkie-team/yorkie/api"
 	"github.com/yorkie-team/yorkie/api/converter"
 	"github.com/yorkie-team/yorkie/pkg/document"
+	"github.com/yorkie-team/yorkie/pkg/document/json"
 	"github.com/yorkie-team/yorkie/pkg/document/proxy"
 	"github.com/yorkie-team/yorkie/pkg/document/time"
 	"github.com/yorkie-team/yorkie/pkg/types"
@@ -111,6 +114,23 @@ func TestConverter(t *testing.T) {
 				Increase(10).
 				Increase(math.MaxInt64)
 
+			// a tree
+			root.SetNewTree("k6").
+				Insert([]int{0}, proxy.TreeNode{
+					Type:     "p",
+					Children: []proxy.TreeNode{{Type: json.TreeTextType, Value: "Hello"}},
+				}).
+				Insert([]int{1}, proxy.TreeNode{Type: "ul"}, proxy.TreeNode{Type: "hr"}).
+				Style([]int{0}, map[string]string{"align": "center"}).
+				Move([]int{2}, []int{0}).
+				Delete([]int{1})
+
+			// a set
+			root.SetNewSet("k7").
+				Add("a", 1, true).
+				Add("a").
+				Remove(1)
+
 			return nil
 		})
 		assert.NoError(t, err)
@@ -171,6 +191,23 @@ func TestConverter(t *testing.T) {
 			// counter
 			root.SetNewCounter("k4", 0).Increase(5)
 
+			// tree
+			root.SetNewTree("k5").
+				Insert([]int{0}, proxy.TreeNode{
+					Type:     "p",
+					Children: []proxy.TreeNode{{Type: json.TreeTextType, Value: "Hello"}},
+				}).
+				Insert([]int{1}, proxy.TreeNode{Type: "ul"}, proxy.TreeNode{Type: "hr"}).
+				Style([]int{0}, map[string]string{"align": "center"}).
+				Move([]int{2}, []int{0}).
+				Delete([]int{1})
+
+			// set
+			root.SetNewSet("k6").
+				Add("a", 1, true).
+				Add("a").
+				Remove(1)
+
 			return nil
 		})
 		assert.NoError(t, err)
@@ -189,12 +226,108 @@ func TestConverter(t *testing.T) {
 		assert.Equal(t, d1.Marshal(), d2.Marshal())
 	})
 
+	t.Run("date encoding test", func(t *testing.T) {
+		date := gotime.Unix(1600000000, 123e6)
+		doc := document.New("c1", "d1")
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetDate("k1", date)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		// 01. Milliseconds are kept in both snapshots and change packs.
+		bytes, err := converter.ObjectToBytes(doc.RootObject())
+		assert.NoError(t, err)
+		obj, err := converter.BytesToObject(bytes)
+		assert.NoError(t, err)
+		assert.Equal(t, date, obj.Get("k1").(*json.Primitive).Value())
+
+		pbPack, err := converter.ToChangePack(doc.CreateChangePack())
+		assert.NoError(t, err)
+		pack, err := converter.FromChangePack(pbPack)
+		assert.NoError(t, err)
+		pack.MinSyncedTicket = time.MaxTicket
+		d2 := document.New("c1", "d1")
+		assert.NoError(t, d2.ApplyChangePack(pack))
+		assert.Equal(t, doc.Marshal(), d2.Marshal())
+
+		// 02. Date stored in Unix seconds before the versioning is still decoded.
+		pbElem := &api.JSONElement{}
+		assert.NoError(t, proto.Unmarshal(bytes, pbElem))
+		legacy := [8]byte{}
+		binary.LittleEndian.PutUint64(legacy[:], uint64(date.Unix()))
+		pbElem.GetJsonObject().Nodes[0].Element.GetPrimitive().Value = legacy[:]
+		bytes, err = proto.Marshal(pbElem)
+		assert.NoError(t, err)
+
+		obj, err = converter.BytesToObject(bytes)
+		assert.NoError(t, err)
+		assert.Equal(t, gotime.Unix(date.Unix(), 0), obj.Get("k1").(*json.Primitive).Value())
+
+		// 03. Malformed Date is rejected instead of panicking.
+		pbElem.GetJsonObject().Nodes[0].Element.GetPrimitive().Value = []byte{2}
+		bytes, err = proto.Marshal(pbElem)
+		assert.NoError(t, err)
+		_, err = converter.BytesToObject(bytes)
+		assert.ErrorIs(t, err, json.ErrInvalidValue)
+
+		pbPack.Changes[0].Operations[0].GetSet().Value.Value = []byte{2}
+		_, err = converter.FromChangePack(pbPack)
+		assert.ErrorIs(t, err, json.ErrInvalidValue)
+	})
+
 	t.Run("change pack error test", func(t *testing.T) {
 		_, err := converter.FromChangePack(nil)
 		assert.ErrorIs(t, err, converter.ErrPackRequired)
 
 		_, err = converter.FromChangePack(&api.ChangePack{})
 		assert.ErrorIs(t, err, converter.ErrCheckpointRequired)
+
+		_, err = converter.FromChangePack(&api.ChangePack{Checkpoint: &api.Checkpoint{}})
+		assert.ErrorIs(t, err, converter.ErrDocumentKeyRequired)
+
+		_, err = converter.FromChangePack(&api.ChangePack{
+			DocumentKey: &api.DocumentKey{},
+			Checkpoint:  &api.Checkpoint{},
+			Changes:     []*api.Change{{}},
+		})
+		assert.ErrorIs(t, err, converter.ErrChangeIDRequired)
+	})
+
+	t.Run("malformed operations test", func(t *testing.T) {
+		for _, pbOp := range []*api.Operation{
+			{Body: &api.Operation_Set_{Set: &api.Operation_Set{}}},
+			{Body: &api.Operation_Add_{Add: &api.Operation_Add{}}},
+			{Body: &api.Operation_Increase_{Increase: &api.Operation_Increase{}}},
+			{Body: &api.Operation_Edit_{Edit: &api.Operation_Edit{}}},
+			{Body: &api.Operation_Style_{Style: &api.Operation_Style{}}},
+			{Body: &api.Operation_Remove_{}},
+		} {
+			_, err := converter.FromOperations([]*api.Operation{pbOp})
+			assert.ErrorIs(t, err, converter.ErrInvalidOperation)
+		}
+
+		_, err := converter.FromOperations([]*api.Operation{nil})
+		assert.ErrorIs(t, err, converter.ErrUnsupportedOperation)
+	})
+
+	t.Run("malformed tree operations test", func(t *testing.T) {
+		ticket := &api.TimeTicket{ActorId: time.InitialActorID.Bytes()}
+		for _, pbInsert := range []*api.Operation_TreeInsert{
+			{ExecutedAt: ticket},
+			{Node: &api.TreeNode{Type: "p"}, ExecutedAt: ticket},
+			{Node: &api.TreeNode{Id: ticket, Type: "p", Children: []*api.TreeNode{{Type: "p"}}}, ExecutedAt: ticket},
+		} {
+			_, err := converter.FromOperations([]*api.Operation{
+				{Body: &api.Operation_TreeInsert_{TreeInsert: pbInsert}},
+			})
+			assert.ErrorIs(t, err, converter.ErrInvalidOperation)
+		}
+
+		_, err := converter.FromOperations([]*api.Operation{
+			{Body: &api.Operation_TreeMove_{}},
+		})
+		assert.ErrorIs(t, err, converter.ErrInvalidOperation)
 	})
 
 	t.Run("client test", func(t *testing.T) {
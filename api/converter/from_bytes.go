@@ -60,6 +60,10 @@ func fromJSONElement(pbElem *api.JSONElement) (json.Element, error) {
 		return fromJSONRichText(decoded.RichText)
 	case *api.JSONElement_Counter_:
 		return fromJSONCounter(decoded.Counter)
+	case *api.JSONElement_Tree_:
+		return fromJSONTree(decoded.Tree)
+	case *api.JSONElement_Set_:
+		return fromJSONSet(decoded.Set)
 	default:
 		return nil, fmt.Errorf("%s: %w", decoded, ErrUnsupportedElement)
 	}
@@ -152,10 +156,12 @@ func fromJSONPrimitive(
 		return nil, err
 	}
 
-	primitive := json.NewPrimitive(
-		json.ValueFromBytes(valueType, pbPrim.Value),
-		createdAt,
-	)
+	value, err := json.ValueFromBytes(valueType, pbPrim.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	primitive := json.NewPrimitive(value, createdAt)
 	primitive.SetMovedAt(movedAt)
 	primitive.SetRemovedAt(removedAt)
 	return primitive, nil
@@ -283,6 +289,63 @@ func fromJSONCounter(pbCnt *api.JSONElement_Counter) (*json.Counter, error) {
 	return counter, nil
 }
 
+func fromJSONTree(pbTree *api.JSONElement_Tree) (*json.Tree, error) {
+	createdAt, err := fromTimeTicket(pbTree.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	movedAt, err := fromTimeTicket(pbTree.MovedAt)
+	if err != nil {
+		return nil, err
+	}
+	removedAt, err := fromTimeTicket(pbTree.RemovedAt)
+	if err != nil {
+		return nil, err
+	}
+	root, err := fromTreeNode(pbTree.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := json.NewTree(root, createdAt)
+	tree.SetMovedAt(movedAt)
+	tree.SetRemovedAt(removedAt)
+
+	return tree, nil
+}
+
+func fromJSONSet(pbSet *api.JSONElement_Set) (*json.Set, error) {
+	createdAt, err := fromTimeTicket(pbSet.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	movedAt, err := fromTimeTicket(pbSet.MovedAt)
+	if err != nil {
+		return nil, err
+	}
+	removedAt, err := fromTimeTicket(pbSet.RemovedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	set := json.NewSet(createdAt)
+	for _, pbElem := range pbSet.Elements {
+		pbPrim, ok := pbElem.Body.(*api.JSONElement_Primitive_)
+		if !ok {
+			return nil, fmt.Errorf("%s: %w", pbElem, ErrUnsupportedElement)
+		}
+		elem, err := fromJSONPrimitive(pbPrim.Primitive)
+		if err != nil {
+			return nil, err
+		}
+		set.Add(elem)
+	}
+	set.SetMovedAt(movedAt)
+	set.SetRemovedAt(removedAt)
+
+	return set, nil
+}
+
 func fromTextNode(pbTextNode *api.TextNode) (*json.RGATreeSplitNode, error) {
 	id, err := fromTextNodeID(pbTextNode.Id)
 	if err != nil {
@@ -350,3 +413,44 @@ func fromTextNodeID(
 		int(pbTextNodeID.Offset),
 	), nil
 }
+
+func fromTreeNode(pbNode *api.TreeNode) (*json.TreeNode, error) {
+	if pbNode == nil || pbNode.Id == nil {
+		return nil, fmt.Errorf("tree node without id: %w", ErrInvalidOperation)
+	}
+
+	id, err := fromTimeTicket(pbNode.Id)
+	if err != nil {
+		return nil, err
+	}
+	movedAt, err := fromTimeTicket(pbNode.MovedAt)
+	if err != nil {
+		return nil, err
+	}
+	removedAt, err := fromTimeTicket(pbNode.RemovedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := json.NewRHT()
+	for _, pbAttr := range pbNode.Attributes {
+		updatedAt, err := fromTimeTicket(pbAttr.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		attrs.Set(pbAttr.Key, pbAttr.Value, updatedAt)
+	}
+
+	node := json.NewTreeNode(id, pbNode.Type, pbNode.Value, attrs)
+	node.SetMovedAt(movedAt)
+	node.SetRemovedAt(removedAt)
+	for _, pbChild := range pbNode.Children {
+		child, err := fromTreeNode(pbChild)
+		if err != nil {
+			return nil, err
+		}
+		node.Append(child)
+	}
+
+	return node, nil
+}
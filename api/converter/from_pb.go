@@ -19,6 +19,8 @@ package converter
 import (
 	"fmt"
 
+	protoTypes "github.com/gogo/protobuf/types"
+
 	"github.com/yorkie-team/yorkie/api"
 	"github.com/yorkie-team/yorkie/pkg/document/change"
 	"github.com/yorkie-team/yorkie/pkg/document/checkpoint"
@@ -51,6 +53,9 @@ func FromChangePack(pbPack *api.ChangePack) (*change.Pack, error) {
 	if pbPack.Checkpoint == nil {
 		return nil, ErrCheckpointRequired
 	}
+	if pbPack.DocumentKey == nil {
+		return nil, ErrDocumentKeyRequired
+	}
 
 	changes, err := fromChanges(pbPack.Changes)
 	if err != nil {
@@ -71,6 +76,15 @@ func FromChangePack(pbPack *api.ChangePack) (*change.Pack, error) {
 	}, nil
 }
 
+// FromDocumentKey converts the given Protobuf format to model format.
+func FromDocumentKey(pbKey *api.DocumentKey) (*key.Key, error) {
+	if pbKey == nil {
+		return nil, ErrDocumentKeyRequired
+	}
+
+	return fromDocumentKey(pbKey), nil
+}
+
 func fromDocumentKey(pbKey *api.DocumentKey) *key.Key {
 	return &key.Key{
 		Collection: pbKey.Collection,
@@ -107,6 +121,10 @@ func fromChanges(pbChanges []*api.Change) ([]*change.Change, error) {
 }
 
 func fromChangeID(id *api.ChangeID) (*change.ID, error) {
+	if id == nil {
+		return nil, ErrChangeIDRequired
+	}
+
 	actorID, err := time.ActorIDFromBytes(id.ActorId)
 	if err != nil {
 		return nil, err
@@ -127,6 +145,30 @@ func FromDocumentKeys(pbKeys []*api.DocumentKey) []*key.Key {
 	return keys
 }
 
+// FromChangeSummaries converts the given Protobuf format to model format.
+func FromChangeSummaries(pbSummaries []*api.ChangeSummary) ([]*types.ChangeSummary, error) {
+	var summaries []*types.ChangeSummary
+	for _, pbSummary := range pbSummaries {
+		actorID, err := time.ActorIDFromBytes(pbSummary.ActorId)
+		if err != nil {
+			return nil, err
+		}
+
+		createdAt, err := protoTypes.TimestampFromProto(pbSummary.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		summaries = append(summaries, &types.ChangeSummary{
+			ServerSeq: pbSummary.ServerSeq,
+			Actor:     actorID,
+			Message:   pbSummary.Message,
+			CreatedAt: createdAt,
+		})
+	}
+	return summaries, nil
+}
+
 // FromEventType converts the given Protobuf format to model format.
 func FromEventType(pbDocEventType api.DocEventType) (types.DocEventType, error) {
 	switch pbDocEventType {
@@ -179,7 +221,7 @@ func FromOperations(pbOps []*api.Operation) ([]operation.Operation, error) {
 	for _, pbOp := range pbOps {
 		var op operation.Operation
 		var err error
-		switch decoded := pbOp.Body.(type) {
+		switch decoded := pbOp.GetBody().(type) {
 		case *api.Operation_Set_:
 			op, err = fromSet(decoded.Set)
 		case *api.Operation_Add_:
@@ -198,6 +240,14 @@ func FromOperations(pbOps []*api.Operation) ([]operation.Operation, error) {
 			op, err = fromStyle(decoded.Style)
 		case *api.Operation_Increase_:
 			op, err = fromIncrease(decoded.Increase)
+		case *api.Operation_TreeInsert_:
+			op, err = fromTreeInsert(decoded.TreeInsert)
+		case *api.Operation_TreeRemove_:
+			op, err = fromTreeRemove(decoded.TreeRemove)
+		case *api.Operation_TreeMove_:
+			op, err = fromTreeMove(decoded.TreeMove)
+		case *api.Operation_TreeStyle_:
+			op, err = fromTreeStyle(decoded.TreeStyle)
 		default:
 			return nil, ErrUnsupportedOperation
 		}
@@ -211,6 +261,9 @@ func FromOperations(pbOps []*api.Operation) ([]operation.Operation, error) {
 }
 
 func fromSet(pbSet *api.Operation_Set) (*operation.Set, error) {
+	if pbSet == nil {
+		return nil, fmt.Errorf("set: %w", ErrInvalidOperation)
+	}
 	parentCreatedAt, err := fromTimeTicket(pbSet.ParentCreatedAt)
 	if err != nil {
 		return nil, err
@@ -233,6 +286,9 @@ func fromSet(pbSet *api.Operation_Set) (*operation.Set, error) {
 }
 
 func fromAdd(pbAdd *api.Operation_Add) (*operation.Add, error) {
+	if pbAdd == nil {
+		return nil, fmt.Errorf("add: %w", ErrInvalidOperation)
+	}
 	parentCreatedAt, err := fromTimeTicket(pbAdd.ParentCreatedAt)
 	if err != nil {
 		return nil, err
@@ -258,6 +314,9 @@ func fromAdd(pbAdd *api.Operation_Add) (*operation.Add, error) {
 }
 
 func fromMove(pbMove *api.Operation_Move) (*operation.Move, error) {
+	if pbMove == nil {
+		return nil, fmt.Errorf("move: %w", ErrInvalidOperation)
+	}
 	parentCreatedAt, err := fromTimeTicket(pbMove.ParentCreatedAt)
 	if err != nil {
 		return nil, err
@@ -283,6 +342,9 @@ func fromMove(pbMove *api.Operation_Move) (*operation.Move, error) {
 }
 
 func fromRemove(pbRemove *api.Operation_Remove) (*operation.Remove, error) {
+	if pbRemove == nil {
+		return nil, fmt.Errorf("remove: %w", ErrInvalidOperation)
+	}
 	parentCreatedAt, err := fromTimeTicket(pbRemove.ParentCreatedAt)
 	if err != nil {
 		return nil, err
@@ -303,6 +365,9 @@ func fromRemove(pbRemove *api.Operation_Remove) (*operation.Remove, error) {
 }
 
 func fromEdit(pbEdit *api.Operation_Edit) (*operation.Edit, error) {
+	if pbEdit == nil {
+		return nil, fmt.Errorf("edit: %w", ErrInvalidOperation)
+	}
 	parentCreatedAt, err := fromTimeTicket(pbEdit.ParentCreatedAt)
 	if err != nil {
 		return nil, err
@@ -336,6 +401,9 @@ func fromEdit(pbEdit *api.Operation_Edit) (*operation.Edit, error) {
 }
 
 func fromSelect(pbSelect *api.Operation_Select) (*operation.Select, error) {
+	if pbSelect == nil {
+		return nil, fmt.Errorf("select: %w", ErrInvalidOperation)
+	}
 	parentCreatedAt, err := fromTimeTicket(pbSelect.ParentCreatedAt)
 	if err != nil {
 		return nil, err
@@ -361,6 +429,9 @@ func fromSelect(pbSelect *api.Operation_Select) (*operation.Select, error) {
 }
 
 func fromRichEdit(pbEdit *api.Operation_RichEdit) (*operation.RichEdit, error) {
+	if pbEdit == nil {
+		return nil, fmt.Errorf("rich edit: %w", ErrInvalidOperation)
+	}
 	parentCreatedAt, err := fromTimeTicket(pbEdit.ParentCreatedAt)
 	if err != nil {
 		return nil, err
@@ -395,6 +466,9 @@ func fromRichEdit(pbEdit *api.Operation_RichEdit) (*operation.RichEdit, error) {
 }
 
 func fromStyle(pbStyle *api.Operation_Style) (*operation.Style, error) {
+	if pbStyle == nil {
+		return nil, fmt.Errorf("style: %w", ErrInvalidOperation)
+	}
 	parentCreatedAt, err := fromTimeTicket(pbStyle.ParentCreatedAt)
 	if err != nil {
 		return nil, err
@@ -421,6 +495,9 @@ func fromStyle(pbStyle *api.Operation_Style) (*operation.Style, error) {
 }
 
 func fromIncrease(pbInc *api.Operation_Increase) (*operation.Increase, error) {
+	if pbInc == nil {
+		return nil, fmt.Errorf("increase: %w", ErrInvalidOperation)
+	}
 	parentCreatedAt, err := fromTimeTicket(pbInc.ParentCreatedAt)
 	if err != nil {
 		return nil, err
@@ -440,6 +517,114 @@ func fromIncrease(pbInc *api.Operation_Increase) (*operation.Increase, error) {
 	), nil
 }
 
+func fromTreeInsert(pbInsert *api.Operation_TreeInsert) (*operation.TreeInsert, error) {
+	if pbInsert == nil || pbInsert.Node == nil {
+		return nil, fmt.Errorf("tree insert without node: %w", ErrInvalidOperation)
+	}
+	parentCreatedAt, err := fromTimeTicket(pbInsert.ParentCreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	parentID, err := fromTimeTicket(pbInsert.ParentId)
+	if err != nil {
+		return nil, err
+	}
+	prevID, err := fromTimeTicket(pbInsert.PrevId)
+	if err != nil {
+		return nil, err
+	}
+	node, err := fromTreeNode(pbInsert.Node)
+	if err != nil {
+		return nil, err
+	}
+	executedAt, err := fromTimeTicket(pbInsert.ExecutedAt)
+	if err != nil {
+		return nil, err
+	}
+	return operation.NewTreeInsert(
+		parentCreatedAt,
+		parentID,
+		prevID,
+		node,
+		executedAt,
+	), nil
+}
+
+func fromTreeRemove(pbRemove *api.Operation_TreeRemove) (*operation.TreeRemove, error) {
+	if pbRemove == nil {
+		return nil, fmt.Errorf("tree remove: %w", ErrInvalidOperation)
+	}
+	parentCreatedAt, err := fromTimeTicket(pbRemove.ParentCreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	id, err := fromTimeTicket(pbRemove.Id)
+	if err != nil {
+		return nil, err
+	}
+	executedAt, err := fromTimeTicket(pbRemove.ExecutedAt)
+	if err != nil {
+		return nil, err
+	}
+	return operation.NewTreeRemove(
+		parentCreatedAt,
+		id,
+		executedAt,
+	), nil
+}
+
+func fromTreeMove(pbMove *api.Operation_TreeMove) (*operation.TreeMove, error) {
+	if pbMove == nil {
+		return nil, fmt.Errorf("tree move: %w", ErrInvalidOperation)
+	}
+	parentCreatedAt, err := fromTimeTicket(pbMove.ParentCreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	id, err := fromTimeTicket(pbMove.Id)
+	if err != nil {
+		return nil, err
+	}
+	prevID, err := fromTimeTicket(pbMove.PrevId)
+	if err != nil {
+		return nil, err
+	}
+	executedAt, err := fromTimeTicket(pbMove.ExecutedAt)
+	if err != nil {
+		return nil, err
+	}
+	return operation.NewTreeMove(
+		parentCreatedAt,
+		id,
+		prevID,
+		executedAt,
+	), nil
+}
+
+func fromTreeStyle(pbStyle *api.Operation_TreeStyle) (*operation.TreeStyle, error) {
+	if pbStyle == nil {
+		return nil, fmt.Errorf("tree style: %w", ErrInvalidOperation)
+	}
+	parentCreatedAt, err := fromTimeTicket(pbStyle.ParentCreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	id, err := fromTimeTicket(pbStyle.Id)
+	if err != nil {
+		return nil, err
+	}
+	executedAt, err := fromTimeTicket(pbStyle.ExecutedAt)
+	if err != nil {
+		return nil, err
+	}
+	return operation.NewTreeStyle(
+		parentCreatedAt,
+		id,
+		pbStyle.Attributes,
+		executedAt,
+	), nil
+}
+
 func fromCreatedAtMapByActor(
 	pbCreatedAtMapByActor map[string]*api.TimeTicket,
 ) (map[string]*time.Ticket, error) {
@@ -457,6 +642,9 @@ func fromCreatedAtMapByActor(
 func fromTextNodePos(
 	pbPos *api.TextNodePos,
 ) (*json.RGATreeSplitNodePos, error) {
+	if pbPos == nil {
+		return nil, fmt.Errorf("text node pos required: %w", ErrInvalidOperation)
+	}
 	createdAt, err := fromTimeTicket(pbPos.CreatedAt)
 	if err != nil {
 		return nil, err
@@ -484,6 +672,10 @@ func fromTimeTicket(pbTicket *api.TimeTicket) (*time.Ticket, error) {
 }
 
 func fromElement(pbElement *api.JSONElementSimple) (json.Element, error) {
+	if pbElement == nil {
+		return nil, fmt.Errorf("element required: %w", ErrInvalidOperation)
+	}
+
 	switch pbType := pbElement.Type; pbType {
 	case api.ValueType_JSON_OBJECT:
 		createdAt, err := fromTimeTicket(pbElement.CreatedAt)
@@ -526,10 +718,11 @@ func fromElement(pbElement *api.JSONElementSimple) (json.Element, error) {
 		if err != nil {
 			return nil, err
 		}
-		return json.NewPrimitive(
-			json.ValueFromBytes(valueType, pbElement.Value),
-			createdAt,
-		), nil
+		value, err := json.ValueFromBytes(valueType, pbElement.Value)
+		if err != nil {
+			return nil, err
+		}
+		return json.NewPrimitive(value, createdAt), nil
 	case api.ValueType_TEXT:
 		createdAt, err := fromTimeTicket(pbElement.CreatedAt)
 		if err != nil {
@@ -548,6 +741,18 @@ func fromElement(pbElement *api.JSONElementSimple) (json.Element, error) {
 			json.NewRGATreeSplit(json.InitialRichTextNode()),
 			createdAt,
 		), nil
+	case api.ValueType_TREE:
+		createdAt, err := fromTimeTicket(pbElement.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		return json.NewInitialTree(createdAt), nil
+	case api.ValueType_SET:
+		createdAt, err := fromTimeTicket(pbElement.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		return json.NewSet(createdAt), nil
 	case api.ValueType_INTEGER_CNT:
 		fallthrough
 	case api.ValueType_LONG_CNT:
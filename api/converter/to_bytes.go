@@ -56,6 +56,10 @@ func toJSONElement(elem json.Element) (*api.JSONElement, error) {
 		return toRichText(elem), nil
 	case *json.Counter:
 		return toCounter(elem)
+	case *json.Tree:
+		return toTree(elem), nil
+	case *json.Set:
+		return toJSONSet(elem)
 	default:
 		return nil, fmt.Errorf("%v: %w", reflect.TypeOf(elem), ErrUnsupportedElement)
 	}
@@ -151,6 +155,37 @@ func toCounter(counter *json.Counter) (*api.JSONElement, error) {
 	}, nil
 }
 
+func toTree(tree *json.Tree) *api.JSONElement {
+	return &api.JSONElement{
+		Body: &api.JSONElement_Tree_{Tree: &api.JSONElement_Tree{
+			Root:      toTreeNode(tree.Root()),
+			CreatedAt: toTimeTicket(tree.CreatedAt()),
+			MovedAt:   toTimeTicket(tree.MovedAt()),
+			RemovedAt: toTimeTicket(tree.RemovedAt()),
+		}},
+	}
+}
+
+func toJSONSet(set *json.Set) (*api.JSONElement, error) {
+	var pbElems []*api.JSONElement
+	for _, elem := range set.Nodes() {
+		pbElem, err := toPrimitive(elem)
+		if err != nil {
+			return nil, err
+		}
+		pbElems = append(pbElems, pbElem)
+	}
+
+	return &api.JSONElement{
+		Body: &api.JSONElement_Set_{Set: &api.JSONElement_Set{
+			Elements:  pbElems,
+			CreatedAt: toTimeTicket(set.CreatedAt()),
+			MovedAt:   toTimeTicket(set.MovedAt()),
+			RemovedAt: toTimeTicket(set.RemovedAt()),
+		}},
+	}, nil
+}
+
 func toRHTNodes(rhtNodes []*json.RHTPQMapNode) ([]*api.RHTNode, error) {
 	var pbRHTNodes []*api.RHTNode
 	for _, rhtNode := range rhtNodes {
@@ -236,3 +271,29 @@ func toTextNodeID(id *json.RGATreeSplitNodeID) *api.TextNodeID {
 		Offset:    int32(id.Offset()),
 	}
 }
+
+func toTreeNode(node *json.TreeNode) *api.TreeNode {
+	attrs := make(map[string]*api.RichTextNodeAttr)
+	for _, attr := range node.Attrs().Nodes() {
+		attrs[attr.Key()] = &api.RichTextNodeAttr{
+			Key:       attr.Key(),
+			Value:     attr.Value(),
+			UpdatedAt: toTimeTicket(attr.UpdatedAt()),
+		}
+	}
+
+	var children []*api.TreeNode
+	for _, child := range node.AllChildren() {
+		children = append(children, toTreeNode(child))
+	}
+
+	return &api.TreeNode{
+		Id:         toTimeTicket(node.ID()),
+		Type:       node.Type(),
+		Value:      node.Value(),
+		Attributes: attrs,
+		Children:   children,
+		MovedAt:    toTimeTicket(node.MovedAt()),
+		RemovedAt:  toTimeTicket(node.RemovedAt()),
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"reflect"
 
+	protoTypes "github.com/gogo/protobuf/types"
+
 	"github.com/yorkie-team/yorkie/api"
 	"github.com/yorkie-team/yorkie/pkg/document/change"
 	"github.com/yorkie-team/yorkie/pkg/document/checkpoint"
@@ -55,6 +57,11 @@ func ToChangePack(pack *change.Pack) (*api.ChangePack, error) {
 	}, nil
 }
 
+// ToDocumentKey converts the given model to Protobuf format.
+func ToDocumentKey(key *key.Key) *api.DocumentKey {
+	return toDocumentKey(key)
+}
+
 func toDocumentKey(key *key.Key) *api.DocumentKey {
 	return &api.DocumentKey{
 		Collection: key.Collection,
@@ -105,6 +112,25 @@ func ToDocumentKeys(keys []*key.Key) []*api.DocumentKey {
 	return pbKeys
 }
 
+// ToChangeSummaries converts the given model to Protobuf format.
+func ToChangeSummaries(summaries []*types.ChangeSummary) ([]*api.ChangeSummary, error) {
+	var pbSummaries []*api.ChangeSummary
+	for _, summary := range summaries {
+		createdAt, err := protoTypes.TimestampProto(summary.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		pbSummaries = append(pbSummaries, &api.ChangeSummary{
+			ServerSeq: summary.ServerSeq,
+			ActorId:   summary.Actor.Bytes(),
+			Message:   summary.Message,
+			CreatedAt: createdAt,
+		})
+	}
+	return pbSummaries, nil
+}
+
 // ToClientsMap converts the given model to Protobuf format.
 func ToClientsMap(clientsMap map[string][]types.Client) map[string]*api.Clients {
 	pbClientsMap := make(map[string]*api.Clients)
@@ -177,6 +203,14 @@ func ToOperations(operations []operation.Operation) ([]*api.Operation, error) {
 			pbOperation.Body, err = toStyle(op)
 		case *operation.Increase:
 			pbOperation.Body, err = toIncrease(op)
+		case *operation.TreeInsert:
+			pbOperation.Body, err = toTreeInsert(op)
+		case *operation.TreeRemove:
+			pbOperation.Body, err = toTreeRemove(op)
+		case *operation.TreeMove:
+			pbOperation.Body, err = toTreeMove(op)
+		case *operation.TreeStyle:
+			pbOperation.Body, err = toTreeStyle(op)
 		default:
 			return nil, ErrUnsupportedOperation
 		}
@@ -307,6 +341,50 @@ func toIncrease(increase *operation.Increase) (*api.Operation_Increase_, error)
 	}, nil
 }
 
+func toTreeInsert(insert *operation.TreeInsert) (*api.Operation_TreeInsert_, error) {
+	return &api.Operation_TreeInsert_{
+		TreeInsert: &api.Operation_TreeInsert{
+			ParentCreatedAt: toTimeTicket(insert.ParentCreatedAt()),
+			ParentId:        toTimeTicket(insert.ParentID()),
+			PrevId:          toTimeTicket(insert.PrevID()),
+			Node:            toTreeNode(insert.Node()),
+			ExecutedAt:      toTimeTicket(insert.ExecutedAt()),
+		},
+	}, nil
+}
+
+func toTreeRemove(remove *operation.TreeRemove) (*api.Operation_TreeRemove_, error) {
+	return &api.Operation_TreeRemove_{
+		TreeRemove: &api.Operation_TreeRemove{
+			ParentCreatedAt: toTimeTicket(remove.ParentCreatedAt()),
+			Id:              toTimeTicket(remove.ID()),
+			ExecutedAt:      toTimeTicket(remove.ExecutedAt()),
+		},
+	}, nil
+}
+
+func toTreeMove(move *operation.TreeMove) (*api.Operation_TreeMove_, error) {
+	return &api.Operation_TreeMove_{
+		TreeMove: &api.Operation_TreeMove{
+			ParentCreatedAt: toTimeTicket(move.ParentCreatedAt()),
+			Id:              toTimeTicket(move.ID()),
+			PrevId:          toTimeTicket(move.PrevID()),
+			ExecutedAt:      toTimeTicket(move.ExecutedAt()),
+		},
+	}, nil
+}
+
+func toTreeStyle(style *operation.TreeStyle) (*api.Operation_TreeStyle_, error) {
+	return &api.Operation_TreeStyle_{
+		TreeStyle: &api.Operation_TreeStyle{
+			ParentCreatedAt: toTimeTicket(style.ParentCreatedAt()),
+			Id:              toTimeTicket(style.ID()),
+			Attributes:      style.Attributes(),
+			ExecutedAt:      toTimeTicket(style.ExecutedAt()),
+		},
+	}, nil
+}
+
 func toJSONElementSimple(elem json.Element) (*api.JSONElementSimple, error) {
 	switch elem := elem.(type) {
 	case *json.Object:
@@ -340,6 +418,16 @@ func toJSONElementSimple(elem json.Element) (*api.JSONElementSimple, error) {
 			Type:      api.ValueType_RICH_TEXT,
 			CreatedAt: toTimeTicket(elem.CreatedAt()),
 		}, nil
+	case *json.Tree:
+		return &api.JSONElementSimple{
+			Type:      api.ValueType_TREE,
+			CreatedAt: toTimeTicket(elem.CreatedAt()),
+		}, nil
+	case *json.Set:
+		return &api.JSONElementSimple{
+			Type:      api.ValueType_SET,
+			CreatedAt: toTimeTicket(elem.CreatedAt()),
+		}, nil
 	case *json.Counter:
 		pbCounterType, err := toCounterType(elem.ValueType())
 		if err != nil {
@@ -22,13 +22,18 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	grpcstatus "google.golang.org/grpc/status"
 
 	"github.com/yorkie-team/yorkie/api"
 	"github.com/yorkie-team/yorkie/api/converter"
 	"github.com/yorkie-team/yorkie/internal/log"
 	"github.com/yorkie-team/yorkie/pkg/document"
+	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/checkpoint"
 	"github.com/yorkie-team/yorkie/pkg/document/key"
 	"github.com/yorkie-team/yorkie/pkg/document/time"
 	"github.com/yorkie-team/yorkie/pkg/types"
@@ -49,6 +54,20 @@ var (
 	// ErrDocumentNotAttached occurs when the given document is not attached to
 	// this client.
 	ErrDocumentNotAttached = errors.New("document is not attached")
+
+	// ErrClientSeqGap occurs when the agent finds that some changes of the
+	// document are missing. The local changes are kept, so they can be sent
+	// again by the next sync.
+	ErrClientSeqGap = errors.New("client seq gap")
+
+	// ErrChangesOutOfOrder occurs when the agent receives the changes of the
+	// document out of order. The local changes are kept, so they can be sent
+	// again by the next sync.
+	ErrChangesOutOfOrder = errors.New("changes out of order")
+
+	// ErrInvalidServerSeq occurs when the checkpoint of the document is ahead
+	// of the agent. The document should be attached again to recover.
+	ErrInvalidServerSeq = errors.New("invalid server seq")
 )
 
 // Metadata represents custom metadata that can be defined in the client.
@@ -268,7 +287,7 @@ func (c *Client) Attach(ctx context.Context, doc *document.Document) error {
 	})
 	if err != nil {
 		log.Logger.Error(err)
-		return err
+		return fromStatusError(err)
 	}
 
 	pack, err := converter.FromChangePack(res.ChangePack)
@@ -316,7 +335,7 @@ func (c *Client) Detach(ctx context.Context, doc *document.Document) error {
 	})
 	if err != nil {
 		log.Logger.Error(err)
-		return err
+		return fromStatusError(err)
 	}
 
 	pack, err := converter.FromChangePack(res.ChangePack)
@@ -354,6 +373,103 @@ func (c *Client) Sync(ctx context.Context, keys ...*key.Key) error {
 	return nil
 }
 
+// GetDocumentHistory returns the summaries of the changes of the document of
+// the given key starting from the given server seq, and the server seq of the
+// next page. The server seq of the next page is zero if there are no more
+// changes. If the page size is zero, the default page size of the agent is
+// used.
+func (c *Client) GetDocumentHistory(
+	ctx context.Context,
+	k *key.Key,
+	fromServerSeq uint64,
+	pageSize uint32,
+) ([]*types.ChangeSummary, uint64, error) {
+	if c.status != activated {
+		return nil, 0, ErrClientNotActivated
+	}
+
+	res, err := c.client.GetDocumentHistory(ctx, &api.GetDocumentHistoryRequest{
+		ClientId:      c.id.Bytes(),
+		DocumentKey:   converter.ToDocumentKey(k),
+		FromServerSeq: fromServerSeq,
+		PageSize:      pageSize,
+	})
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, 0, err
+	}
+
+	summaries, err := converter.FromChangeSummaries(res.Changes)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return summaries, res.NextServerSeq, nil
+}
+
+// GetDocumentAt returns the document of the given key as it was at the given
+// server seq. The returned document is not attached to this client, so it
+// is only for reading.
+func (c *Client) GetDocumentAt(
+	ctx context.Context,
+	k *key.Key,
+	serverSeq uint64,
+) (*document.Document, error) {
+	if c.status != activated {
+		return nil, ErrClientNotActivated
+	}
+
+	res, err := c.client.GetDocumentAt(ctx, &api.GetDocumentAtRequest{
+		ClientId:    c.id.Bytes(),
+		DocumentKey: converter.ToDocumentKey(k),
+		ServerSeq:   serverSeq,
+	})
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	doc := document.New(k.Collection, k.Document)
+	if len(res.Snapshot) == 0 {
+		return doc, nil
+	}
+
+	pack := change.NewPack(k, checkpoint.Initial.NextServerSeq(res.ServerSeq), nil, res.Snapshot)
+	pack.MinSyncedTicket = time.InitialTicket
+	if err := doc.ApplyChangePack(pack); err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// RevertDocument reverts the document of the given key to the state at the
+// given server seq and returns the server seq of the document after
+// reverting. The revert is delivered to the attached documents as a change
+// of other clients, so they should be synced to reflect it.
+func (c *Client) RevertDocument(
+	ctx context.Context,
+	k *key.Key,
+	serverSeq uint64,
+) (uint64, error) {
+	if c.status != activated {
+		return 0, ErrClientNotActivated
+	}
+
+	res, err := c.client.RevertDocument(ctx, &api.RevertDocumentRequest{
+		ClientId:    c.id.Bytes(),
+		DocumentKey: converter.ToDocumentKey(k),
+		ServerSeq:   serverSeq,
+	})
+	if err != nil {
+		log.Logger.Error(err)
+		return 0, err
+	}
+
+	return res.ServerSeq, nil
+}
+
 // Metadata returns the metadata of this client.
 func (c *Client) Metadata() Metadata {
 	return c.metadata
@@ -514,7 +630,7 @@ func (c *Client) sync(ctx context.Context, key *key.Key) error {
 	})
 	if err != nil {
 		log.Logger.Error(err)
-		return err
+		return fromStatusError(err)
 	}
 
 	pack, err := converter.FromChangePack(res.ChangePack)
@@ -529,3 +645,32 @@ func (c *Client) sync(ctx context.Context, key *key.Key) error {
 
 	return nil
 }
+
+// fromStatusError converts the given status error of the agent into the error
+// of this client if the change pack violates a precondition of the agent.
+func fromStatusError(err error) error {
+	st, ok := grpcstatus.FromError(err)
+	if !ok || st.Code() != codes.FailedPrecondition {
+		return err
+	}
+
+	for _, detail := range st.Details() {
+		failure, ok := detail.(*errdetails.PreconditionFailure)
+		if !ok {
+			continue
+		}
+
+		for _, violation := range failure.Violations {
+			switch types.PreconditionType(violation.Type) {
+			case types.ClientSeqGap:
+				return fmt.Errorf("%s: %w", st.Message(), ErrClientSeqGap)
+			case types.ChangesOutOfOrder:
+				return fmt.Errorf("%s: %w", st.Message(), ErrChangesOutOfOrder)
+			case types.InvalidServerSeq:
+				return fmt.Errorf("%s: %w", st.Message(), ErrInvalidServerSeq)
+			}
+		}
+	}
+
+	return err
+}
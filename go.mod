@@ -10,7 +10,7 @@ require (
 	github.com/google/uuid v1.0.0
 	github.com/grpc-ecosystem/go-grpc-middleware v1.0.1-0.20190118093823-f849b5445de4
 	github.com/grpc-ecosystem/go-grpc-prometheus v1.2.0
-	github.com/moby/locker v1.0.1
+	github.com/hashicorp/go-memdb v1.3.2
 	github.com/prometheus/client_golang v1.10.0
 	github.com/rs/xid v1.2.1
 	github.com/spf13/cobra v1.1.1
@@ -18,6 +18,7 @@ require (
 	github.com/tidwall/pretty v1.0.0 // indirect
 	github.com/xdg/scram v0.0.0-20180814205039-7eeb5667e42c // indirect
 	github.com/xdg/stringprep v1.0.0 // indirect
+	go.etcd.io/bbolt v1.3.5
 	go.etcd.io/etcd v0.5.0-alpha.5.0.20201125193152-8a03d2e9614b
 	go.mongodb.org/mongo-driver v1.1.2
 	go.uber.org/zap v1.13.0
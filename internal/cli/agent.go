@@ -27,6 +27,7 @@ import (
 
 	"github.com/yorkie-team/yorkie/internal/log"
 	"github.com/yorkie-team/yorkie/yorkie"
+	"github.com/yorkie-team/yorkie/yorkie/backend"
 	"github.com/yorkie-team/yorkie/yorkie/backend/sync/etcd"
 )
 
@@ -38,7 +39,10 @@ var (
 	flagConfPath              string
 	mongoConnectionTimeoutSec int
 	mongoPingTimeoutSec       int
+	boltOpenTimeoutSec        int
 	etcdEndpoints             []string
+	etcdEventPropagation      string
+	etcdClusterSecret         string
 	conf                      = yorkie.NewConfig()
 )
 
@@ -49,9 +53,12 @@ func newAgentCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			conf.Mongo.ConnectionTimeoutSec = time.Duration(mongoConnectionTimeoutSec)
 			conf.Mongo.PingTimeoutSec = time.Duration(mongoPingTimeoutSec)
+			conf.Bolt.OpenTimeoutSec = time.Duration(boltOpenTimeoutSec)
 			if etcdEndpoints != nil {
 				conf.ETCD = &etcd.Config{
-					Endpoints: etcdEndpoints,
+					Endpoints:        etcdEndpoints,
+					EventPropagation: etcdEventPropagation,
+					ClusterSecret:    etcdClusterSecret,
 				}
 			}
 			// If config file is given, command-line arguments will be overwritten.
@@ -72,6 +79,14 @@ func newAgentCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err := conf.RPC.Validate(); err != nil {
+				return err
+			}
+			if conf.ETCD != nil {
+				if err := conf.ETCD.Validate(); err != nil {
+					return err
+				}
+			}
 
 			r, err := yorkie.New(conf)
 			if err != nil {
@@ -145,6 +160,12 @@ func init() {
 		yorkie.DefaultRPCPort,
 		"RPC port",
 	)
+	cmd.Flags().IntVar(
+		&conf.RPC.ClusterPort,
+		"rpc-cluster-port",
+		yorkie.DefaultRPCClusterPort,
+		"RPC port of the Cluster service for other agents",
+	)
 	cmd.Flags().StringVar(
 		&conf.RPC.CertFile,
 		"rpc-cert-file",
@@ -157,6 +178,18 @@ func init() {
 		"",
 		"RPC key file's path",
 	)
+	cmd.Flags().StringToStringVar(
+		&conf.RPC.RateLimits,
+		"rpc-rate-limits",
+		nil,
+		"Rate limits of RPC methods for each client in rate:burst format: method=rate:burst",
+	)
+	cmd.Flags().BoolVar(
+		&conf.RPC.RateLimitShared,
+		"rpc-rate-limit-shared",
+		false,
+		"Whether to share the rate limits across agents through the coordinator",
+	)
 	cmd.Flags().IntVar(
 		&conf.Metrics.Port,
 		"metrics-port",
@@ -187,12 +220,42 @@ func init() {
 		yorkie.DefaultMongoPingTimeoutSec,
 		"Mongo DB's ping timeout in seconds",
 	)
+	cmd.Flags().StringVar(
+		&conf.Bolt.Path,
+		"bolt-path",
+		yorkie.DefaultBoltPath,
+		"Path of the bolt database file",
+	)
+	cmd.Flags().IntVar(
+		&boltOpenTimeoutSec,
+		"bolt-open-timeout-sec",
+		yorkie.DefaultBoltOpenTimeoutSec,
+		"Bolt DB's timeout in seconds to obtain the file lock",
+	)
 	cmd.Flags().StringSliceVar(
 		&etcdEndpoints,
 		"etcd-endpoints",
 		nil,
 		"Comma separated list of etcd endpoints",
 	)
+	cmd.Flags().StringVar(
+		&etcdEventPropagation,
+		"etcd-event-propagation",
+		etcd.BroadcastPropagation,
+		"Mode of propagating document events to other agents: broadcast or watch",
+	)
+	cmd.Flags().StringVar(
+		&etcdClusterSecret,
+		"etcd-cluster-secret",
+		"",
+		"Secret shared by the agents of the cluster to authenticate each other",
+	)
+	cmd.Flags().StringVar(
+		&conf.Backend.DBType,
+		"backend-db-type",
+		backend.MongoDB,
+		"Type of the database to store Yorkie data: mongo, memory or bolt",
+	)
 	cmd.Flags().Uint64Var(
 		&conf.Backend.SnapshotThreshold,
 		"backend-snapshot-threshold",
@@ -206,6 +269,60 @@ func init() {
 		yorkie.DefaultSnapshotInterval,
 		"Interval of changes to create a snapshot",
 	)
+	cmd.Flags().IntVar(
+		&conf.Backend.SubscriptionQueueSize,
+		"backend-subscription-queue-size",
+		yorkie.DefaultSubscriptionQueueSize,
+		"Maximum number of events queued for a subscription of WatchDocuments",
+	)
+	cmd.Flags().StringVar(
+		&conf.Backend.SlowConsumerPolicy,
+		"backend-slow-consumer-policy",
+		yorkie.DefaultSlowConsumerPolicy,
+		"Policy for a subscription whose queue is full: drop or resync",
+	)
+	cmd.Flags().IntVar(
+		&conf.Backend.DocCacheSize,
+		"backend-doc-cache-size",
+		yorkie.DefaultDocCacheSize,
+		"Maximum number of documents cached to validate the changes pushed by clients",
+	)
+	cmd.Flags().StringToStringVar(
+		&conf.Backend.SchemaFiles,
+		"backend-schema-files",
+		map[string]string{},
+		"JSON Schema files by collection to validate documents: collection=path",
+	)
+	cmd.Flags().IntVar(
+		&conf.Backend.MaxChangesPerPack,
+		"backend-max-changes-per-pack",
+		0,
+		"Maximum number of changes in a pack pushed by a client. 0 means unlimited",
+	)
+	cmd.Flags().IntVar(
+		&conf.Backend.MaxPackBytes,
+		"backend-max-pack-bytes",
+		0,
+		"Maximum size of a pack pushed by a client in bytes. 0 means unlimited",
+	)
+	cmd.Flags().IntVar(
+		&conf.Backend.MaxDocumentBytes,
+		"backend-max-document-bytes",
+		0,
+		"Maximum size of the snapshot of a document in bytes. 0 means unlimited",
+	)
+	cmd.Flags().IntVar(
+		&conf.Backend.MaxAttachedDocumentsPerClient,
+		"backend-max-attached-documents-per-client",
+		0,
+		"Maximum number of documents attached to a client. 0 means unlimited",
+	)
+	cmd.Flags().IntVar(
+		&conf.Backend.MaxWatchersPerDocument,
+		"backend-max-watchers-per-document",
+		0,
+		"Maximum number of watchers of a document on an agent. 0 means unlimited",
+	)
 	cmd.Flags().StringVar(
 		&conf.Backend.AuthorizationWebhookURL,
 		"authorization-webhook-url",
@@ -68,9 +68,10 @@ func (c *Context) Push(op operation.Operation) {
 	c.operations = append(c.operations, op)
 }
 
-// RegisterElement registers the given element to the root.
-func (c *Context) RegisterElement(elem json.Element) {
-	c.root.RegisterElement(elem)
+// RegisterElement registers the given element of the given parent to the
+// root.
+func (c *Context) RegisterElement(parent json.Container, elem json.Element) {
+	c.root.RegisterElement(parent, elem)
 }
 
 // RegisterRemovedElementPair registers the given element pair to hash table.
@@ -82,3 +83,8 @@ func (c *Context) RegisterRemovedElementPair(parent json.Container, deleted json
 func (c *Context) RegisterRemovedNodeTextElement(textType json.TextElement) {
 	c.root.RegisterRemovedNodeTextElement(textType)
 }
+
+// RegisterRemovedNodeTree registers a given tree to hash table.
+func (c *Context) RegisterRemovedNodeTree(tree *json.Tree) {
+	c.root.RegisterRemovedNodeTree(tree)
+}
@@ -0,0 +1,399 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"unicode/utf16"
+
+	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/pkg/document/operation"
+	"github.com/yorkie-team/yorkie/pkg/document/proxy"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+)
+
+// CreateDiffChange creates a change of the given ID that turns the root of
+// this document into the given target object. The target is usually the root
+// of this document at an earlier point, so the elements of both are matched by
+// their creation time. It returns nil if there is nothing to change.
+//
+// The change is created on a copy of the root, so this document is not
+// modified. The lamport of the given ID should be greater than the lamport of
+// every change applied to this document so that the change wins over them.
+func (d *InternalDocument) CreateDiffChange(id *change.ID, message string, target *json.Object) *change.Change {
+	root := d.root.DeepCopy()
+	ctx := change.NewContext(id, message, root)
+
+	newHistory().diffObject(ctx, root.Object(), target)
+
+	if !ctx.HasOperations() {
+		return nil
+	}
+	return ctx.ToChange()
+}
+
+// diff generates the operations that turn the given element into the given
+// target element of the same creation time.
+func (h *history) diff(ctx *change.Context, elem json.Element, target json.Element) {
+	switch elem := elem.(type) {
+	case *json.Object:
+		if target, ok := target.(*json.Object); ok {
+			h.diffObject(ctx, elem, target)
+		}
+	case *json.Array:
+		if target, ok := target.(*json.Array); ok {
+			h.diffArray(ctx, elem, target)
+		}
+	case *json.Text:
+		if target, ok := target.(*json.Text); ok {
+			diffText(ctx, elem, target)
+		}
+	case *json.RichText:
+		if target, ok := target.(*json.RichText); ok {
+			diffRichText(ctx, elem, target)
+		}
+	case *json.Counter:
+		if target, ok := target.(*json.Counter); ok {
+			diffCounter(ctx, elem, target)
+		}
+	case *json.Set:
+		if target, ok := target.(*json.Set); ok {
+			h.diffSet(ctx, elem, target)
+		}
+	case *json.Tree:
+		if target, ok := target.(*json.Tree); ok {
+			diffTree(ctx, elem, target)
+		}
+	case *json.Primitive:
+		// NOTE: Primitive is immutable, so the primitives of the
+		// same creation time always have the same value.
+	}
+}
+
+// diffObject generates the operations that turn the given object into the
+// given target object.
+func (h *history) diffObject(ctx *change.Context, obj *json.Object, target *json.Object) {
+	targetMembers := target.Members()
+	for k, elem := range obj.Members() {
+		if _, ok := targetMembers[k]; !ok {
+			h.removeElement(ctx, obj, elem)
+		}
+	}
+
+	for _, node := range target.RHTNodes() {
+		if node.Element().RemovedAt() != nil {
+			continue
+		}
+
+		k, targetElem := node.Key(), node.Element()
+		if elem := obj.Get(k); elem != nil && elem.RemovedAt() == nil &&
+			elem.CreatedAt().Compare(targetElem.CreatedAt()) == 0 {
+			h.diff(ctx, elem, targetElem)
+			continue
+		}
+		h.setElement(ctx, obj, k, targetElem)
+	}
+}
+
+// diffArray generates the operations that turn the given array into the
+// given target array. The elements that exist in both are moved into the
+// order of the target, and the others are removed or inserted.
+func (h *history) diffArray(ctx *change.Context, arr *json.Array, target *json.Array) {
+	targetElems := target.Elements()
+	targetKeys := make(map[string]bool)
+	for _, elem := range targetElems {
+		targetKeys[elem.CreatedAt().Key()] = true
+	}
+
+	elems := make(map[string]json.Element)
+	for _, elem := range arr.Elements() {
+		if !targetKeys[elem.CreatedAt().Key()] {
+			h.removeElement(ctx, arr, elem)
+			continue
+		}
+		elems[elem.CreatedAt().Key()] = elem
+	}
+
+	prevCreatedAt := time.InitialTicket
+	for _, targetElem := range targetElems {
+		elem, ok := elems[targetElem.CreatedAt().Key()]
+		if !ok {
+			prevCreatedAt = h.insertElement(ctx, arr, prevCreatedAt, targetElem).CreatedAt()
+			continue
+		}
+
+		if arr.FindPrevCreatedAt(elem.CreatedAt()).Compare(prevCreatedAt) != 0 {
+			ticket := ctx.IssueTimeTicket()
+			ctx.Push(operation.NewMove(
+				arr.CreatedAt(),
+				prevCreatedAt,
+				elem.CreatedAt(),
+				ticket,
+			))
+			arr.MoveAfter(prevCreatedAt, elem.CreatedAt(), ticket)
+		}
+
+		h.diff(ctx, elem, targetElem)
+		prevCreatedAt = elem.CreatedAt()
+	}
+}
+
+// diffSet generates the operations that turn the given set into the given
+// target set. The elements that are not in the target are removed, and the
+// elements of the target that are not in the set are added.
+func (h *history) diffSet(ctx *change.Context, set *json.Set, target *json.Set) {
+	targetElems := make(map[string]*json.Primitive)
+	for _, elem := range target.Nodes() {
+		if elem.RemovedAt() == nil {
+			targetElems[elem.CreatedAt().Key()] = elem
+		}
+	}
+
+	for _, elem := range set.Nodes() {
+		if elem.RemovedAt() != nil {
+			continue
+		}
+		if _, ok := targetElems[elem.CreatedAt().Key()]; ok {
+			delete(targetElems, elem.CreatedAt().Key())
+			continue
+		}
+		h.removeElement(ctx, set, elem)
+	}
+
+	for _, elem := range target.Nodes() {
+		if _, ok := targetElems[elem.CreatedAt().Key()]; ok {
+			h.addElement(ctx, set, elem)
+		}
+	}
+}
+
+// diffText generates an edit that replaces the range between the common
+// prefix and the common suffix of the given text with the target content.
+func diffText(ctx *change.Context, text *json.Text, target *json.Text) {
+	from := utf16.Encode([]rune(text.Content()))
+	to := utf16.Encode([]rune(target.Content()))
+
+	prefix, suffix := proxy.TextAffixLen(from, to, func(i, j int) bool {
+		return from[i] == to[j]
+	})
+	if prefix == len(from)-suffix && prefix == len(to)-suffix {
+		return
+	}
+
+	editText(ctx, text, prefix, len(from)-suffix, removedText{
+		value: string(utf16.Decode(to[prefix : len(to)-suffix])),
+	})
+}
+
+// diffRichText generates the edits that replace the range between the
+// common prefix and the common suffix of the given rich text with the target
+// content. The characters are compared with their attributes, and the
+// content is inserted as runs of the same attributes.
+func diffRichText(ctx *change.Context, text *json.RichText, target *json.RichText) {
+	from := newRichTextUnits(text)
+	to := newRichTextUnits(target)
+
+	prefix, suffix := proxy.TextAffixLen(from.units, to.units, func(i, j int) bool {
+		return from.units[i] == to.units[j] && from.attrKeys[i] == to.attrKeys[j]
+	})
+	if prefix == len(from.units)-suffix && prefix == len(to.units)-suffix {
+		return
+	}
+
+	runs := to.runs(prefix, len(to.units)-suffix)
+	if len(runs) == 0 {
+		editText(ctx, text, prefix, len(from.units)-suffix, removedText{})
+		return
+	}
+
+	editText(ctx, text, prefix, len(from.units)-suffix, runs[0])
+	index := prefix + len(utf16.Encode([]rune(runs[0].value)))
+	for _, run := range runs[1:] {
+		editText(ctx, text, index, index, run)
+		index += len(utf16.Encode([]rune(run.value)))
+	}
+}
+
+// richTextUnits is the visible content of a rich text as UTF-16 code units
+// with the attributes of each unit.
+type richTextUnits struct {
+	units    []uint16
+	attrKeys []string
+	attrs    []map[string]string
+}
+
+// newRichTextUnits returns the visible content of the given rich text.
+func newRichTextUnits(text *json.RichText) *richTextUnits {
+	u := &richTextUnits{}
+	for _, node := range text.Nodes() {
+		if node.RemovedAt() != nil || node.ID().CreatedAt().Compare(text.CreatedAt()) == 0 {
+			continue
+		}
+
+		val := node.Value().(*json.RichTextValue)
+		attrKey, attrs := val.Attrs().Marshal(), val.Attrs().Elements()
+		for _, unit := range utf16.Encode([]rune(val.Value())) {
+			u.units = append(u.units, unit)
+			u.attrKeys = append(u.attrKeys, attrKey)
+			u.attrs = append(u.attrs, attrs)
+		}
+	}
+	return u
+}
+
+// runs returns the content between the given indexes as runs of the units
+// of the same attributes.
+func (u *richTextUnits) runs(from, to int) []removedText {
+	var runs []removedText
+	for start := from; start < to; {
+		end := start + 1
+		for end < to && u.attrKeys[end] == u.attrKeys[start] {
+			end++
+		}
+		runs = append(runs, removedText{
+			value: string(utf16.Decode(u.units[start:end])),
+			attrs: u.attrs[start],
+		})
+		start = end
+	}
+	return runs
+}
+
+// diffCounter increases the given counter by the difference between the
+// value of the target and the value of the counter.
+func diffCounter(ctx *change.Context, cnt *json.Counter, target *json.Counter) {
+	value := json.CounterValueFromBytes(cnt.ValueType(), cnt.Bytes())
+	targetValue := json.CounterValueFromBytes(target.ValueType(), target.Bytes())
+
+	_, isFloat := value.(float64)
+	_, isTargetFloat := targetValue.(float64)
+	if isFloat || isTargetFloat {
+		if delta := toFloat64(targetValue) - toFloat64(value); delta != 0 {
+			increaseCounter(ctx, cnt, delta)
+		}
+		return
+	}
+
+	if delta := toInt64(targetValue) - toInt64(value); delta != 0 {
+		increaseCounter(ctx, cnt, delta)
+	}
+}
+
+// diffTree generates the operations that turn the given tree into the given
+// target tree. The nodes of both are matched by their IDs, so only the
+// children that differ are deleted or inserted.
+func diffTree(ctx *change.Context, tree *json.Tree, target *json.Tree) {
+	if tree.Marshal() == target.Marshal() {
+		return
+	}
+
+	diffTreeNode(proxy.NewTreeProxy(ctx, tree), nil, tree.Root(), target.Root())
+}
+
+// diffTreeNode generates the operations that turn the children of the node
+// at the given path into the children of the target node. The children
+// between the common prefix and the common suffix are replaced with the
+// copies of the target children, and the others are compared recursively.
+func diffTreeNode(p *proxy.TreeProxy, path []int, node *json.TreeNode, target *json.TreeNode) {
+	children, targetChildren := node.Children(), target.Children()
+	prefix, suffix := proxy.CommonAffixLen(len(children), len(targetChildren), func(i, j int) bool {
+		return children[i].ID().Compare(targetChildren[j].ID()) == 0 &&
+			hasAttrsOf(targetChildren[j], children[i])
+	})
+
+	for i := prefix; i < len(children)-suffix; i++ {
+		p.Delete(childPath(path, prefix))
+	}
+	for i := prefix; i < len(targetChildren)-suffix; i++ {
+		p.Insert(childPath(path, i), proxy.ToTreeNode(targetChildren[i]))
+	}
+
+	for i := 0; i < prefix; i++ {
+		diffTreeChild(p, childPath(path, i), children[i], targetChildren[i])
+	}
+	for i := 1; i <= suffix; i++ {
+		index := len(targetChildren) - i
+		diffTreeChild(p, childPath(path, index), children[len(children)-i], targetChildren[index])
+	}
+}
+
+// diffTreeChild generates the operations that turn the given child of the
+// same ID into the target child. Text nodes are not changed after creation,
+// so only the attributes and the children of the other nodes are compared.
+func diffTreeChild(p *proxy.TreeProxy, path []int, child *json.TreeNode, target *json.TreeNode) {
+	if child.IsText() {
+		return
+	}
+
+	attrs := child.Attrs().Elements()
+	styles := make(map[string]string)
+	for k, v := range target.Attrs().Elements() {
+		if value, ok := attrs[k]; !ok || value != v {
+			styles[k] = v
+		}
+	}
+	if len(styles) > 0 {
+		p.Style(path, styles)
+	}
+
+	diffTreeNode(p, path, child, target)
+}
+
+// hasAttrsOf returns whether the given target node has every attribute of
+// the given node. Tree cannot remove attributes, so a node with attributes
+// that the target does not have is replaced.
+func hasAttrsOf(target *json.TreeNode, node *json.TreeNode) bool {
+	targetAttrs := target.Attrs().Elements()
+	for k := range node.Attrs().Elements() {
+		if _, ok := targetAttrs[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// childPath returns the path of the child at the given index of the node at
+// the given path.
+func childPath(path []int, index int) []int {
+	child := make([]int, len(path), len(path)+1)
+	copy(child, path)
+	return append(child, index)
+}
+
+func toInt64(value interface{}) int64 {
+	switch value := value.(type) {
+	case int:
+		return int64(value)
+	case int64:
+		return value
+	case float64:
+		return int64(value)
+	}
+	return 0
+}
+
+func toFloat64(value interface{}) float64 {
+	switch value := value.(type) {
+	case int:
+		return float64(value)
+	case int64:
+		return float64(value)
+	case float64:
+		return value
+	}
+	return 0
+}
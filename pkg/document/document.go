@@ -25,6 +25,7 @@ import (
 	"github.com/yorkie-team/yorkie/pkg/document/json"
 	"github.com/yorkie-team/yorkie/pkg/document/key"
 	"github.com/yorkie-team/yorkie/pkg/document/proxy"
+	"github.com/yorkie-team/yorkie/pkg/document/schema"
 	"github.com/yorkie-team/yorkie/pkg/document/time"
 )
 
@@ -42,12 +43,28 @@ type Document struct {
 	// clone is a copy of `doc` to be exposed to the user and is used to
 	// protect `doc`.
 	clone *json.Root
+
+	// history holds the reverses of the local updates for undo and redo.
+	history *history
+
+	// schema validates the document after local updates.
+	schema schema.Schema
+
+	// subscriptions are the callbacks receiving the events of this document.
+	subscriptions      []subscription
+	nextSubscriptionID int
+
+	// pendingEvents are the events to be delivered to the subscriptions, and
+	// publishing is whether they are being delivered.
+	pendingEvents []Event
+	publishing    bool
 }
 
 // New creates a new instance of Document.
 func New(collection, document string) *Document {
 	return &Document{
-		doc: NewInternalDocument(collection, document),
+		doc:     NewInternalDocument(collection, document),
+		history: newHistory(),
 	}
 }
 
@@ -71,32 +88,91 @@ func (d *Document) Update(
 		return err
 	}
 
-	if ctx.HasOperations() {
-		c := ctx.ToChange()
-		if err := c.Execute(d.doc.root); err != nil {
+	if d.schema != nil {
+		if err := d.schema.Validate(d.clone.Object()); err != nil {
+			// drop clone because it violates the schema.
+			d.clone = nil
+			log.Logger.Error(err)
 			return err
 		}
+	}
+
+	reverses, recorder, err := d.commit(ctx)
+	if err != nil {
+		return err
+	}
+	d.history.pushUndo(reverses, true)
+	d.publish(recorder)
+
+	return nil
+}
 
-		d.doc.localChanges = append(d.doc.localChanges, c)
-		d.doc.changeID = ctx.ID()
+// Undo reverts the last local update of this document. The reverse is
+// created as a new local change, so it is synchronized like other changes.
+func (d *Document) Undo() error {
+	reverses, ok := d.history.popUndo()
+	if !ok {
+		return ErrNothingToUndo
 	}
 
+	redo, recorder, err := d.applyReverses(reverses)
+	if err != nil {
+		d.history.undoStack = append(d.history.undoStack, reverses)
+		return err
+	}
+	d.history.pushRedo(redo)
+	d.publish(recorder)
+
 	return nil
 }
 
+// Redo applies the last update reverted by Undo again.
+func (d *Document) Redo() error {
+	reverses, ok := d.history.popRedo()
+	if !ok {
+		return ErrNothingToRedo
+	}
+
+	undo, recorder, err := d.applyReverses(reverses)
+	if err != nil {
+		d.history.redoStack = append(d.history.redoStack, reverses)
+		return err
+	}
+	d.history.pushUndo(undo, false)
+	d.publish(recorder)
+
+	return nil
+}
+
+// CanUndo returns whether this document has a local update to undo or not.
+func (d *Document) CanUndo() bool {
+	return len(d.history.undoStack) > 0
+}
+
+// CanRedo returns whether this document has an undone update to redo or not.
+func (d *Document) CanRedo() bool {
+	return len(d.history.redoStack) > 0
+}
+
 // ApplyChangePack applies the given change pack into this document.
 func (d *Document) ApplyChangePack(pack *change.Pack) error {
 	// 01. Apply remote changes to both the clone and the document.
+	var recorder *eventRecorder
 	if len(pack.Snapshot) > 0 {
 		d.clone = nil
 		if err := d.doc.applySnapshot(pack.Snapshot, pack.Checkpoint.ServerSeq); err != nil {
 			return err
 		}
+
+		if recorder = d.newEventRecorder(nil, false); recorder != nil {
+			recorder.events = append(recorder.events, Event{Type: SnapshotEvent, Path: "$"})
+		}
 	} else {
 		d.ensureClone()
 
+		recorder = d.newEventRecorder(d.clone, false)
 		for _, c := range pack.Changes {
-			if err := c.Execute(d.clone); err != nil {
+			if err := executeWithEvents(c, d.clone, recorder); err != nil {
 				return err
 			}
 		}
@@ -122,9 +198,44 @@ func (d *Document) ApplyChangePack(pack *change.Pack) error {
 	d.GarbageCollect(pack.MinSyncedTicket)
 
 	log.Logger.Debugf("after apply %d changes: %s", len(pack.Changes), d.RootObject().Marshal())
+
+	// 05. Publish the events of the changes.
+	d.publish(recorder)
 	return nil
 }
 
+// Subscribe registers the given callback to receive the events of the
+// operations applied to this document by both local updates and remote
+// change packs. The callback is called after the change is applied and
+// recorded in the history, so it may update this document again. The events
+// of such an update are delivered after the events being delivered. It
+// returns the function to cancel the subscription.
+func (d *Document) Subscribe(callback func(Event)) func() {
+	id := d.nextSubscriptionID
+	d.nextSubscriptionID++
+	d.subscriptions = append(d.subscriptions, subscription{
+		id:       id,
+		callback: callback,
+	})
+
+	return func() {
+		for i, sub := range d.subscriptions {
+			if sub.id == id {
+				d.subscriptions = append(d.subscriptions[:i:i], d.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// SetSchema sets the schema to validate this document after local updates.
+// An update, an undo or a redo that makes this document violate the schema is
+// rejected before it is applied, like the agent rejects the changes of the
+// collection with the schema.
+func (d *Document) SetSchema(s schema.Schema) {
+	d.schema = s
+}
+
 // Key returns the key of this document.
 func (d *Document) Key() *key.Key {
 	return d.doc.key
@@ -140,7 +251,9 @@ func (d *Document) HasLocalChanges() bool {
 	return d.doc.HasLocalChanges()
 }
 
-// Marshal returns the JSON encoding of this document.
+// Marshal returns the JSON encoding of this document. Date is encoded as a
+// string in json.DateFormat, Bytes as a string in standard base64 encoding
+// and Text as a string.
 func (d *Document) Marshal() string {
 	return d.doc.Marshal()
 }
@@ -176,6 +289,25 @@ func (d *Document) RootObject() *json.Object {
 	return d.doc.RootObject()
 }
 
+// Get returns the element of the given path such as `$.todos[2].title`.
+func (d *Document) Get(path string) (json.Element, error) {
+	return d.doc.root.FindByPath(path)
+}
+
+// ImportJSON sets the members of the given JSON object to the root of this
+// document. See proxy.ImportJSON for how the values are created.
+func (d *Document) ImportJSON(data []byte, hints proxy.ImportHints) error {
+	return d.Update(func(root *proxy.ObjectProxy) error {
+		return proxy.ImportJSON(root, data, hints)
+	})
+}
+
+// Unmarshal stores the content of the given document into the value pointed
+// to by v. See proxy.Marshal for how the elements are bound to Go values.
+func Unmarshal(doc *Document, v interface{}) error {
+	return proxy.Unmarshal(doc.RootObject(), v)
+}
+
 // Root returns the proxy of the root object.
 func (d *Document) Root() *proxy.ObjectProxy {
 	d.ensureClone()
@@ -197,6 +329,105 @@ func (d *Document) GarbageLen() int {
 	return d.doc.GarbageLen()
 }
 
+// applyReverses applies the given reverses in the reverse order of execution
+// and returns the reverses of the applied change with the recorder of its
+// events.
+func (d *Document) applyReverses(reverses []reverse) ([]reverse, *eventRecorder, error) {
+	d.ensureClone()
+
+	restored := d.history.saveRestored()
+	ctx := change.NewContext(d.doc.changeID.Next(), "", d.clone)
+	for i := len(reverses) - 1; i >= 0; i-- {
+		reverses[i].apply(d.history, ctx, d.clone)
+	}
+
+	if d.schema != nil {
+		if err := d.schema.Validate(d.clone.Object()); err != nil {
+			// drop clone and the restored elements because they violate the
+			// schema.
+			d.clone = nil
+			d.history.loadRestored(restored)
+			log.Logger.Error(err)
+			return nil, nil, err
+		}
+	}
+
+	applied, recorder, err := d.commit(ctx)
+	if err != nil {
+		// drop clone and the restored elements because they are contaminated.
+		d.clone = nil
+		d.history.loadRestored(restored)
+		log.Logger.Error(err)
+		return nil, nil, err
+	}
+
+	return applied, recorder, nil
+}
+
+// commit executes the change of the given context on the document and
+// appends it to the local changes. It returns the reverses of the change and
+// the recorder of its events, which the caller publishes after pushing the
+// reverses to the history.
+func (d *Document) commit(ctx *change.Context) ([]reverse, *eventRecorder, error) {
+	if !ctx.HasOperations() {
+		return nil, nil, nil
+	}
+
+	c := ctx.ToChange()
+	recorder := d.newEventRecorder(d.doc.root, true)
+	reverses, err := executeWithReverses(c, d.doc.root, recorder)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d.doc.localChanges = append(d.doc.localChanges, c)
+	d.doc.changeID = ctx.ID()
+
+	return reverses, recorder, nil
+}
+
+// newEventRecorder creates a recorder of the events of the operations
+// executed on the given root. It returns nil if there is no subscription, so
+// that the events are not built.
+func (d *Document) newEventRecorder(root *json.Root, local bool) *eventRecorder {
+	if len(d.subscriptions) == 0 {
+		return nil
+	}
+
+	return &eventRecorder{
+		root:  root,
+		local: local,
+	}
+}
+
+// publish delivers the events recorded by the given recorder to the
+// subscriptions. If it is called by a callback, the events are queued and
+// delivered after the events being delivered, so that the subscriptions
+// receive the events in the order of the changes.
+func (d *Document) publish(recorder *eventRecorder) {
+	if recorder == nil {
+		return
+	}
+
+	d.pendingEvents = append(d.pendingEvents, recorder.events...)
+	if d.publishing {
+		return
+	}
+
+	d.publishing = true
+	defer func() {
+		d.publishing = false
+	}()
+
+	for len(d.pendingEvents) > 0 {
+		event := d.pendingEvents[0]
+		d.pendingEvents = d.pendingEvents[1:]
+		for _, sub := range d.subscriptions {
+			sub.callback(event)
+		}
+	}
+}
+
 func (d *Document) ensureClone() {
 	if d.clone == nil {
 		d.clone = d.doc.root.DeepCopy()
@@ -17,15 +17,21 @@
 package document_test
 
 import (
+	gojson "encoding/json"
 	"errors"
 	"fmt"
 	"testing"
+	gotime "time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/yorkie-team/yorkie/api/converter"
 	"github.com/yorkie-team/yorkie/pkg/document"
+	"github.com/yorkie-team/yorkie/pkg/document/change"
 	"github.com/yorkie-team/yorkie/pkg/document/checkpoint"
+	"github.com/yorkie-team/yorkie/pkg/document/json"
 	"github.com/yorkie-team/yorkie/pkg/document/proxy"
+	"github.com/yorkie-team/yorkie/pkg/document/schema"
 	"github.com/yorkie-team/yorkie/pkg/document/time"
 )
 
@@ -160,6 +166,330 @@ func TestDocument(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("array insert and move test", func(t *testing.T) {
+		doc := document.New("c1", "d1")
+
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			arr := root.SetNewArray("k1")
+			arr.AddNewObject().SetString("a", "1")
+			arr.AddNewText().Edit(0, 0, "text")
+			arr.AddNewRichText().Edit(0, 0, "rich", nil)
+			arr.AddNewCounter(1).Increase(2)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"k1":[{"a":"1"},"text",[{"attrs":{},"val":"rich"}],3]}`, doc.Marshal())
+
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			arr := root.SetNewArray("k2").AddInteger(1, 2, 3)
+			arr.InsertStringBefore(0, "a").InsertBoolAfter(3, true)
+			arr.InsertNewArrayAfter(1).AddInteger(0)
+			arr.InsertNewObjectBefore(2).SetInteger("b", 1)
+			assert.Equal(t, `["a",1,{"b":1},[0],2,3,true]`, arr.Marshal())
+
+			arr.SetLong(1, 7).SetNull(6)
+			arr.SetNewCounter(4, 5).Increase(1)
+			assert.Equal(t, 7, arr.Len())
+
+			arr.MoveFront(6).MoveLast(1).MoveAfter(1, 0)
+			arr.MoveLast(6).MoveAfter(0, 0)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(
+			t,
+			`{"k1":[{"a":"1"},"text",[{"attrs":{},"val":"rich"}],3],"k2":[7,null,{"b":1},[0],6,3,"a"]}`,
+			doc.Marshal(),
+		)
+		assert.Equal(t, 3, doc.GarbageLen())
+
+		internal, err := document.NewInternalDocumentFromSnapshot("c1", "d1", 1, snapshotBytesOf(t, doc))
+		assert.NoError(t, err)
+		assert.Equal(t, doc.Marshal(), internal.Marshal())
+	})
+
+	t.Run("typed getters and path test", func(t *testing.T) {
+		doc := document.New("c1", "d1")
+		now := gotime.Unix(gotime.Now().Unix(), 0)
+
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetString("title", "todos").SetBool("done", false).SetDate("at", now)
+			todos := root.SetNewArray("todos")
+			todos.AddNewObject().SetString("title", "a")
+			todos.AddNewObject().SetString("title", "b").SetLong("priority", 3)
+			todos.AddInteger(7)
+
+			title, err := root.GetString("title")
+			assert.NoError(t, err)
+			assert.Equal(t, "todos", title)
+			done, err := root.GetBool("done")
+			assert.NoError(t, err)
+			assert.False(t, done)
+			at, err := root.GetDate("at")
+			assert.NoError(t, err)
+			assert.True(t, now.Equal(at))
+
+			_, err = root.GetInteger("title")
+			assert.ErrorIs(t, err, json.ErrUnexpectedType)
+			_, err = root.GetString("todos")
+			assert.ErrorIs(t, err, json.ErrUnexpectedType)
+			_, err = root.GetString("none")
+			assert.ErrorIs(t, err, json.ErrElementNotFound)
+
+			assert.Equal(t, 4, root.Len())
+			assert.Equal(t, []string{"at", "done", "title", "todos"}, root.Keys())
+
+			n, err := root.GetArray("todos").GetInteger(2)
+			assert.NoError(t, err)
+			assert.Equal(t, 7, n)
+			_, err = root.GetArray("todos").GetInteger(3)
+			assert.ErrorIs(t, err, json.ErrElementNotFound)
+
+			var keys []string
+			root.Range(func(k string, elem json.Element) bool {
+				keys = append(keys, k)
+				return k != "done"
+			})
+			assert.Equal(t, []string{"at", "done"}, keys)
+
+			count := 0
+			root.GetArray("todos").Range(func(index int, elem json.Element) bool {
+				count++
+				return true
+			})
+			assert.Equal(t, 3, count)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		elem, err := doc.Get("$.todos[1].title")
+		assert.NoError(t, err)
+		assert.Equal(t, `"b"`, elem.Marshal())
+
+		elem, err = doc.Get(`$['todos'][1]["priority"]`)
+		assert.NoError(t, err)
+		assert.Equal(t, `3`, elem.Marshal())
+
+		elem, err = doc.Get("$")
+		assert.NoError(t, err)
+		assert.Equal(t, doc.Marshal(), elem.Marshal())
+
+		_, err = doc.Get("$.todos[3]")
+		assert.ErrorIs(t, err, json.ErrElementNotFound)
+		_, err = doc.Get("$.todos.title")
+		assert.ErrorIs(t, err, json.ErrElementNotFound)
+		_, err = doc.Get("$.title.length")
+		assert.ErrorIs(t, err, json.ErrElementNotFound)
+		_, err = doc.Get("$.none")
+		assert.ErrorIs(t, err, json.ErrElementNotFound)
+		_, err = doc.Get("todos[0]")
+		assert.ErrorIs(t, err, json.ErrInvalidPath)
+		_, err = doc.Get("$.todos[x]")
+		assert.ErrorIs(t, err, json.ErrInvalidPath)
+		_, err = doc.Get("$..title")
+		assert.ErrorIs(t, err, json.ErrInvalidPath)
+	})
+
+	t.Run("path with escaped keys test", func(t *testing.T) {
+		doc := document.New("c1", "d1")
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetNewObject(`it's [a]`).SetString(`b\'c`, "v")
+			return nil
+		})
+		assert.NoError(t, err)
+
+		path := "$" + json.KeyPathSegment(`it's [a]`) + json.KeyPathSegment(`b\'c`)
+		assert.Equal(t, `$['it\'s [a]']['b\\\'c']`, path)
+		elem, err := doc.Get(path)
+		assert.NoError(t, err)
+		assert.Equal(t, `"v"`, elem.Marshal())
+
+		elem, err = doc.Get(`$["it's [a]"]`)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"b\\'c":"v"}`, elem.Marshal())
+
+		normalized, err := json.NormalizePath(`$["it's [a]"].x`)
+		assert.NoError(t, err)
+		assert.Equal(t, `$['it\'s [a]']['x']`, normalized)
+
+		_, err = doc.Get(`$['it's [a]']`)
+		assert.ErrorIs(t, err, json.ErrInvalidPath)
+		_, err = doc.Get(`$['a\']`)
+		assert.ErrorIs(t, err, json.ErrInvalidPath)
+
+		err = doc.ImportJSON([]byte(`{"it's": "hi"}`), proxy.ImportHints{`$["it's"]`: proxy.TextHint})
+		assert.NoError(t, err)
+		elem, err = doc.Get(`$['it\'s']`)
+		assert.NoError(t, err)
+		assert.IsType(t, &json.Text{}, elem)
+	})
+
+	t.Run("struct binding test", func(t *testing.T) {
+		type Todo struct {
+			Title string `yorkie:"title"`
+			Done  bool   `yorkie:"done"`
+		}
+		type Board struct {
+			Name    string            `yorkie:"name,text"`
+			Note    string            `yorkie:"note,richtext,omitempty"`
+			Views   int               `yorkie:"views,counter"`
+			Todos   []Todo            `yorkie:"todos"`
+			Labels  map[string]string `yorkie:"labels"`
+			Owner   *Todo             `yorkie:"owner"`
+			Created gotime.Time       `yorkie:"created"`
+			Data    []byte            `yorkie:"data,omitempty"`
+			Size    uint32
+			Secret  string `yorkie:"-"`
+			hidden  string
+		}
+
+		lastOps := func(doc *document.Document) int {
+			changes := doc.CreateChangePack().Changes
+			return len(changes[len(changes)-1].Operations())
+		}
+
+		doc := document.New("c1", "d1")
+		now := gotime.Unix(gotime.Now().Unix(), 0)
+		board := Board{
+			Name:    "yorkie",
+			Views:   3,
+			Todos:   []Todo{{Title: "a"}, {Title: "b", Done: true}},
+			Labels:  map[string]string{"k": "v"},
+			Created: now,
+			Size:    2,
+			Secret:  "secret",
+			hidden:  "hidden",
+		}
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			return proxy.Marshal(root, board)
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(
+			`{"Size":2,"created":"%s","labels":{"k":"v"},"name":"yorkie","owner":null,`+
+				`"todos":[{"done":false,"title":"a"},{"done":true,"title":"b"}],"views":3}`,
+			now.Format(json.DateFormat),
+		), doc.Marshal())
+
+		// 01. Marshal the same value again: no changes are created.
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			return proxy.Marshal(root, &board)
+		})
+		assert.NoError(t, err)
+		assert.Len(t, doc.CreateChangePack().Changes, 1)
+
+		// 02. Only the differences are applied.
+		board.Name = "yorkie!"
+		board.Views = 5
+		board.Todos[1].Done = false
+		board.Todos = append(board.Todos, Todo{Title: "c"})
+		delete(board.Labels, "k")
+		board.Note = "note"
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			return proxy.Marshal(root, board)
+		})
+		assert.NoError(t, err)
+		// edit, increase, set, add with its two members, remove, and set and
+		// edit for the note.
+		assert.Equal(t, 9, lastOps(doc))
+
+		var decoded Board
+		assert.NoError(t, document.Unmarshal(doc, &decoded))
+		assert.Equal(t, "", decoded.Secret)
+		board.Secret, board.hidden = "", ""
+		assert.Equal(t, board, decoded)
+
+		var generic map[string]interface{}
+		assert.NoError(t, document.Unmarshal(doc, &generic))
+		assert.Equal(t, "yorkie!", generic["name"])
+		assert.Equal(t, 5, generic["views"])
+		assert.Len(t, generic["todos"], 3)
+
+		// 03. The styles of the rich text out of the edited range are kept.
+		board.Note = "note!"
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.GetRichText("note").SetStyle(0, 4, map[string]string{"b": "1"})
+			return proxy.Marshal(root, board)
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, lastOps(doc))
+		assert.Contains(t, doc.Marshal(), `"note":[{"attrs":{"b":"1"},"val":"note"},{"attrs":{},"val":"!"}]`)
+
+		// 04. Unsupported values and mismatched types are rejected.
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			return proxy.Marshal(root, []int{1})
+		})
+		assert.ErrorIs(t, err, proxy.ErrUnsupportedValue)
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			return proxy.Marshal(root, map[string]interface{}{"ch": make(chan int)})
+		})
+		assert.ErrorIs(t, err, proxy.ErrUnsupportedValue)
+		assert.ErrorIs(t, document.Unmarshal(doc, decoded), proxy.ErrUnsupportedValue)
+
+		var mismatched struct {
+			Name int `yorkie:"name"`
+		}
+		assert.ErrorIs(t, document.Unmarshal(doc, &mismatched), json.ErrUnexpectedType)
+	})
+
+	t.Run("import and export json test", func(t *testing.T) {
+		doc := document.New("c1", "d1")
+		err := doc.ImportJSON([]byte(`{
+			"title": "say \"hi\"\n",
+			"views": 10,
+			"big": 9000000000,
+			"ratio": 0.5,
+			"tags": ["a", null, true, {"k": []}],
+			"todos": [{"text": "a\\b"}]
+		}`), proxy.ImportHints{
+			"$.views":            proxy.CounterHint,
+			"$['todos'][0].text": proxy.TextHint,
+		})
+		assert.NoError(t, err)
+		assert.Equal(
+			t,
+			`{"big":9000000000,"ratio":0.5,"tags":["a",null,true,{"k":[]}],`+
+				`"title":"say \"hi\"\n","todos":[{"text":"a\\b"}],"views":10}`,
+			doc.Marshal(),
+		)
+
+		elem, err := doc.Get("$.views")
+		assert.NoError(t, err)
+		assert.IsType(t, &json.Counter{}, elem)
+		elem, err = doc.Get("$.todos[0].text")
+		assert.NoError(t, err)
+		assert.IsType(t, &json.Text{}, elem)
+		elem, err = doc.Get("$.big")
+		assert.NoError(t, err)
+		assert.Equal(t, json.Long, elem.(*json.Primitive).ValueType())
+
+		now := gotime.Unix(gotime.Now().Unix(), 0)
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetDate("date", now).SetBytes("bytes", []byte{0, 1, 255})
+			return root.SetFromJSON("nested", []byte(`{"a": [1, 2.5e1]}`), proxy.ImportHints{
+				"$.a[0]": proxy.CounterHint,
+			})
+		})
+		assert.NoError(t, err)
+
+		var exported map[string]interface{}
+		assert.NoError(t, gojson.Unmarshal([]byte(doc.Marshal()), &exported))
+		assert.Equal(t, now.Format(json.DateFormat), exported["date"])
+		assert.Equal(t, "AAH/", exported["bytes"])
+		assert.Equal(t, "say \"hi\"\n", exported["title"])
+		assert.Equal(t, map[string]interface{}{"a": []interface{}{float64(1), float64(25)}}, exported["nested"])
+
+		err = doc.ImportJSON([]byte(`{"a": 1`), nil)
+		assert.ErrorIs(t, err, proxy.ErrInvalidJSON)
+		err = doc.ImportJSON([]byte(`{"a": 1} {}`), nil)
+		assert.ErrorIs(t, err, proxy.ErrInvalidJSON)
+		err = doc.ImportJSON([]byte(`[1]`), nil)
+		assert.ErrorIs(t, err, json.ErrUnexpectedType)
+		err = doc.ImportJSON([]byte(`{"a": "1"}`), proxy.ImportHints{"$.a": proxy.CounterHint})
+		assert.ErrorIs(t, err, json.ErrUnexpectedType)
+		err = doc.ImportJSON([]byte(`{"a": "1"}`), proxy.ImportHints{"a": proxy.TextHint})
+		assert.ErrorIs(t, err, json.ErrInvalidPath)
+	})
+
 	t.Run("text test", func(t *testing.T) {
 		doc := document.New("c1", "d1")
 
@@ -314,8 +644,8 @@ func TestDocument(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(
 			t,
-			`{"k1":[{"attrs":{"b":"1"},"val":"Hel"},{"attrs":{"b":"1","i":"1"},"val":"lo"},{"attrs":{"list":"true"},"val":"
-"},{"attrs":{},"val":" Yorkie"}]}`,
+			`{"k1":[{"attrs":{"b":"1"},"val":"Hel"},{"attrs":{"b":"1","i":"1"},"val":"lo"},`+
+				`{"attrs":{"list":"true"},"val":"\n"},{"attrs":{},"val":" Yorkie"}]}`,
 			doc.Marshal(),
 		)
 	})
@@ -344,6 +674,22 @@ func TestDocument(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, `{"age":128}`, doc.Marshal())
 
+		// the increase is visible in the same update and the next ones.
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			assert.Equal(t, `{"age":128}`, root.Marshal())
+			root.GetCounter("age").Increase(2)
+			assert.Equal(t, `{"age":130}`, root.Marshal())
+			return nil
+		})
+		assert.NoError(t, err)
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			assert.Equal(t, `{"age":130}`, root.Marshal())
+			root.GetCounter("age").Increase(-2)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"age":128}`, doc.Marshal())
+
 		// long type test
 		err = doc.Update(func(root *proxy.ObjectProxy) error {
 			root.SetNewCounter("price", 9000000000000000000)
@@ -412,6 +758,132 @@ func TestDocument(t *testing.T) {
 		assert.Equal(t, `{"age":120,"price":9000000000000000003,"width":130.000000}`, doc.Marshal())
 	})
 
+	t.Run("tree test", func(t *testing.T) {
+		d1 := document.New("c1", "d1")
+		d2 := document.New("c1", "d1")
+		d1.SetActor(actorID(t, "000000000000000000000001"))
+		d2.SetActor(actorID(t, "000000000000000000000002"))
+
+		err := d1.Update(func(root *proxy.ObjectProxy) error {
+			root.SetNewTree("t").Insert([]int{0}, proxy.TreeNode{
+				Type:     "p",
+				Children: []proxy.TreeNode{{Type: json.TreeTextType, Value: "ab"}},
+			})
+			return nil
+		})
+		assert.NoError(t, err)
+		syncChanges(t, d1, d2)
+		assert.Equal(t, `{"t":{"type":"root","children":[{"type":"p","children":[{"type":"text","value":"ab"}]}]}}`,
+			d2.Marshal())
+
+		err = d1.Update(func(root *proxy.ObjectProxy) error {
+			root.GetTree("t").
+				Insert([]int{1}, proxy.TreeNode{Type: "h1"}).
+				Style([]int{0}, map[string]string{"align": "left"})
+			return nil
+		})
+		assert.NoError(t, err)
+		err = d2.Update(func(root *proxy.ObjectProxy) error {
+			root.GetTree("t").
+				Insert([]int{1}, proxy.TreeNode{Type: "ul"}).
+				Delete([]int{0, 0})
+			return nil
+		})
+		assert.NoError(t, err)
+		syncChanges(t, d1, d2)
+		syncChanges(t, d2, d1)
+		assert.Equal(t, `{"t":{"type":"root","children":[{"type":"p","attributes":{"align":"left"},"children":[]},`+
+			`{"type":"ul","children":[]},{"type":"h1","children":[]}]}}`, d1.Marshal())
+		assert.Equal(t, d1.Marshal(), d2.Marshal())
+
+		assert.Equal(t, 1, d1.GarbageLen())
+		assert.Equal(t, 1, d1.GarbageCollect(time.MaxTicket))
+		assert.Equal(t, 0, d1.GarbageLen())
+	})
+
+	t.Run("tree path precondition test", func(t *testing.T) {
+		doc := document.New("c1", "d1")
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetNewTree("t").Insert([]int{0},
+				proxy.TreeNode{Type: "p", Children: []proxy.TreeNode{{Type: json.TreeTextType, Value: "ab"}}},
+				proxy.TreeNode{Type: "p"},
+			)
+			return nil
+		})
+		assert.NoError(t, err)
+		expected := doc.Marshal()
+
+		for name, updater := range map[string]func(tree *proxy.TreeProxy){
+			"insert with empty path":    func(tree *proxy.TreeProxy) { tree.Insert(nil, proxy.TreeNode{Type: "p"}) },
+			"insert out of range":       func(tree *proxy.TreeProxy) { tree.Insert([]int{3}, proxy.TreeNode{Type: "p"}) },
+			"insert under missing node": func(tree *proxy.TreeProxy) { tree.Insert([]int{2, 0}, proxy.TreeNode{Type: "p"}) },
+			"insert into text node": func(tree *proxy.TreeProxy) {
+				tree.Insert([]int{0, 0, 0}, proxy.TreeNode{Type: "p"})
+			},
+			"insert root node": func(tree *proxy.TreeProxy) {
+				tree.Insert([]int{0}, proxy.TreeNode{Type: json.TreeRootType})
+			},
+			"insert text node with child": func(tree *proxy.TreeProxy) {
+				tree.Insert([]int{0}, proxy.TreeNode{Type: "p"}, proxy.TreeNode{
+					Type:     json.TreeTextType,
+					Children: []proxy.TreeNode{{Type: "p"}},
+				})
+			},
+			"delete out of range":       func(tree *proxy.TreeProxy) { tree.Delete([]int{2}) },
+			"delete root node":          func(tree *proxy.TreeProxy) { tree.Delete(nil) },
+			"move root node":            func(tree *proxy.TreeProxy) { tree.Move(nil, []int{0}) },
+			"move out of range":         func(tree *proxy.TreeProxy) { tree.Move([]int{0}, []int{3}) },
+			"move under another parent": func(tree *proxy.TreeProxy) { tree.Move([]int{1}, []int{0, 1}) },
+			"move into itself":          func(tree *proxy.TreeProxy) { tree.Move([]int{0}, []int{0, 0}) },
+			"style out of range":        func(tree *proxy.TreeProxy) { tree.Style([]int{0, 1}, nil) },
+		} {
+			assert.Panics(t, func() {
+				_ = doc.Update(func(root *proxy.ObjectProxy) error {
+					updater(root.GetTree("t"))
+					return nil
+				})
+			}, name)
+			assert.Equal(t, expected, doc.Marshal(), name)
+		}
+	})
+
+	t.Run("set test", func(t *testing.T) {
+		d1 := document.New("c1", "d1")
+		d2 := document.New("c1", "d1")
+		d1.SetActor(actorID(t, "000000000000000000000001"))
+		d2.SetActor(actorID(t, "000000000000000000000002"))
+
+		err := d1.Update(func(root *proxy.ObjectProxy) error {
+			root.SetNewSet("tags").Add("a", "b", "c")
+			return nil
+		})
+		assert.NoError(t, err)
+		syncChanges(t, d1, d2)
+		assert.Equal(t, `{"tags":["a","b","c"]}`, d2.Marshal())
+		assert.True(t, d2.Root().GetSet("tags").Has("b"))
+
+		// the concurrent addition of a value wins over its removal.
+		err = d1.Update(func(root *proxy.ObjectProxy) error {
+			root.GetSet("tags").Remove("a", "b")
+			return nil
+		})
+		assert.NoError(t, err)
+		err = d2.Update(func(root *proxy.ObjectProxy) error {
+			root.GetSet("tags").Add("a", "d")
+			return nil
+		})
+		assert.NoError(t, err)
+		syncChanges(t, d1, d2)
+		syncChanges(t, d2, d1)
+		assert.Equal(t, `{"tags":["c","a","d"]}`, d1.Marshal())
+		assert.Equal(t, d1.Marshal(), d2.Marshal())
+		assert.False(t, d1.Root().GetSet("tags").Has("b"))
+
+		assert.Equal(t, 2, d1.GarbageLen())
+		assert.Equal(t, 2, d1.GarbageCollect(time.MaxTicket))
+		assert.Equal(t, 0, d1.GarbageLen())
+	})
+
 	t.Run("rollback test", func(t *testing.T) {
 		doc := document.New("c1", "d1")
 
@@ -511,4 +983,591 @@ func TestDocument(t *testing.T) {
 		assert.Equal(t, "{}", doc.Marshal())
 		assert.Equal(t, 0, doc.GarbageLen())
 	})
+
+	t.Run("subscribe test", func(t *testing.T) {
+		d1 := document.New("c1", "d1")
+		d2 := document.New("c1", "d1")
+		d1.SetActor(actorID(t, "000000000000000000000001"))
+		d2.SetActor(actorID(t, "000000000000000000000002"))
+
+		var events1, events2 []document.Event
+		d1.Subscribe(func(event document.Event) {
+			events1 = append(events1, event)
+		})
+		unsubscribe := d2.Subscribe(func(event document.Event) {
+			events2 = append(events2, event)
+		})
+
+		err := d1.Update(func(root *proxy.ObjectProxy) error {
+			root.SetNewArray("todos").AddString("a", "b")
+			root.SetNewText("k.1").Edit(0, 0, "hello")
+			root.SetNewCounter("cnt", 0)
+			return nil
+		})
+		assert.NoError(t, err)
+		syncChanges(t, d1, d2)
+
+		err = d1.Update(func(root *proxy.ObjectProxy) error {
+			root.GetArray("todos").Delete(0)
+			root.GetText("k.1").Edit(1, 3, "EL")
+			root.GetCounter("cnt").Increase(2)
+			return nil
+		})
+		assert.NoError(t, err)
+		syncChanges(t, d1, d2)
+
+		expected := []document.Event{
+			{Type: document.SetEvent, Path: "$.todos"},
+			{Type: document.AddEvent, Path: "$.todos[0]"},
+			{Type: document.AddEvent, Path: "$.todos[1]"},
+			{Type: document.SetEvent, Path: "$['k.1']"},
+			{Type: document.EditEvent, Path: "$['k.1']", Content: "hello"},
+			{Type: document.SetEvent, Path: "$.cnt"},
+			{Type: document.RemoveEvent, Path: "$.todos[0]"},
+			{Type: document.EditEvent, Path: "$['k.1']", From: 1, To: 3, Content: "EL"},
+			{Type: document.IncreaseEvent, Path: "$.cnt"},
+		}
+		assert.Len(t, events1, len(expected))
+		assert.Len(t, events2, len(expected))
+		for i, event := range expected {
+			event.Actor = d1.Actor()
+			event.Local = true
+			assert.Equal(t, event, events1[i])
+			event.Local = false
+			assert.Equal(t, event, events2[i])
+		}
+		assert.Equal(t, `{"cnt":2,"k.1":"hELlo","todos":["b"]}`, d2.Marshal())
+
+		unsubscribe()
+		err = d1.Update(func(root *proxy.ObjectProxy) error {
+			root.Delete("cnt")
+			return nil
+		})
+		assert.NoError(t, err)
+		syncChanges(t, d1, d2)
+		assert.Len(t, events1, len(expected)+1)
+		assert.Len(t, events2, len(expected))
+	})
+
+	t.Run("update in subscription test", func(t *testing.T) {
+		doc := document.New("c1", "d1")
+
+		var paths []string
+		doc.Subscribe(func(event document.Event) {
+			paths = append(paths, event.Path)
+			if event.Type == document.SetEvent && event.Path == "$.a" {
+				assert.NoError(t, doc.Update(func(root *proxy.ObjectProxy) error {
+					root.SetString("c", "3")
+					return nil
+				}))
+			}
+		})
+
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetString("a", "1").SetString("b", "2")
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"$.a", "$.b", "$.c"}, paths)
+		assert.Equal(t, `{"a":"1","b":"2","c":"3"}`, doc.Marshal())
+
+		// the update in the callback is undone before the update causing it.
+		assert.NoError(t, doc.Undo())
+		assert.Equal(t, `{"a":"1","b":"2"}`, doc.Marshal())
+		assert.NoError(t, doc.Undo())
+		assert.Equal(t, `{}`, doc.Marshal())
+	})
+
+	t.Run("schema test", func(t *testing.T) {
+		s, err := schema.NewJSONSchema([]byte(
+			`{"properties": {"todos": {"type": "array", "items": {"required": ["title"]}}}}`,
+		))
+		assert.NoError(t, err)
+
+		doc := document.New("c1", "d1")
+		doc.SetSchema(s)
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetNewArray("todos").AddNewObject().SetNewText("title").Edit(0, 0, "a")
+			return nil
+		})
+		assert.NoError(t, err)
+
+		// 01. the update violating the schema is not applied.
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetNewArray("todos").AddNewObject()
+			return nil
+		})
+		assert.ErrorIs(t, err, schema.ErrViolation)
+		assert.Equal(t, `{"todos":[{"title":"a"}]}`, doc.Marshal())
+		assert.Len(t, doc.CreateChangePack().Changes, 1)
+
+		err = doc.ImportJSON([]byte(`{"todos": [{"done": true}]}`), nil)
+		assert.ErrorIs(t, err, schema.ErrViolation)
+
+		// 02. the document can be updated again after the violation.
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.GetArray("todos").AddNewObject().SetString("title", "b")
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"todos":[{"title":"a"},{"title":"b"}]}`, doc.Marshal())
+	})
+}
+
+func TestUndoRedo(t *testing.T) {
+	t.Run("object undo redo test", func(t *testing.T) {
+		doc := document.New("c1", "d1")
+		assert.False(t, doc.CanUndo())
+		assert.ErrorIs(t, doc.Undo(), document.ErrNothingToUndo)
+
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetString("k1", "v1")
+			root.SetNewObject("k2").SetString("k3", "v3")
+			return nil
+		})
+		assert.NoError(t, err)
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetString("k1", "v2")
+			root.Delete("k2")
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"k1":"v2"}`, doc.Marshal())
+
+		assert.NoError(t, doc.Undo())
+		assert.Equal(t, `{"k1":"v1","k2":{"k3":"v3"}}`, doc.Marshal())
+		assert.NoError(t, doc.Undo())
+		assert.Equal(t, `{}`, doc.Marshal())
+		assert.False(t, doc.CanUndo())
+
+		assert.NoError(t, doc.Redo())
+		assert.Equal(t, `{"k1":"v1","k2":{"k3":"v3"}}`, doc.Marshal())
+		assert.NoError(t, doc.Redo())
+		assert.Equal(t, `{"k1":"v2"}`, doc.Marshal())
+		assert.False(t, doc.CanRedo())
+		assert.ErrorIs(t, doc.Redo(), document.ErrNothingToRedo)
+
+		assert.NoError(t, doc.Undo())
+		assert.NoError(t, doc.Undo())
+		assert.Equal(t, `{}`, doc.Marshal())
+		assert.Equal(t, doc.Marshal(), doc.RootObject().Marshal())
+	})
+
+	t.Run("new update clears redo test", func(t *testing.T) {
+		doc := document.New("c1", "d1")
+
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetString("k1", "v1")
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, doc.Undo())
+		assert.True(t, doc.CanRedo())
+
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetString("k2", "v2")
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.False(t, doc.CanRedo())
+		assert.Equal(t, `{"k2":"v2"}`, doc.Marshal())
+	})
+
+	t.Run("array undo redo test", func(t *testing.T) {
+		doc := document.New("c1", "d1")
+
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetNewArray("k1").AddInteger(1, 2, 3)
+			return nil
+		})
+		assert.NoError(t, err)
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.GetArray("k1").Delete(1)
+			return nil
+		})
+		assert.NoError(t, err)
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			arr := root.GetArray("k1")
+			arr.MoveBefore(arr.Get(0).CreatedAt(), arr.Get(1).CreatedAt())
+			return nil
+		})
+		assert.NoError(t, err)
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.GetArray("k1").AddInteger(4)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"k1":[3,1,4]}`, doc.Marshal())
+
+		assert.NoError(t, doc.Undo())
+		assert.Equal(t, `{"k1":[3,1]}`, doc.Marshal())
+		assert.NoError(t, doc.Undo())
+		assert.Equal(t, `{"k1":[1,3]}`, doc.Marshal())
+		assert.NoError(t, doc.Undo())
+		assert.Equal(t, `{"k1":[1,2,3]}`, doc.Marshal())
+
+		assert.NoError(t, doc.Redo())
+		assert.Equal(t, `{"k1":[1,3]}`, doc.Marshal())
+		assert.NoError(t, doc.Redo())
+		assert.Equal(t, `{"k1":[3,1]}`, doc.Marshal())
+		assert.NoError(t, doc.Redo())
+		assert.Equal(t, `{"k1":[3,1,4]}`, doc.Marshal())
+	})
+
+	t.Run("text undo redo test", func(t *testing.T) {
+		doc := document.New("c1", "d1")
+
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetNewText("k1").Edit(0, 0, "ABCD")
+			return nil
+		})
+		assert.NoError(t, err)
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.GetText("k1").Edit(1, 3, "12")
+			return nil
+		})
+		assert.NoError(t, err)
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.GetText("k1").Edit(4, 4, "E")
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"k1":"A12DE"}`, doc.Marshal())
+
+		assert.NoError(t, doc.Undo())
+		assert.Equal(t, `{"k1":"A12D"}`, doc.Marshal())
+		assert.NoError(t, doc.Undo())
+		assert.Equal(t, `{"k1":"ABCD"}`, doc.Marshal())
+		assert.NoError(t, doc.Undo())
+		assert.Equal(t, `{}`, doc.Marshal())
+
+		assert.NoError(t, doc.Redo())
+		assert.Equal(t, `{"k1":"ABCD"}`, doc.Marshal())
+		assert.NoError(t, doc.Redo())
+		assert.Equal(t, `{"k1":"A12D"}`, doc.Marshal())
+		assert.NoError(t, doc.Redo())
+		assert.Equal(t, `{"k1":"A12DE"}`, doc.Marshal())
+		assert.Equal(t, doc.Marshal(), doc.RootObject().Marshal())
+	})
+
+	t.Run("rich text undo redo test", func(t *testing.T) {
+		doc := document.New("c1", "d1")
+
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetNewRichText("k1").Edit(0, 0, "Hello world", map[string]string{"b": "1"})
+			return nil
+		})
+		assert.NoError(t, err)
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.GetRichText("k1").SetStyle(0, 5, map[string]string{"b": "0"})
+			return nil
+		})
+		assert.NoError(t, err)
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.GetRichText("k1").Edit(5, 11, " yorkie", nil)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(
+			t,
+			`{"k1":[{"attrs":{"b":"0"},"val":"Hello"},{"attrs":{},"val":" yorkie"}]}`,
+			doc.Marshal(),
+		)
+
+		assert.NoError(t, doc.Undo())
+		assert.Equal(
+			t,
+			`{"k1":[{"attrs":{"b":"0"},"val":"Hello"},{"attrs":{"b":"1"},"val":" world"}]}`,
+			doc.Marshal(),
+		)
+		assert.NoError(t, doc.Undo())
+		assert.Equal(
+			t,
+			`{"k1":[{"attrs":{"b":"1"},"val":"Hello"},{"attrs":{"b":"1"},"val":" world"}]}`,
+			doc.Marshal(),
+		)
+
+		assert.NoError(t, doc.Redo())
+		assert.NoError(t, doc.Redo())
+		assert.Equal(
+			t,
+			`{"k1":[{"attrs":{"b":"0"},"val":"Hello"},{"attrs":{},"val":" yorkie"}]}`,
+			doc.Marshal(),
+		)
+		assert.Equal(t, doc.Marshal(), doc.RootObject().Marshal())
+	})
+
+	t.Run("counter undo redo test", func(t *testing.T) {
+		doc := document.New("c1", "d1")
+
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetNewCounter("k1", 10)
+			return nil
+		})
+		assert.NoError(t, err)
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.GetCounter("k1").Increase(5).Increase(3)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"k1":18}`, doc.Marshal())
+
+		assert.NoError(t, doc.Undo())
+		assert.Equal(t, `{"k1":10}`, doc.Marshal())
+		assert.NoError(t, doc.Redo())
+		assert.Equal(t, `{"k1":18}`, doc.Marshal())
+	})
+
+	t.Run("set undo redo test", func(t *testing.T) {
+		doc := document.New("c1", "d1")
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetNewSet("k1").Add("a", "b")
+			return nil
+		})
+		assert.NoError(t, err)
+
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.GetSet("k1").Remove("a").Add("c")
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"k1":["b","c"]}`, doc.Marshal())
+
+		// the restored value is added again, so it comes last.
+		assert.NoError(t, doc.Undo())
+		assert.Equal(t, `{"k1":["b","a"]}`, doc.Marshal())
+
+		assert.NoError(t, doc.Redo())
+		assert.Equal(t, `{"k1":["b","c"]}`, doc.Marshal())
+	})
+
+	t.Run("undo after remote changes test", func(t *testing.T) {
+		d1 := document.New("c1", "d1")
+		d2 := document.New("c1", "d1")
+		d1.SetActor(actorID(t, "000000000000000000000001"))
+		d2.SetActor(actorID(t, "000000000000000000000002"))
+
+		err := d1.Update(func(root *proxy.ObjectProxy) error {
+			root.SetNewText("k1").Edit(0, 0, "abc")
+			root.SetNewArray("k2").AddInteger(1, 2, 3)
+			root.SetString("k3", "v1")
+			return nil
+		})
+		assert.NoError(t, err)
+		syncChanges(t, d1, d2)
+
+		err = d1.Update(func(root *proxy.ObjectProxy) error {
+			root.GetText("k1").Edit(3, 3, "d")
+			root.GetArray("k2").Delete(1)
+			root.SetString("k3", "v2")
+			return nil
+		})
+		assert.NoError(t, err)
+		err = d2.Update(func(root *proxy.ObjectProxy) error {
+			root.GetText("k1").Edit(0, 0, "X")
+			root.GetArray("k2").InsertIntegerAfter(0, 9)
+			return nil
+		})
+		assert.NoError(t, err)
+		syncChanges(t, d1, d2)
+		syncChanges(t, d2, d1)
+		assert.Equal(t, `{"k1":"Xabcd","k2":[1,9,3],"k3":"v2"}`, d1.Marshal())
+		assert.Equal(t, d1.Marshal(), d2.Marshal())
+
+		assert.NoError(t, d1.Undo())
+		assert.Equal(t, `{"k1":"Xabc","k2":[1,2,9,3],"k3":"v1"}`, d1.Marshal())
+		syncChanges(t, d1, d2)
+		assert.Equal(t, d1.Marshal(), d2.Marshal())
+
+		// the value overwritten by others is not reverted.
+		err = d2.Update(func(root *proxy.ObjectProxy) error {
+			root.SetString("k3", "v3")
+			return nil
+		})
+		assert.NoError(t, err)
+		syncChanges(t, d2, d1)
+
+		assert.NoError(t, d1.Redo())
+		assert.Equal(t, `{"k1":"Xabcd","k2":[1,9,3],"k3":"v3"}`, d1.Marshal())
+		syncChanges(t, d1, d2)
+		assert.Equal(t, d1.Marshal(), d2.Marshal())
+	})
+
+	t.Run("undo redo with schema test", func(t *testing.T) {
+		doc := document.New("c1", "d1")
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetString("k", "a")
+			return nil
+		})
+		assert.NoError(t, err)
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetInteger("k", 1)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		s, err := schema.NewJSONSchema([]byte(`{"properties": {"k": {"type": "integer"}}}`))
+		assert.NoError(t, err)
+		doc.SetSchema(s)
+
+		// 01. the undo violating the schema is not applied.
+		assert.ErrorIs(t, doc.Undo(), schema.ErrViolation)
+		assert.Equal(t, `{"k":1}`, doc.Marshal())
+		assert.Len(t, doc.CreateChangePack().Changes, 2)
+		assert.True(t, doc.CanUndo())
+
+		// 02. the redo violating the schema is not applied.
+		doc.SetSchema(nil)
+		assert.NoError(t, doc.Undo())
+		assert.Equal(t, `{"k":"a"}`, doc.Marshal())
+		s, err = schema.NewJSONSchema([]byte(`{"properties": {"k": {"type": "string"}}}`))
+		assert.NoError(t, err)
+		doc.SetSchema(s)
+		assert.ErrorIs(t, doc.Redo(), schema.ErrViolation)
+		assert.Equal(t, `{"k":"a"}`, doc.Marshal())
+		assert.True(t, doc.CanRedo())
+
+		// 03. the document can be updated again after the violation.
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetString("k", "b")
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"k":"b"}`, doc.Marshal())
+	})
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("create diff change test", func(t *testing.T) {
+		doc := document.New("c1", "d1")
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetString("k1", "v1")
+			root.SetNewObject("k2").SetInteger("a", 1)
+			root.SetNewArray("k3").AddInteger(1, 2, 3)
+			root.SetNewText("k4").Edit(0, 0, "hello world")
+			root.SetNewRichText("k5").Edit(0, 0, "hello", map[string]string{"b": "1"})
+			root.SetNewCounter("k6", 10)
+			root.SetNewTree("k8").Insert([]int{0}, proxy.TreeNode{Type: "p"})
+			root.SetNewSet("k9").Add("a", "b")
+			return nil
+		}, "initializes")
+		assert.NoError(t, err)
+
+		past := snapshotOf(t, doc)
+		expected := doc.Marshal()
+
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.Delete("k1")
+			root.SetString("k7", "v7")
+			root.GetObject("k2").SetInteger("a", 2)
+			root.GetObject("k2").SetInteger("b", 3)
+			arr := root.GetArray("k3")
+			arr.Delete(1)
+			arr.AddInteger(4)
+			arr.MoveBefore(arr.Get(0).CreatedAt(), arr.Get(1).CreatedAt())
+			root.GetText("k4").Edit(6, 11, "yorkie")
+			root.GetRichText("k5").Edit(0, 5, "world", nil)
+			root.GetCounter("k6").Increase(5)
+			root.GetTree("k8").Insert([]int{1}, proxy.TreeNode{Type: "h1"}).Delete([]int{0})
+			root.GetSet("k9").Remove("b").Add("c")
+			return nil
+		}, "updates")
+		assert.NoError(t, err)
+		assert.NotEqual(t, expected, doc.Marshal())
+
+		internal, err := document.NewInternalDocumentFromSnapshot("c1", "d1", 1, snapshotBytesOf(t, doc))
+		assert.NoError(t, err)
+
+		id := change.NewID(1, 100, actorID(t, "000000000000000000000002"))
+		c := internal.CreateDiffChange(id, "reverts", past)
+		assert.NotNil(t, c)
+
+		pack := change.NewPack(doc.Key(), checkpoint.Initial, []*change.Change{c}, nil)
+		pack.MinSyncedTicket = time.InitialTicket
+		assert.NoError(t, doc.ApplyChangePack(pack))
+		assert.Equal(t, expected, doc.Marshal())
+
+		internal, err = document.NewInternalDocumentFromSnapshot("c1", "d1", 1, snapshotBytesOf(t, doc))
+		assert.NoError(t, err)
+		assert.Nil(t, internal.CreateDiffChange(id.Next(), "reverts again", snapshotOf(t, doc)))
+	})
+
+	t.Run("create minimal diff change test", func(t *testing.T) {
+		doc := document.New("c1", "d1")
+		err := doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetNewRichText("k1").
+				Edit(0, 0, "hello ", map[string]string{"b": "1"}).
+				Edit(6, 6, "world", nil)
+			root.SetNewTree("k2").Insert(
+				[]int{0},
+				proxy.TreeNode{Type: "p", Children: []proxy.TreeNode{{Type: json.TreeTextType, Value: "a"}}},
+				proxy.TreeNode{Type: "p", Children: []proxy.TreeNode{{Type: json.TreeTextType, Value: "b"}}},
+			)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		past := snapshotOf(t, doc)
+		expected := doc.Marshal()
+
+		err = doc.Update(func(root *proxy.ObjectProxy) error {
+			root.GetRichText("k1").Edit(6, 11, "yorkie", nil)
+			root.GetTree("k2").
+				Insert([]int{1, 1}, proxy.TreeNode{Type: json.TreeTextType, Value: "c"}).
+				Style([]int{0}, map[string]string{"align": "left"})
+			return nil
+		})
+		assert.NoError(t, err)
+
+		internal, err := document.NewInternalDocumentFromSnapshot("c1", "d1", 1, snapshotBytesOf(t, doc))
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), internal.MaxLamport())
+
+		// NOTE: The rich text gets an edit of the changed word, and
+		// the tree gets a removal of the inserted text node. The attribute of
+		// the first paragraph cannot be removed, so the paragraph is replaced.
+		id := change.NewID(1, internal.MaxLamport()+1, actorID(t, "000000000000000000000002"))
+		c := internal.CreateDiffChange(id, "reverts", past)
+		assert.NotNil(t, c)
+		assert.Len(t, c.Operations(), 4)
+
+		pack := change.NewPack(doc.Key(), checkpoint.Initial, []*change.Change{c}, nil)
+		pack.MinSyncedTicket = time.InitialTicket
+		assert.NoError(t, doc.ApplyChangePack(pack))
+		assert.Equal(t, expected, doc.Marshal())
+	})
+}
+
+func actorID(t *testing.T, hex string) *time.ActorID {
+	id, err := time.ActorIDFromHex(hex)
+	assert.NoError(t, err)
+	return id
+}
+
+// snapshotOf returns a copy of the root of the given document taken through
+// the snapshot encoding.
+func snapshotOf(t *testing.T, doc *document.Document) *json.Object {
+	obj, err := converter.BytesToObject(snapshotBytesOf(t, doc))
+	assert.NoError(t, err)
+	return obj
+}
+
+func snapshotBytesOf(t *testing.T, doc *document.Document) []byte {
+	bytes, err := converter.ObjectToBytes(doc.RootObject())
+	assert.NoError(t, err)
+	return bytes
+}
+
+// syncChanges applies the local changes of the given source to the target
+// and removes them from the source as if they were pushed to the agent.
+func syncChanges(t *testing.T, from, to *document.Document) {
+	pack := from.CreateChangePack()
+
+	remotePack := change.NewPack(to.Key(), checkpoint.Initial, pack.Changes, nil)
+	remotePack.MinSyncedTicket = time.InitialTicket
+	assert.NoError(t, to.ApplyChangePack(remotePack))
+
+	ackPack := change.NewPack(from.Key(), pack.Checkpoint, nil, nil)
+	ackPack.MinSyncedTicket = time.InitialTicket
+	assert.NoError(t, from.ApplyChangePack(ackPack))
 }
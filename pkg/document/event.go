@@ -0,0 +1,238 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/pkg/document/operation"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+)
+
+// EventType represents the type of the change that an Event reports.
+type EventType string
+
+// The values below are the types of Event.
+const (
+	SetEvent      EventType = "set"
+	AddEvent      EventType = "add"
+	MoveEvent     EventType = "move"
+	RemoveEvent   EventType = "remove"
+	EditEvent     EventType = "edit"
+	StyleEvent    EventType = "style"
+	SelectEvent   EventType = "select"
+	IncreaseEvent EventType = "increase"
+	TreeEditEvent EventType = "tree-edit"
+
+	// SnapshotEvent is delivered when the whole document is replaced with a
+	// snapshot, and its path is the root.
+	SnapshotEvent EventType = "snapshot"
+)
+
+// Event represents a change of an element of the document made by an
+// operation.
+type Event struct {
+	Type EventType
+
+	// Path is the path of the changed element such as `$.todos[2]`. The path
+	// of a removed element is the one before the removal, and the path of an
+	// element of Set is the one of the Set.
+	Path string
+
+	// Actor is the actor who made the change.
+	Actor *time.ActorID
+
+	// Local is whether the change is made by this document or not.
+	Local bool
+
+	// From and To are the range of the text to be edited, styled or selected.
+	// They are the indexes of the text before the change.
+	From int
+	To   int
+
+	// Content is the content inserted by the edit of the text.
+	Content string
+}
+
+// subscription is a callback subscribing the events of a document.
+type subscription struct {
+	id       int
+	callback func(Event)
+}
+
+// textRangeOperation is an operation on a range of Text or RichText.
+type textRangeOperation interface {
+	From() *json.RGATreeSplitNodePos
+	To() *json.RGATreeSplitNodePos
+}
+
+// executeWithEvents executes the given change on the given root and records
+// the events of its operations with the given recorder.
+func executeWithEvents(c *change.Change, root *json.Root, recorder *eventRecorder) error {
+	for _, op := range c.Operations() {
+		op := op
+		if err := recorder.execute(op, func() error {
+			return op.Execute(root)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eventRecorder records the events of the operations executed on a root.
+// The methods of nil eventRecorder execute the operations without recording.
+type eventRecorder struct {
+	root   *json.Root
+	local  bool
+	events []Event
+}
+
+// execute executes the given operation by the given function and records
+// the event of it.
+func (r *eventRecorder) execute(op operation.Operation, execute func() error) error {
+	if r == nil {
+		return execute()
+	}
+
+	event := Event{
+		Actor: op.ExecutedAt().ActorID(),
+		Local: r.local,
+	}
+
+	// 01. Record the path and the range before the execution.
+	removedPath, removedFound := "", false
+	if remove, ok := op.(*operation.Remove); ok {
+		removedPath, removedFound = r.pathOf(remove.ParentCreatedAt(), remove.CreatedAt())
+	}
+	if rangeOp, ok := op.(textRangeOperation); ok {
+		event.From, event.To = r.rangeOf(op.ParentCreatedAt(), rangeOp.From(), rangeOp.To())
+	}
+
+	if err := execute(); err != nil {
+		return err
+	}
+
+	// 02. Record the type and the path after the execution.
+	var found bool
+	switch op := op.(type) {
+	case *operation.Set:
+		event.Type = SetEvent
+		event.Path, found = r.pathOf(op.ParentCreatedAt(), op.Value().CreatedAt())
+	case *operation.Add:
+		event.Type = AddEvent
+		event.Path, found = r.pathOf(op.ParentCreatedAt(), op.Value().CreatedAt())
+	case *operation.Move:
+		event.Type = MoveEvent
+		event.Path, found = r.pathOf(op.ParentCreatedAt(), op.CreatedAt())
+	case *operation.Remove:
+		event.Type = RemoveEvent
+		event.Path, found = removedPath, removedFound
+	case *operation.Edit:
+		event.Type = EditEvent
+		event.Content = op.Content()
+		event.Path, found = r.pathOf(op.ParentCreatedAt(), op.ParentCreatedAt())
+	case *operation.RichEdit:
+		event.Type = EditEvent
+		event.Content = op.Content()
+		event.Path, found = r.pathOf(op.ParentCreatedAt(), op.ParentCreatedAt())
+	case *operation.Style:
+		event.Type = StyleEvent
+		event.Path, found = r.pathOf(op.ParentCreatedAt(), op.ParentCreatedAt())
+	case *operation.Select:
+		event.Type = SelectEvent
+		event.Path, found = r.pathOf(op.ParentCreatedAt(), op.ParentCreatedAt())
+	case *operation.Increase:
+		event.Type = IncreaseEvent
+		event.Path, found = r.pathOf(op.ParentCreatedAt(), op.ParentCreatedAt())
+	case *operation.TreeInsert, *operation.TreeRemove, *operation.TreeMove:
+		event.Type = TreeEditEvent
+		event.Path, found = r.pathOf(op.ParentCreatedAt(), op.ParentCreatedAt())
+	case *operation.TreeStyle:
+		event.Type = StyleEvent
+		event.Path, found = r.pathOf(op.ParentCreatedAt(), op.ParentCreatedAt())
+	}
+
+	// NOTE: An operation on an element that is not reachable from
+	// the root, such as a set that lost to a concurrent set, changes nothing
+	// visible, so it has no event.
+	if found {
+		r.events = append(r.events, event)
+	}
+	return nil
+}
+
+// pathOf returns the path of the element of the given creation time under
+// the given parent. The elements of Set have the path of the Set. The path is
+// built by walking up from the element to the root object through the
+// parents registered in the root.
+func (r *eventRecorder) pathOf(parentCreatedAt, createdAt *time.Ticket) (string, bool) {
+	if _, ok := r.root.FindByCreatedAt(parentCreatedAt).(*json.Set); ok {
+		createdAt = parentCreatedAt
+	}
+
+	var segments []string
+	rootCreatedAt := r.root.Object().CreatedAt()
+	for createdAt.Compare(rootCreatedAt) != 0 {
+		parent := r.root.FindParentByCreatedAt(createdAt)
+
+		var segment string
+		switch parent := parent.(type) {
+		case *json.Object:
+			key, ok := parent.KeyOf(createdAt)
+			if !ok {
+				return "", false
+			}
+			segment = json.KeyPathSegment(key)
+		case *json.Array:
+			index := parent.IndexOf(createdAt)
+			if index < 0 {
+				return "", false
+			}
+			segment = fmt.Sprintf("[%d]", index)
+		default:
+			return "", false
+		}
+
+		segments = append(segments, segment)
+		createdAt = parent.CreatedAt()
+	}
+
+	var sb strings.Builder
+	sb.WriteString("$")
+	for i := len(segments) - 1; i >= 0; i-- {
+		sb.WriteString(segments[i])
+	}
+	return sb.String(), true
+}
+
+// rangeOf returns the indexes of the given range of the text.
+func (r *eventRecorder) rangeOf(
+	textCreatedAt *time.Ticket,
+	from, to *json.RGATreeSplitNodePos,
+) (int, int) {
+	switch text := r.root.FindByCreatedAt(textCreatedAt).(type) {
+	case *json.Text:
+		return text.IndexOf(from), text.IndexOf(to)
+	case *json.RichText:
+		return text.IndexOf(from), text.IndexOf(to)
+	}
+	return 0, 0
+}
@@ -0,0 +1,1172 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/pkg/document/operation"
+	"github.com/yorkie-team/yorkie/pkg/document/proxy"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+)
+
+var (
+	// ErrNothingToUndo is returned when there is no local update to undo.
+	ErrNothingToUndo = errors.New("nothing to undo")
+
+	// ErrNothingToRedo is returned when there is no undone update to redo.
+	ErrNothingToRedo = errors.New("nothing to redo")
+)
+
+// reverse is the reverse of an operation executed by the local actor.
+//
+// A reverse refers to elements and text nodes by their creation time, not by
+// their index, and it generates new operations against the latest state of
+// the document when it is applied. This keeps undo and redo correct even
+// after remote changes have been applied in the meantime.
+type reverse interface {
+	// apply generates the operations that revert the original operation into
+	// the given context. The operations are also applied to the given root.
+	apply(h *history, ctx *change.Context, root *json.Root)
+}
+
+// history holds the reverses of the local updates to undo and redo them.
+// Each group of reverses corresponds to a single call of Update, Undo or Redo.
+type history struct {
+	undoStack [][]reverse
+	redoStack [][]reverse
+
+	// restoredMap maps the creation time of an element removed by the local
+	// actor to the creation time of the element that restored it.
+	restoredMap map[string]*time.Ticket
+
+	// restoredTexts maps the creation time of a restored Text or RichText to
+	// the segments that map its characters to the characters of the copy.
+	restoredTexts map[string][]textSegment
+}
+
+func newHistory() *history {
+	return &history{
+		restoredMap:   make(map[string]*time.Ticket),
+		restoredTexts: make(map[string][]textSegment),
+	}
+}
+
+// pushUndo pushes the given group into the undo stack. A new update
+// invalidates the undone updates, so it also clears the redo stack.
+func (h *history) pushUndo(group []reverse, clearRedo bool) {
+	if len(group) == 0 {
+		return
+	}
+
+	h.undoStack = append(h.undoStack, group)
+	if clearRedo {
+		h.redoStack = nil
+	}
+}
+
+// pushRedo pushes the given group into the redo stack.
+func (h *history) pushRedo(group []reverse) {
+	if len(group) == 0 {
+		return
+	}
+
+	h.redoStack = append(h.redoStack, group)
+}
+
+// popUndo pops the last group from the undo stack.
+func (h *history) popUndo() ([]reverse, bool) {
+	if len(h.undoStack) == 0 {
+		return nil, false
+	}
+
+	group := h.undoStack[len(h.undoStack)-1]
+	h.undoStack = h.undoStack[:len(h.undoStack)-1]
+	return group, true
+}
+
+// popRedo pops the last group from the redo stack.
+func (h *history) popRedo() ([]reverse, bool) {
+	if len(h.redoStack) == 0 {
+		return nil, false
+	}
+
+	group := h.redoStack[len(h.redoStack)-1]
+	h.redoStack = h.redoStack[:len(h.redoStack)-1]
+	return group, true
+}
+
+// restoredState is a copy of the restored elements of history.
+type restoredState struct {
+	restoredMap   map[string]*time.Ticket
+	restoredTexts map[string][]textSegment
+}
+
+// saveRestored returns a copy of the restored elements so that they can be
+// rolled back when reverses fail to be applied.
+func (h *history) saveRestored() *restoredState {
+	state := &restoredState{
+		restoredMap:   make(map[string]*time.Ticket, len(h.restoredMap)),
+		restoredTexts: make(map[string][]textSegment, len(h.restoredTexts)),
+	}
+	for k, v := range h.restoredMap {
+		state.restoredMap[k] = v
+	}
+	for k, v := range h.restoredTexts {
+		state.restoredTexts[k] = v
+	}
+	return state
+}
+
+// loadRestored rolls back the restored elements to the given state.
+func (h *history) loadRestored(state *restoredState) {
+	h.restoredMap = state.restoredMap
+	h.restoredTexts = state.restoredTexts
+}
+
+// resolve returns the creation time of the element that currently stands for
+// the element of the given creation time.
+func (h *history) resolve(createdAt *time.Ticket) *time.Ticket {
+	for {
+		restoredAt, ok := h.restoredMap[createdAt.Key()]
+		if !ok {
+			return createdAt
+		}
+		createdAt = restoredAt
+	}
+}
+
+// find returns the element that currently stands for the element of the
+// given creation time. It returns nil if the element has been removed.
+func (h *history) find(root *json.Root, createdAt *time.Ticket) json.Element {
+	elem := root.FindByCreatedAt(h.resolve(createdAt))
+	if elem == nil || elem.RemovedAt() != nil {
+		return nil
+	}
+
+	return elem
+}
+
+// findPrev returns the creation time of the element that currently stands
+// for the given previous element. If the previous element has been purged,
+// it returns the creation time of the head of the array.
+func (h *history) findPrev(root *json.Root, prevCreatedAt *time.Ticket) *time.Ticket {
+	if prevCreatedAt.Compare(time.InitialTicket) == 0 {
+		return prevCreatedAt
+	}
+
+	prevCreatedAt = h.resolve(prevCreatedAt)
+	if root.FindByCreatedAt(prevCreatedAt) == nil {
+		return time.InitialTicket
+	}
+
+	return prevCreatedAt
+}
+
+// setElement sets a copy of the given element to the given key of the object
+// with new time tickets.
+func (h *history) setElement(ctx *change.Context, obj *json.Object, k string, elem json.Element) {
+	ticket := ctx.IssueTimeTicket()
+	value := newElement(elem, ticket)
+
+	ctx.Push(operation.NewSet(
+		obj.CreatedAt(),
+		k,
+		value.DeepCopy(),
+		ticket,
+	))
+
+	removed := obj.Set(k, value)
+	ctx.RegisterElement(obj, value)
+	if removed != nil {
+		ctx.RegisterRemovedElementPair(obj, removed)
+	}
+
+	h.fill(ctx, elem, value)
+}
+
+// insertElement inserts a copy of the given element after the given previous
+// element of the array with new time tickets and returns the copy.
+func (h *history) insertElement(
+	ctx *change.Context,
+	arr *json.Array,
+	prevCreatedAt *time.Ticket,
+	elem json.Element,
+) json.Element {
+	ticket := ctx.IssueTimeTicket()
+	value := newElement(elem, ticket)
+
+	ctx.Push(operation.NewAdd(
+		arr.CreatedAt(),
+		prevCreatedAt,
+		value.DeepCopy(),
+		ticket,
+	))
+
+	arr.InsertAfter(prevCreatedAt, value)
+	ctx.RegisterElement(arr, value)
+
+	h.fill(ctx, elem, value)
+	return value
+}
+
+// addElement adds a copy of the given element to the set with a new time
+// ticket.
+func (h *history) addElement(ctx *change.Context, set *json.Set, elem *json.Primitive) {
+	ticket := ctx.IssueTimeTicket()
+	value := newElement(elem, ticket)
+
+	ctx.Push(operation.NewAdd(
+		set.CreatedAt(),
+		nil,
+		value.DeepCopy(),
+		ticket,
+	))
+
+	set.Add(value.(*json.Primitive))
+	ctx.RegisterElement(set, value)
+
+	h.fill(ctx, elem, value)
+}
+
+// removeElement removes the given element from the given container.
+func (h *history) removeElement(ctx *change.Context, parent json.Container, elem json.Element) {
+	ticket := ctx.IssueTimeTicket()
+	removed := parent.DeleteByCreatedAt(elem.CreatedAt(), ticket)
+	if removed == nil {
+		return
+	}
+
+	ctx.Push(operation.NewRemove(
+		parent.CreatedAt(),
+		elem.CreatedAt(),
+		ticket,
+	))
+	ctx.RegisterRemovedElementPair(parent, removed)
+}
+
+// fill copies the contents of the given original element into the given
+// element created by newElement and records that the original was restored.
+func (h *history) fill(ctx *change.Context, orig json.Element, value json.Element) {
+	h.restoredMap[orig.CreatedAt().Key()] = value.CreatedAt()
+
+	switch orig := orig.(type) {
+	case *json.Object:
+		obj := value.(*json.Object)
+		for _, node := range orig.RHTNodes() {
+			if node.Element().RemovedAt() != nil {
+				continue
+			}
+			h.setElement(ctx, obj, node.Key(), node.Element())
+		}
+	case *json.Array:
+		arr := value.(*json.Array)
+		for _, elem := range orig.Elements() {
+			h.insertElement(ctx, arr, arr.LastCreatedAt(), elem)
+		}
+	case *json.Text:
+		text := proxy.NewTextProxy(ctx, value.(*json.Text))
+		var sb strings.Builder
+		for _, node := range orig.Nodes() {
+			sb.WriteString(node.String())
+		}
+		if sb.Len() > 0 {
+			text.Edit(0, 0, sb.String())
+		}
+		h.removeTombstones(ctx, orig, value)
+		h.restoredTexts[orig.CreatedAt().Key()] = newTextSegments(orig, value.(*json.Text))
+	case *json.RichText:
+		text := proxy.NewRichTextProxy(ctx, value.(*json.RichText))
+		index := 0
+		for _, node := range orig.Nodes() {
+			// NOTE: The last line of RichText is created along
+			// with the RichText itself, so we skip it.
+			if node.ID().CreatedAt().Compare(orig.CreatedAt()) == 0 {
+				continue
+			}
+			val := node.Value().(*json.RichTextValue)
+			text.Edit(index, index, val.Value(), val.Attrs().Elements())
+			index += node.Value().Len()
+		}
+		h.removeTombstones(ctx, orig, value)
+		h.restoredTexts[orig.CreatedAt().Key()] = newTextSegments(orig, value.(*json.RichText))
+	case *json.Set:
+		set := value.(*json.Set)
+		for _, elem := range orig.Elements() {
+			h.addElement(ctx, set, elem)
+		}
+	case *json.Tree:
+		tree := proxy.NewTreeProxy(ctx, value.(*json.Tree))
+		for i, node := range orig.Root().Children() {
+			tree.Insert([]int{i}, proxy.ToTreeNode(node))
+		}
+	}
+}
+
+// removeTombstones removes the characters of the given copy that stand for
+// the removed characters of the original. The copy is filled with the removed
+// characters too, so that every character of the original keeps its place in
+// the copy.
+func (h *history) removeTombstones(ctx *change.Context, orig textElement, value json.Element) {
+	type span struct{ from, to int }
+
+	var spans []span
+	index := 0
+	for _, node := range orig.Nodes() {
+		if node.ID().CreatedAt().Compare(orig.CreatedAt()) == 0 {
+			continue
+		}
+		length := node.Value().Len()
+		if node.RemovedAt() != nil {
+			spans = append(spans, span{index, index + length})
+		}
+		index += length
+	}
+
+	for i := len(spans) - 1; i >= 0; i-- {
+		editText(ctx, value, spans[i].from, spans[i].to, removedText{})
+	}
+}
+
+// newElement creates an empty element of the same kind as the given element
+// with the given time ticket. Primitive and Counter carry their values.
+func newElement(elem json.Element, ticket *time.Ticket) json.Element {
+	switch elem := elem.(type) {
+	case *json.Object:
+		return json.NewObject(json.NewRHTPriorityQueueMap(), ticket)
+	case *json.Array:
+		return json.NewArray(json.NewRGATreeList(), ticket)
+	case *json.Primitive:
+		return json.NewPrimitive(elem.Value(), ticket)
+	case *json.Text:
+		return json.NewText(json.NewRGATreeSplit(json.InitialTextNode()), ticket)
+	case *json.RichText:
+		return json.NewInitialRichText(json.NewRGATreeSplit(json.InitialRichTextNode()), ticket)
+	case *json.Counter:
+		return json.NewCounter(json.CounterValueFromBytes(elem.ValueType(), elem.Bytes()), ticket)
+	case *json.Tree:
+		return json.NewInitialTree(ticket)
+	case *json.Set:
+		return json.NewSet(ticket)
+	}
+
+	panic("unsupported type")
+}
+
+// executeWithReverses executes the given change on the given root and returns
+// the reverses of its operations in the order of execution. The events of the
+// operations are recorded with the given recorder.
+func executeWithReverses(c *change.Change, root *json.Root, recorder *eventRecorder) ([]reverse, error) {
+	var reverses []reverse
+	for _, op := range c.Operations() {
+		op := op
+		var r reverse
+		if err := recorder.execute(op, func() error {
+			var err error
+			r, err = executeWithReverse(op, root)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		if r != nil {
+			reverses = append(reverses, r)
+		}
+	}
+
+	return reverses, nil
+}
+
+// executeWithReverse executes the given operation on the given root and
+// returns the reverse of it. It returns nil if there is nothing to reverse.
+func executeWithReverse(op operation.Operation, root *json.Root) (reverse, error) {
+	var r reverse
+
+	switch op := op.(type) {
+	case *operation.Set:
+		r = newSetReverse(op, root)
+	case *operation.Add:
+		r = &addReverse{
+			parentCreatedAt: op.ParentCreatedAt(),
+			createdAt:       op.Value().CreatedAt(),
+		}
+	case *operation.Remove:
+		r = newRemoveReverse(op, root)
+	case *operation.Move:
+		r = newMoveReverse(op, root)
+	case *operation.Increase:
+		r = &increaseReverse{
+			parentCreatedAt: op.ParentCreatedAt(),
+			value:           op.Value().(*json.Primitive),
+		}
+	case *operation.TreeInsert, *operation.TreeRemove, *operation.TreeMove, *operation.TreeStyle:
+		// NOTE: The operations of Tree are not reversed yet, so
+		// they are skipped by undo.
+	}
+
+	var snapshot textSnapshot
+	switch op.(type) {
+	case *operation.Edit, *operation.RichEdit, *operation.Style:
+		snapshot = newTextSnapshot(root.FindByCreatedAt(op.ParentCreatedAt()))
+	}
+
+	if err := op.Execute(root); err != nil {
+		return nil, err
+	}
+
+	switch op := op.(type) {
+	case *operation.Edit:
+		r = newEditReverse(op.ParentCreatedAt(), op.ExecutedAt(), op.Content(), root, snapshot)
+	case *operation.RichEdit:
+		r = newEditReverse(op.ParentCreatedAt(), op.ExecutedAt(), op.Content(), root, snapshot)
+	case *operation.Style:
+		r = newStyleReverse(op, root, snapshot)
+	}
+
+	return r, nil
+}
+
+// setReverse is the reverse of Set. It restores the previous value of the
+// key, or removes the value if the key did not have one.
+type setReverse struct {
+	parentCreatedAt *time.Ticket
+	key             string
+	createdAt       *time.Ticket
+	prev            json.Element
+}
+
+func newSetReverse(op *operation.Set, root *json.Root) reverse {
+	r := &setReverse{
+		parentCreatedAt: op.ParentCreatedAt(),
+		key:             op.Key(),
+		createdAt:       op.Value().CreatedAt(),
+	}
+
+	if obj, ok := root.FindByCreatedAt(op.ParentCreatedAt()).(*json.Object); ok {
+		if prev := obj.Get(op.Key()); prev != nil {
+			r.prev = prev.DeepCopy()
+		}
+	}
+
+	return r
+}
+
+func (r *setReverse) apply(h *history, ctx *change.Context, root *json.Root) {
+	obj, ok := h.find(root, r.parentCreatedAt).(*json.Object)
+	if !ok {
+		return
+	}
+
+	// NOTE: If the value has been overwritten by a newer Set, the
+	// newer one wins and we leave it as it is.
+	current := obj.Get(r.key)
+	if current == nil || current.CreatedAt().Compare(h.resolve(r.createdAt)) != 0 {
+		return
+	}
+
+	if r.prev != nil {
+		h.setElement(ctx, obj, r.key, r.prev)
+		return
+	}
+	h.removeElement(ctx, obj, current)
+}
+
+// addReverse is the reverse of Add. It removes the added element.
+type addReverse struct {
+	parentCreatedAt *time.Ticket
+	createdAt       *time.Ticket
+}
+
+func (r *addReverse) apply(h *history, ctx *change.Context, root *json.Root) {
+	parent, ok := h.find(root, r.parentCreatedAt).(json.Container)
+	if !ok {
+		return
+	}
+
+	elem := h.find(root, r.createdAt)
+	if elem == nil {
+		return
+	}
+
+	h.removeElement(ctx, parent, elem)
+}
+
+// removeReverse is the reverse of Remove. It restores a copy of the removed
+// element under the key of the object, after the previous element of the
+// array or into the set.
+type removeReverse struct {
+	parentCreatedAt *time.Ticket
+	key             string
+	prevCreatedAt   *time.Ticket
+	elem            json.Element
+}
+
+func newRemoveReverse(op *operation.Remove, root *json.Root) reverse {
+	elem := root.FindByCreatedAt(op.CreatedAt())
+	if elem == nil || elem.RemovedAt() != nil {
+		return nil
+	}
+
+	r := &removeReverse{
+		parentCreatedAt: op.ParentCreatedAt(),
+		elem:            elem.DeepCopy(),
+	}
+
+	switch parent := root.FindByCreatedAt(op.ParentCreatedAt()).(type) {
+	case *json.Object:
+		for _, node := range parent.RHTNodes() {
+			if node.Element() == elem {
+				r.key = node.Key()
+				break
+			}
+		}
+	case *json.Array:
+		r.prevCreatedAt = parent.FindPrevCreatedAt(op.CreatedAt())
+	case *json.Set:
+		// NOTE: The elements of Set have no position to record.
+	default:
+		return nil
+	}
+
+	return r
+}
+
+func (r *removeReverse) apply(h *history, ctx *change.Context, root *json.Root) {
+	switch parent := h.find(root, r.parentCreatedAt).(type) {
+	case *json.Object:
+		// NOTE: If the key has been set by others in the meantime,
+		// we do not overwrite it.
+		if parent.Has(r.key) {
+			return
+		}
+		h.setElement(ctx, parent, r.key, r.elem)
+	case *json.Array:
+		h.insertElement(ctx, parent, h.findPrev(root, r.prevCreatedAt), r.elem)
+	case *json.Set:
+		h.addElement(ctx, parent, r.elem.(*json.Primitive))
+	}
+}
+
+// moveReverse is the reverse of Move. It moves the element back after the
+// element that preceded it.
+type moveReverse struct {
+	parentCreatedAt *time.Ticket
+	prevCreatedAt   *time.Ticket
+	createdAt       *time.Ticket
+	executedAt      *time.Ticket
+}
+
+func newMoveReverse(op *operation.Move, root *json.Root) reverse {
+	arr, ok := root.FindByCreatedAt(op.ParentCreatedAt()).(*json.Array)
+	if !ok {
+		return nil
+	}
+
+	return &moveReverse{
+		parentCreatedAt: op.ParentCreatedAt(),
+		prevCreatedAt:   arr.FindPrevCreatedAt(op.CreatedAt()),
+		createdAt:       op.CreatedAt(),
+		executedAt:      op.ExecutedAt(),
+	}
+}
+
+func (r *moveReverse) apply(h *history, ctx *change.Context, root *json.Root) {
+	arr, ok := h.find(root, r.parentCreatedAt).(*json.Array)
+	if !ok {
+		return
+	}
+
+	elem := h.find(root, r.createdAt)
+	if elem == nil {
+		return
+	}
+
+	// NOTE: If the element has been moved by a newer Move, the
+	// newer one wins and we leave it as it is.
+	if elem.MovedAt() != nil && elem.MovedAt().After(r.executedAt) {
+		return
+	}
+
+	prevCreatedAt := h.findPrev(root, r.prevCreatedAt)
+	if prevCreatedAt.Compare(elem.CreatedAt()) == 0 {
+		return
+	}
+
+	ticket := ctx.IssueTimeTicket()
+	ctx.Push(operation.NewMove(
+		arr.CreatedAt(),
+		prevCreatedAt,
+		elem.CreatedAt(),
+		ticket,
+	))
+	arr.MoveAfter(prevCreatedAt, elem.CreatedAt(), ticket)
+}
+
+// increaseReverse is the reverse of Increase. It increases the counter by
+// the negated value.
+type increaseReverse struct {
+	parentCreatedAt *time.Ticket
+	value           *json.Primitive
+}
+
+func (r *increaseReverse) apply(h *history, ctx *change.Context, root *json.Root) {
+	cnt, ok := h.find(root, r.parentCreatedAt).(*json.Counter)
+	if !ok {
+		return
+	}
+
+	var negated interface{}
+	switch value := r.value.Value().(type) {
+	case int:
+		negated = -value
+	case int64:
+		negated = -value
+	case float64:
+		negated = -value
+	default:
+		return
+	}
+
+	increaseCounter(ctx, cnt, negated)
+}
+
+// increaseCounter increases the given counter by the given value.
+func increaseCounter(ctx *change.Context, cnt *json.Counter, value interface{}) {
+	ticket := ctx.IssueTimeTicket()
+	primitive := json.NewPrimitive(value, ticket)
+	ctx.Push(operation.NewIncrease(
+		cnt.CreatedAt(),
+		primitive,
+		ticket,
+	))
+	cnt.Increase(primitive)
+}
+
+// textElement represents Text or RichText to read their nodes.
+type textElement interface {
+	json.Element
+	Nodes() []*json.RGATreeSplitNode
+}
+
+// textSegment maps the characters of a node of a restored text to the
+// characters of its copy.
+type textSegment struct {
+	id     *json.RGATreeSplitNodeID
+	length int
+	to     *json.RGATreeSplitNodeID
+}
+
+func newTextSegments(orig, copied textElement) []textSegment {
+	// NOTE: The content of the original including the removed
+	// characters is inserted into the copy in order, so we can map the
+	// characters of them one by one except for the last line of RichText
+	// which is created along with the copy.
+	var lastLine *json.RGATreeSplitNodeID
+	var chars []*json.RGATreeSplitNodeID
+	for _, node := range copied.Nodes() {
+		if node.ID().CreatedAt().Compare(copied.CreatedAt()) == 0 {
+			lastLine = node.ID()
+			continue
+		}
+		for i := 0; i < node.Value().Len(); i++ {
+			chars = append(chars, node.ID().Split(i))
+		}
+	}
+
+	var segments []textSegment
+	index := 0
+	for _, node := range orig.Nodes() {
+		segment := textSegment{
+			id:     node.ID(),
+			length: node.Value().Len(),
+		}
+
+		if node.ID().CreatedAt().Compare(orig.CreatedAt()) == 0 {
+			segment.to = lastLine
+		} else if index+segment.length <= len(chars) {
+			segment.to = chars[index]
+			index += segment.length
+		}
+
+		if segment.to != nil {
+			segments = append(segments, segment)
+		}
+	}
+
+	return segments
+}
+
+// translate returns the ID of the character of the copy that stands for the
+// character of the given ID.
+func translate(segments []textSegment, id *json.RGATreeSplitNodeID) (*json.RGATreeSplitNodeID, bool) {
+	for _, segment := range segments {
+		if segment.id.CreatedAt().Compare(id.CreatedAt()) != 0 ||
+			id.Offset() < segment.id.Offset() || segment.id.Offset()+segment.length <= id.Offset() {
+			continue
+		}
+
+		return segment.to.Split(id.Offset() - segment.id.Offset()), true
+	}
+
+	return nil, false
+}
+
+// locate returns the index of the character of the given ID in the text that
+// currently stands for the text of the given creation time. It also returns
+// whether the character is visible and whether it has been found.
+func (h *history) locate(
+	root *json.Root,
+	createdAt *time.Ticket,
+	id *json.RGATreeSplitNodeID,
+) (int, bool, bool) {
+	text, id, ok := h.follow(root, createdAt, id)
+	if !ok {
+		return 0, false, false
+	}
+
+	return indexOf(text.Nodes(), id)
+}
+
+// positionAfter returns the position right after the character of the given
+// ID in the text that currently stands for the text of the given creation
+// time, regardless of whether the character is removed or not.
+func (h *history) positionAfter(
+	root *json.Root,
+	createdAt *time.Ticket,
+	id *json.RGATreeSplitNodeID,
+) (*json.RGATreeSplitNodePos, bool) {
+	text, id, ok := h.follow(root, createdAt, id)
+	if !ok {
+		return nil, false
+	}
+
+	for _, node := range text.Nodes() {
+		if node.Value().Len() == 0 && node.ID().Compare(id) == 0 {
+			return json.NewRGATreeSplitNodePos(node.ID(), 0), true
+		}
+		if contains(node, id) {
+			return json.NewRGATreeSplitNodePos(node.ID(), id.Offset()-node.ID().Offset()+1), true
+		}
+	}
+
+	return nil, false
+}
+
+// follow returns the text that currently stands for the text of the given
+// creation time and the ID of the character in it that stands for the
+// character of the given ID.
+func (h *history) follow(
+	root *json.Root,
+	createdAt *time.Ticket,
+	id *json.RGATreeSplitNodeID,
+) (textElement, *json.RGATreeSplitNodeID, bool) {
+	for id.CreatedAt().Compare(time.InitialTicket) != 0 {
+		segments, ok := h.restoredTexts[createdAt.Key()]
+		if !ok {
+			break
+		}
+
+		var found bool
+		if id, found = translate(segments, id); !found {
+			return nil, nil, false
+		}
+		createdAt = h.restoredMap[createdAt.Key()]
+	}
+
+	text, ok := root.FindByCreatedAt(h.resolve(createdAt)).(textElement)
+	if !ok {
+		return nil, nil, false
+	}
+
+	return text, id, true
+}
+
+// textSnapshot is a snapshot of the nodes of Text or RichText taken before an
+// operation is executed on them.
+type textSnapshot []nodeSnapshot
+
+// nodeSnapshot is a snapshot of a node of Text or RichText.
+type nodeSnapshot struct {
+	id      *json.RGATreeSplitNodeID
+	length  int
+	removed bool
+	attrs   map[string]string
+}
+
+func newTextSnapshot(elem json.Element) textSnapshot {
+	text, ok := elem.(textElement)
+	if !ok {
+		return nil
+	}
+
+	var snapshot textSnapshot
+	for _, node := range text.Nodes() {
+		n := nodeSnapshot{
+			id:      node.ID(),
+			length:  node.Value().Len(),
+			removed: node.RemovedAt() != nil,
+		}
+		if val, ok := node.Value().(*json.RichTextValue); ok {
+			n.attrs = val.Attrs().Elements()
+		}
+		snapshot = append(snapshot, n)
+	}
+	return snapshot
+}
+
+// find returns the snapshot of the node that contains the given ID.
+func (s textSnapshot) find(id *json.RGATreeSplitNodeID) *nodeSnapshot {
+	for i := range s {
+		n := &s[i]
+		if n.id.CreatedAt().Compare(id.CreatedAt()) == 0 &&
+			n.id.Offset() <= id.Offset() && id.Offset() < n.id.Offset()+n.length {
+			return n
+		}
+	}
+	return nil
+}
+
+// removedText is a piece of text removed by Edit or RichEdit.
+type removedText struct {
+	id    *json.RGATreeSplitNodeID
+	value string
+	attrs map[string]string
+}
+
+// editReverse is the reverse of Edit and RichEdit. It removes the inserted
+// content and inserts the removed content again.
+type editReverse struct {
+	parentCreatedAt *time.Ticket
+	executedAt      *time.Ticket
+	inserted        int
+	removed         []removedText
+
+	// left is the ID of the character right before the removed content, and
+	// index is the index of the removed content. The index is used when the
+	// character has been purged.
+	left  *json.RGATreeSplitNodeID
+	index int
+}
+
+func newEditReverse(
+	parentCreatedAt *time.Ticket,
+	executedAt *time.Ticket,
+	content string,
+	root *json.Root,
+	snapshot textSnapshot,
+) reverse {
+	text, ok := root.FindByCreatedAt(parentCreatedAt).(textElement)
+	if !ok {
+		return nil
+	}
+
+	r := &editReverse{
+		parentCreatedAt: parentCreatedAt,
+		executedAt:      executedAt,
+		inserted:        json.NewTextValue(content).Len(),
+	}
+
+	index := 0
+	var left *json.RGATreeSplitNodeID
+	for _, node := range text.Nodes() {
+		// NOTE: Edit also updates the removal time of the nodes
+		// that had been removed before, so we only take the nodes that were
+		// visible before the operation.
+		prev := snapshot.find(node.ID())
+		if node.RemovedAt() != nil && node.RemovedAt().Compare(executedAt) == 0 && prev != nil && !prev.removed {
+			if len(r.removed) == 0 {
+				r.left = left
+				r.index = index
+			}
+
+			piece := removedText{id: node.ID()}
+			if val, ok := node.Value().(*json.RichTextValue); ok {
+				piece.value = val.Value()
+				piece.attrs = val.Attrs().Elements()
+			} else {
+				piece.value = node.String()
+			}
+			r.removed = append(r.removed, piece)
+			continue
+		}
+
+		if node.ID().CreatedAt().Compare(executedAt) == 0 {
+			continue
+		}
+
+		if length := node.Value().Len(); length > 0 {
+			left = node.ID().Split(length - 1)
+		} else {
+			left = node.ID()
+		}
+		index += node.Len()
+	}
+
+	return r
+}
+
+func (r *editReverse) apply(h *history, ctx *change.Context, root *json.Root) {
+	target := h.find(root, r.parentCreatedAt)
+	if target == nil {
+		return
+	}
+
+	// 01. Remove the inserted content that is still visible from the back.
+	var indexes []int
+	for i := 0; i < r.inserted; i++ {
+		id := json.NewRGATreeSplitNodeID(r.executedAt, i)
+		if index, visible, _ := h.locate(root, r.parentCreatedAt, id); visible {
+			indexes = append(indexes, index)
+		}
+	}
+	for i := len(indexes) - 1; i >= 0; {
+		to := i
+		for i > 0 && indexes[i-1] == indexes[i]-1 {
+			i--
+		}
+		editText(ctx, target, indexes[i], indexes[to]+1, removedText{})
+		i--
+	}
+
+	if len(r.removed) == 0 {
+		return
+	}
+
+	// 02. Insert the removed content again right after the character that
+	// was before it.
+	if r.left != nil {
+		if pos, ok := h.positionAfter(root, r.parentCreatedAt, r.left); ok {
+			for _, piece := range r.removed {
+				pos = insertText(ctx, target, pos, piece)
+			}
+			return
+		}
+	}
+
+	index := r.index
+	if length := textLen(target); index > length {
+		index = length
+	}
+	for _, piece := range r.removed {
+		editText(ctx, target, index, index, piece)
+		index += json.NewTextValue(piece.value).Len()
+	}
+}
+
+// styledText is a piece of text styled by Style with the previous values of
+// the styled attributes.
+type styledText struct {
+	id     *json.RGATreeSplitNodeID
+	length int
+	attrs  map[string]string
+}
+
+// styleReverse is the reverse of Style. It restores the previous values of
+// the styled attributes.
+//
+// NOTE: Style cannot remove attributes, so the attributes that did
+// not exist before are restored with an empty value.
+type styleReverse struct {
+	parentCreatedAt *time.Ticket
+	executedAt      *time.Ticket
+	styled          []styledText
+}
+
+func newStyleReverse(op *operation.Style, root *json.Root, snapshot textSnapshot) reverse {
+	text, ok := root.FindByCreatedAt(op.ParentCreatedAt()).(*json.RichText)
+	if !ok {
+		return nil
+	}
+
+	r := &styleReverse{
+		parentCreatedAt: op.ParentCreatedAt(),
+		executedAt:      op.ExecutedAt(),
+	}
+	for _, node := range text.Nodes() {
+		if !isStyledAt(node, op.ExecutedAt()) {
+			continue
+		}
+
+		attrs := make(map[string]string)
+		prev := snapshot.find(node.ID())
+		for key := range op.Attributes() {
+			if prev != nil {
+				attrs[key] = prev.attrs[key]
+			} else {
+				attrs[key] = ""
+			}
+		}
+		r.styled = append(r.styled, styledText{
+			id:     node.ID(),
+			length: node.Value().Len(),
+			attrs:  attrs,
+		})
+	}
+
+	return r
+}
+
+func (r *styleReverse) apply(h *history, ctx *change.Context, root *json.Root) {
+	target, ok := h.find(root, r.parentCreatedAt).(*json.RichText)
+	if !ok {
+		return
+	}
+
+	text := proxy.NewRichTextProxy(ctx, target)
+	for _, piece := range r.styled {
+		from, _, found := h.locate(root, r.parentCreatedAt, piece.id)
+		if !found {
+			continue
+		}
+
+		to, visible, _ := h.locate(root, r.parentCreatedAt, piece.id.Split(piece.length-1))
+		if visible {
+			to++
+		}
+		if from >= to {
+			continue
+		}
+
+		// NOTE: If the attribute has been styled by a newer Style,
+		// the newer one wins and we leave it as it is.
+		attrs := make(map[string]string)
+		for key, value := range piece.attrs {
+			if isStyledAfter(target, from, key, r.executedAt) {
+				continue
+			}
+			attrs[key] = value
+		}
+		if len(attrs) > 0 {
+			text.SetStyle(from, to, attrs)
+		}
+	}
+}
+
+// isStyledAt returns whether the given node has an attribute styled at the
+// given time.
+func isStyledAt(node *json.RGATreeSplitNode, executedAt *time.Ticket) bool {
+	for _, attr := range node.Value().(*json.RichTextValue).Attrs().Nodes() {
+		if attr.UpdatedAt().Compare(executedAt) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isStyledAfter returns whether the attribute of the given key of the
+// character at the given index has been styled after the given time.
+func isStyledAfter(text *json.RichText, index int, key string, executedAt *time.Ticket) bool {
+	for _, node := range text.Nodes() {
+		if node.Len() <= index {
+			index -= node.Len()
+			continue
+		}
+
+		for _, attr := range node.Value().(*json.RichTextValue).Attrs().Nodes() {
+			if attr.Key() == key && attr.UpdatedAt().After(executedAt) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// indexOf returns the index of the character of the given ID among the
+// visible characters of the given nodes. It also returns whether the
+// character is visible and whether it has been found.
+func indexOf(nodes []*json.RGATreeSplitNode, id *json.RGATreeSplitNodeID) (int, bool, bool) {
+	index := 0
+	for _, node := range nodes {
+		if contains(node, id) {
+			if node.RemovedAt() != nil {
+				return index, false, true
+			}
+			return index + id.Offset() - node.ID().Offset(), true, true
+		}
+		index += node.Len()
+	}
+
+	return index, false, false
+}
+
+// contains returns whether the given node contains the character of the
+// given ID.
+func contains(node *json.RGATreeSplitNode, id *json.RGATreeSplitNodeID) bool {
+	nodeID := node.ID()
+	return nodeID.CreatedAt().Compare(id.CreatedAt()) == 0 &&
+		nodeID.Offset() <= id.Offset() &&
+		id.Offset() < nodeID.Offset()+node.Value().Len()
+}
+
+// editText edits the given range of Text or RichText with the given piece.
+func editText(ctx *change.Context, elem json.Element, from, to int, piece removedText) {
+	switch text := elem.(type) {
+	case *json.Text:
+		proxy.NewTextProxy(ctx, text).Edit(from, to, piece.value)
+	case *json.RichText:
+		proxy.NewRichTextProxy(ctx, text).Edit(from, to, piece.value, piece.attrs)
+	}
+}
+
+// insertText inserts the given piece at the given position of Text or
+// RichText and returns the position right after the inserted piece.
+func insertText(
+	ctx *change.Context,
+	elem json.Element,
+	pos *json.RGATreeSplitNodePos,
+	piece removedText,
+) *json.RGATreeSplitNodePos {
+	ticket := ctx.IssueTimeTicket()
+
+	switch text := elem.(type) {
+	case *json.Text:
+		caret, latestCreatedAtMap := text.Edit(pos, pos, nil, piece.value, ticket)
+		ctx.Push(operation.NewEdit(text.CreatedAt(), pos, pos, latestCreatedAtMap, piece.value, ticket))
+		return caret
+	case *json.RichText:
+		caret, latestCreatedAtMap := text.Edit(pos, pos, nil, piece.value, piece.attrs, ticket)
+		ctx.Push(operation.NewRichEdit(
+			text.CreatedAt(),
+			pos,
+			pos,
+			latestCreatedAtMap,
+			piece.value,
+			piece.attrs,
+			ticket,
+		))
+		return caret
+	}
+
+	return pos
+}
+
+// textLen returns the length of the visible content of Text or RichText.
+func textLen(elem json.Element) int {
+	text, ok := elem.(textElement)
+	if !ok {
+		return 0
+	}
+
+	length := 0
+	for _, node := range text.Nodes() {
+		length += node.Len()
+	}
+	return length
+}
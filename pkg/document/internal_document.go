@@ -17,6 +17,8 @@
 package document
 
 import (
+	"fmt"
+
 	"github.com/yorkie-team/yorkie/api/converter"
 	"github.com/yorkie-team/yorkie/internal/log"
 	"github.com/yorkie-team/yorkie/pkg/document/change"
@@ -126,6 +128,23 @@ func (d *InternalDocument) ApplyChangePack(pack *change.Pack) error {
 	return nil
 }
 
+// ApplyPushedChanges applies the given changes pushed by a client before
+// they are stored, and moves the checkpoint to the given server seq that the
+// changes will be stored up to. A panic caused by a malformed change is
+// returned as an error. If an error is returned, this document may be
+// partially changed, so it should be discarded.
+func (d *InternalDocument) ApplyPushedChanges(changes []*change.Change, serverSeq uint64) error {
+	for _, c := range changes {
+		if err := applyPushedChange(c, d.root); err != nil {
+			return fmt.Errorf("change(clientSeq %d): %w", c.ClientSeq(), err)
+		}
+		d.changeID = d.changeID.SyncLamport(c.ID().Lamport())
+	}
+
+	d.checkpoint = d.checkpoint.Forward(checkpoint.Initial.NextServerSeq(serverSeq))
+	return nil
+}
+
 // GarbageCollect purge elements that were removed before the given time.
 func (d *InternalDocument) GarbageCollect(ticket *time.Ticket) int {
 	return d.root.GarbageCollect(ticket)
@@ -149,6 +168,17 @@ func (d *InternalDocument) CreateChangePack() *change.Pack {
 	return change.NewPack(d.key, cp, changes, nil)
 }
 
+// MaxLamport returns the maximum lamport of this document. It covers the
+// changes applied to this document and the tickets of its root, which hold
+// the lamports of the changes before the snapshot this document is built from.
+func (d *InternalDocument) MaxLamport() uint64 {
+	lamport := d.root.MaxLamport()
+	if lamport < d.changeID.Lamport() {
+		lamport = d.changeID.Lamport()
+	}
+	return lamport
+}
+
 // SetActor sets actor into this document. This is also applied in the local
 // changes the document has.
 func (d *InternalDocument) SetActor(actor *time.ActorID) {
@@ -209,3 +239,13 @@ func (d *InternalDocument) applyChanges(changes []*change.Change) error {
 
 	return nil
 }
+
+func applyPushedChange(c *change.Change, root *json.Root) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return c.Execute(root)
+}
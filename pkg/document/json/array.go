@@ -53,6 +53,12 @@ func (a *Array) Get(idx int) Element {
 	return a.elements.Get(idx).elem
 }
 
+// IndexOf returns the index of the element of the given creation time. It
+// returns -1 if the element is not in this array or removed.
+func (a *Array) IndexOf(createdAt *time.Ticket) int {
+	return a.elements.IndexOf(createdAt)
+}
+
 // FindPrevCreatedAt returns the creation time of the previous element of the
 // given element.
 func (a *Array) FindPrevCreatedAt(createdAt *time.Ticket) *time.Ticket {
@@ -174,6 +180,8 @@ func (a *Array) Descendants(callback func(elem Element, parent Container) bool)
 			elem.Descendants(callback)
 		case *Array:
 			elem.Descendants(callback)
+		case *Set:
+			elem.Descendants(callback)
 		}
 	}
 }
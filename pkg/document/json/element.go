@@ -20,7 +20,7 @@ import (
 	"github.com/yorkie-team/yorkie/pkg/document/time"
 )
 
-// Container represents Array or Object.
+// Container represents Array, Object or Set.
 type Container interface {
 	Element
 
@@ -57,6 +57,12 @@ func (o *Object) Get(k string) Element {
 	return o.memberNodes.Get(k)
 }
 
+// KeyOf returns the key of the member of the given creation time. It returns
+// false if the element is not a member of this object.
+func (o *Object) KeyOf(createdAt *time.Ticket) (string, bool) {
+	return o.memberNodes.KeyOf(createdAt)
+}
+
 // Has returns whether the element exists of the given key or not.
 func (o *Object) Has(k string) bool {
 	return o.memberNodes.Has(k)
@@ -84,6 +90,8 @@ func (o *Object) Descendants(callback func(elem Element, parent Container) bool)
 			elem.Descendants(callback)
 		case *Array:
 			elem.Descendants(callback)
+		case *Set:
+			elem.Descendants(callback)
 		}
 	}
 }
@@ -0,0 +1,194 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPath is returned when the given path cannot be parsed.
+	ErrInvalidPath = errors.New("invalid path")
+
+	// ErrElementNotFound is returned when there is no element at the given
+	// path, key or index.
+	ErrElementNotFound = errors.New("element not found")
+
+	// ErrUnexpectedType is returned when the element is not of the requested
+	// type.
+	ErrUnexpectedType = errors.New("unexpected type")
+)
+
+// KeyPathSegment returns the segment of the path for the given key. The key
+// is written as `['key']` if it cannot be written as `.key`, and the quotes
+// and the backslashes in the key are escaped with a backslash.
+func KeyPathSegment(k string) string {
+	if k == "" || strings.ContainsAny(k, ".[]'\"\\") {
+		return QuotedKeyPathSegment(k)
+	}
+	return "." + k
+}
+
+// QuotedKeyPathSegment returns the segment of the path for the given key
+// written as `['key']`, which is the form of the keys in NormalizePath.
+func QuotedKeyPathSegment(k string) string {
+	return "['" + pathKeyEscaper.Replace(k) + "']"
+}
+
+// pathKeyEscaper escapes the characters that end a quoted key in the path.
+var pathKeyEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// FindByPath returns the element of the given path. The path is a subset of
+// JSONPath: it starts with `$` for the root object and is followed by keys
+// such as `.todos` or `['todos']` and indexes such as `[2]`.
+// e.g. `$.todos[2].title`
+func (r *Root) FindByPath(path string) (Element, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("%s: %w", path, ErrInvalidPath)
+	}
+
+	var elem Element = r.object
+	rest := path[1:]
+	for len(rest) > 0 {
+		var key string
+		var index int
+		var isIndex bool
+		var err error
+		if key, index, isIndex, rest, err = nextPathSegment(rest); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		switch parent := elem.(type) {
+		case *Object:
+			if isIndex {
+				return nil, fmt.Errorf("%s: index %d of object: %w", path, index, ErrElementNotFound)
+			}
+			elem = parent.Get(key)
+		case *Array:
+			if !isIndex {
+				return nil, fmt.Errorf("%s: key %s of array: %w", path, key, ErrElementNotFound)
+			}
+			if index >= parent.Len() {
+				return nil, fmt.Errorf("%s: index %d: %w", path, index, ErrElementNotFound)
+			}
+			elem = parent.Get(index)
+		default:
+			return nil, fmt.Errorf("%s: child of %T: %w", path, elem, ErrElementNotFound)
+		}
+
+		if elem == nil {
+			return nil, fmt.Errorf("%s: key %s: %w", path, key, ErrElementNotFound)
+		}
+	}
+
+	return elem, nil
+}
+
+// NormalizePath returns the canonical form of the given path, in which every
+// key is written as `['key']` with escapes and every index as `[n]`.
+func NormalizePath(path string) (string, error) {
+	if !strings.HasPrefix(path, "$") {
+		return "", fmt.Errorf("%s: %w", path, ErrInvalidPath)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("$")
+	rest := path[1:]
+	for len(rest) > 0 {
+		var key string
+		var index int
+		var isIndex bool
+		var err error
+		if key, index, isIndex, rest, err = nextPathSegment(rest); err != nil {
+			return "", fmt.Errorf("%s: %w", path, err)
+		}
+
+		if isIndex {
+			sb.WriteString(fmt.Sprintf("[%d]", index))
+		} else {
+			sb.WriteString(QuotedKeyPathSegment(key))
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// nextPathSegment parses the first segment of the given path and returns the
+// key or the index of the segment with the rest of the path.
+func nextPathSegment(path string) (string, int, bool, string, error) {
+	switch path[0] {
+	case '.':
+		end := strings.IndexAny(path[1:], ".[")
+		if end < 0 {
+			end = len(path) - 1
+		}
+		if end == 0 {
+			return "", 0, false, "", ErrInvalidPath
+		}
+		return path[1 : end+1], 0, false, path[end+1:], nil
+	case '[':
+		if len(path) > 1 && (path[1] == '\'' || path[1] == '"') {
+			key, rest, err := nextQuotedKey(path)
+			return key, 0, false, rest, err
+		}
+
+		end := strings.IndexByte(path, ']')
+		if end < 0 {
+			return "", 0, false, "", ErrInvalidPath
+		}
+
+		inner := path[1:end]
+		index, err := strconv.Atoi(inner)
+		if err != nil || index < 0 {
+			return "", 0, false, "", ErrInvalidPath
+		}
+		return "", index, true, path[end+1:], nil
+	}
+
+	return "", 0, false, "", ErrInvalidPath
+}
+
+// nextQuotedKey parses the quoted key at the front of the given path such as
+// `['key']` and returns the key with the rest of the path. A backslash in the
+// key escapes the following character.
+func nextQuotedKey(path string) (string, string, error) {
+	quote := path[1]
+
+	var sb strings.Builder
+	for i := 2; i < len(path); i++ {
+		switch path[i] {
+		case '\\':
+			i++
+			if i == len(path) {
+				return "", "", ErrInvalidPath
+			}
+			sb.WriteByte(path[i])
+		case quote:
+			if i+1 == len(path) || path[i+1] != ']' {
+				return "", "", ErrInvalidPath
+			}
+			return sb.String(), path[i+2:], nil
+		default:
+			sb.WriteByte(path[i])
+		}
+	}
+
+	return "", "", ErrInvalidPath
+}
@@ -17,9 +17,13 @@
 package json
 
 import (
+	"encoding/base64"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 	gotime "time"
 
 	"github.com/yorkie-team/yorkie/pkg/document/time"
@@ -40,33 +44,74 @@ const (
 	Date
 )
 
-// ValueFromBytes parses the given bytes into value.
-func ValueFromBytes(valueType ValueType, value []byte) interface{} {
+// DateFormat is the layout of Date in the JSON encoding. It is RFC 3339 with
+// milliseconds, the precision of Date.
+const DateFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// ErrInvalidValue is returned when the given bytes cannot be decoded into a
+// value of the given type.
+var ErrInvalidValue = errors.New("invalid value")
+
+// The encoding of Date is versioned inside the bytes of Primitive rather than
+// in the envelope, because the values stored before the versioning have no
+// version byte. They are told apart by their length:
+//   - legacy: 8 bytes of Unix seconds without the version byte.
+//   - dateEncodingV1: the version byte followed by 8 bytes of Unix milliseconds.
+//
+// A new version must keep the version byte first and must not be 8 bytes
+// long, so that it is not mistaken for the legacy encoding.
+const (
+	legacyDateLen          = 8
+	dateEncodingV1    byte = 1
+	dateEncodingV1Len      = 9
+)
+
+// ValueFromBytes parses the given bytes into value. It returns
+// ErrInvalidValue if the bytes are malformed, because they can come from
+// a client.
+func ValueFromBytes(valueType ValueType, value []byte) (interface{}, error) {
 	switch valueType {
 	case Null:
-		return nil
+		return nil, nil
 	case Boolean:
-		if value[0] == 1 {
-			return true
+		if len(value) != 1 {
+			return nil, invalidValueError(valueType, value)
 		}
-		return false
+		return value[0] == 1, nil
 	case Integer:
+		if len(value) != 4 {
+			return nil, invalidValueError(valueType, value)
+		}
 		val := int32(binary.LittleEndian.Uint32(value))
-		return int(val)
+		return int(val), nil
 	case Long:
-		return int64(binary.LittleEndian.Uint64(value))
+		if len(value) != 8 {
+			return nil, invalidValueError(valueType, value)
+		}
+		return int64(binary.LittleEndian.Uint64(value)), nil
 	case Double:
-		return math.Float64frombits(binary.LittleEndian.Uint64(value))
+		if len(value) != 8 {
+			return nil, invalidValueError(valueType, value)
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(value)), nil
 	case String:
-		return string(value)
+		return string(value), nil
 	case Bytes:
-		return value
+		return value, nil
 	case Date:
-		v := int64(binary.LittleEndian.Uint64(value))
-		return gotime.Unix(v, 0)
+		date, err := dateFromBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return date, nil
 	}
 
-	panic("unsupported type")
+	return nil, fmt.Errorf("%d: %w", valueType, ErrInvalidValue)
+}
+
+// invalidValueError returns the error for the given malformed bytes.
+func invalidValueError(valueType ValueType, value []byte) error {
+	return fmt.Errorf("%d bytes for type %d: %w", len(value), valueType, ErrInvalidValue)
 }
 
 // Primitive represents JSON primitive data type including logical lock.
@@ -135,7 +180,7 @@ func NewPrimitive(value interface{}, createdAt *time.Ticket) *Primitive {
 	case gotime.Time:
 		return &Primitive{
 			valueType: Date,
-			value:     val,
+			value:     val.Truncate(gotime.Millisecond),
 			createdAt: createdAt,
 		}
 	}
@@ -172,15 +217,15 @@ func (p *Primitive) Bytes() []byte {
 	case []byte:
 		return val
 	case gotime.Time:
-		bytes := [8]byte{}
-		binary.LittleEndian.PutUint64(bytes[:], uint64(val.UTC().Unix()))
-		return bytes[:]
+		return dateToBytes(val)
 	}
 
 	panic("unsupported type")
 }
 
-// Marshal returns the JSON encoding of the value.
+// Marshal returns the JSON encoding of the value. Double is encoded as
+// described in marshalDouble, Date as a string in DateFormat and Bytes as a
+// string in standard base64 encoding.
 func (p *Primitive) Marshal() string {
 	switch p.valueType {
 	case Null:
@@ -192,20 +237,58 @@ func (p *Primitive) Marshal() string {
 	case Long:
 		return fmt.Sprintf("%d", p.value)
 	case Double:
-		return fmt.Sprintf("%f", p.value)
+		return marshalDouble(p.value.(float64))
 	case String:
-		return fmt.Sprintf("\"%s\"", p.value)
+		return quoteString(p.value.(string))
 	case Bytes:
-		// TODO: JSON.stringify({a: new Uint8Array([1,2]), b: 2})
-		// {"a":{"0":1,"1":2},"b":2}
-		return fmt.Sprintf("\"%s\"", p.value)
+		return quoteString(base64.StdEncoding.EncodeToString(p.value.([]byte)))
 	case Date:
-		return p.value.(gotime.Time).Format(gotime.RFC3339)
+		return quoteString(p.value.(gotime.Time).Format(DateFormat))
 	}
 
 	panic("unsupported type")
 }
 
+// marshalDouble returns the JSON encoding of the given double in the shortest
+// form that represents it exactly. The form always has a fraction or an
+// exponent so that the double is imported as Double again. NaN and infinities
+// cannot be represented in JSON, so they are encoded as null like JavaScript.
+func marshalDouble(value float64) string {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return "null"
+	}
+
+	encoded := strconv.FormatFloat(value, 'g', -1, 64)
+	if !strings.ContainsAny(encoded, ".e") {
+		encoded += ".0"
+	}
+	return encoded
+}
+
+// dateToBytes encodes the given time in the latest version of Date encoding.
+func dateToBytes(t gotime.Time) []byte {
+	millis := t.Unix()*1000 + int64(t.Nanosecond()/int(gotime.Millisecond))
+
+	bytes := [dateEncodingV1Len]byte{dateEncodingV1}
+	binary.LittleEndian.PutUint64(bytes[1:], uint64(millis))
+	return bytes[:]
+}
+
+// dateFromBytes decodes the given bytes encoded in any version of Date
+// encoding.
+func dateFromBytes(value []byte) (gotime.Time, error) {
+	if len(value) == legacyDateLen {
+		return gotime.Unix(int64(binary.LittleEndian.Uint64(value)), 0), nil
+	}
+
+	if len(value) == dateEncodingV1Len && value[0] == dateEncodingV1 {
+		millis := int64(binary.LittleEndian.Uint64(value[1:]))
+		return gotime.Unix(millis/1000, millis%1000*int64(gotime.Millisecond)), nil
+	}
+
+	return gotime.Time{}, invalidValueError(Date, value)
+}
+
 // DeepCopy copies itself deeply.
 func (p *Primitive) DeepCopy() Element {
 	primitive := *p
@@ -262,3 +345,35 @@ func (p *Primitive) IsNumericType() bool {
 	t := p.valueType
 	return t == Integer || t == Long || t == Double
 }
+
+// quoteString returns the JSON string literal of the given string. The
+// quotation mark, the reverse solidus and the control characters are escaped
+// as RFC 8259 requires.
+func quoteString(s string) string {
+	const hex = "0123456789abcdef"
+
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c == '\n':
+			sb.WriteString(`\n`)
+		case c == '\r':
+			sb.WriteString(`\r`)
+		case c == '\t':
+			sb.WriteString(`\t`)
+		case c < 0x20:
+			sb.WriteString(`\u00`)
+			sb.WriteByte(hex[c>>4])
+			sb.WriteByte(hex[c&0xf])
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
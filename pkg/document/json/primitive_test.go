@@ -38,17 +38,26 @@ func TestPrimitive(t *testing.T) {
 		{true, json.Boolean, "true"},
 		{0, json.Integer, "0"},
 		{int64(0), json.Long, "0"},
-		{float64(0), json.Double, "0.000000"},
+		{float64(0), json.Double, "0.0"},
+		{float64(0.1), json.Double, "0.1"},
+		{float64(1e-7), json.Double, "1e-07"},
+		{float64(-1.5e300), json.Double, "-1.5e+300"},
 		{"0", json.String, `"0"`},
+		{"a\"b\\c\nd\u0001", json.String, `"a\"b\\c\nd\u0001"`},
 		{[]byte{}, json.Bytes, `""`},
-		{gotime.Unix(0, 0), json.Date, gotime.Unix(0, 0).Format(gotime.RFC3339)},
+		{[]byte{0, 1, 255}, json.Bytes, `"AAH/"`},
+		{gotime.Unix(0, 0), json.Date, `"` + gotime.Unix(0, 0).Format(json.DateFormat) + `"`},
+		{gotime.Unix(-1, 5e8), json.Date, `"` + gotime.Unix(-1, 5e8).Format(json.DateFormat) + `"`},
+		{gotime.Unix(1, 123e6), json.Date, `"` + gotime.Unix(1, 123e6).Format(json.DateFormat) + `"`},
 	}
 
 	t.Run("creation and deep copy test", func(t *testing.T) {
 		for _, test := range tests {
 			prim := json.NewPrimitive(test.value, time.InitialTicket)
 			assert.Equal(t, prim.ValueType(), test.valueType)
-			assert.Equal(t, prim.Value(), json.ValueFromBytes(prim.ValueType(), prim.Bytes()))
+			value, err := json.ValueFromBytes(prim.ValueType(), prim.Bytes())
+			assert.NoError(t, err)
+			assert.Equal(t, prim.Value(), value)
 			assert.Equal(t, prim.Marshal(), test.marshal)
 
 			copied := prim.DeepCopy()
@@ -63,4 +72,44 @@ func TestPrimitive(t *testing.T) {
 		longPrim := json.NewPrimitive(math.MaxInt32+1, time.InitialTicket)
 		assert.Equal(t, longPrim.ValueType(), json.Long)
 	})
+
+	t.Run("double encoding test", func(t *testing.T) {
+		for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+			assert.Equal(t, "null", json.NewPrimitive(value, time.InitialTicket).Marshal())
+		}
+	})
+
+	t.Run("date precision test", func(t *testing.T) {
+		date := gotime.Unix(1, 123456789)
+		prim := json.NewPrimitive(date, time.InitialTicket)
+		assert.Equal(t, gotime.Unix(1, 123e6), prim.Value())
+		value, err := json.ValueFromBytes(json.Date, prim.Bytes())
+		assert.NoError(t, err)
+		assert.Equal(t, gotime.Unix(1, 123e6), value)
+
+		// Date stored as Unix seconds before the versioning is still decoded.
+		legacy := []byte{100, 0, 0, 0, 0, 0, 0, 0}
+		value, err = json.ValueFromBytes(json.Date, legacy)
+		assert.NoError(t, err)
+		assert.Equal(t, gotime.Unix(100, 0), value)
+	})
+
+	t.Run("malformed bytes test", func(t *testing.T) {
+		tests := []struct {
+			valueType json.ValueType
+			value     []byte
+		}{
+			{json.Boolean, nil},
+			{json.Integer, []byte{1, 2}},
+			{json.Long, []byte{1}},
+			{json.Double, nil},
+			{json.Date, nil},
+			{json.Date, []byte{1}},
+			{json.Date, []byte{2, 0, 0, 0, 0, 0, 0, 0, 0}},
+		}
+		for _, test := range tests {
+			_, err := json.ValueFromBytes(test.valueType, test.value)
+			assert.ErrorIs(t, err, json.ErrInvalidValue)
+		}
+	})
 }
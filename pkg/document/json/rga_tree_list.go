@@ -126,31 +126,18 @@ func NewRGATreeList() *RGATreeList {
 
 // Marshal returns the JSON encoding of this RGATreeList.
 func (a *RGATreeList) Marshal() string {
-	sb := strings.Builder{}
-	sb.WriteString("[")
+	var values []string
 
 	current := a.dummyHead.next
-	for {
-		if current == nil {
-			break
-		}
-
+	for current != nil {
 		if !current.isRemoved() {
-			sb.WriteString(current.elem.Marshal())
-
-			// FIXME: When the last element of the array is deleted, it does not
-			// work properly.
-			if current != a.last {
-				sb.WriteString(",")
-			}
+			values = append(values, current.elem.Marshal())
 		}
 
 		current = current.next
 	}
 
-	sb.WriteString("]")
-
-	return sb.String()
+	return "[" + strings.Join(values, ",") + "]"
 }
 
 // Add adds the given element at the last.
@@ -208,6 +195,17 @@ func (a *RGATreeList) Get(idx int) *RGATreeListNode {
 	return node
 }
 
+// IndexOf returns the index of the element of the given creation time. It
+// returns -1 if the element is not in this list or removed.
+func (a *RGATreeList) IndexOf(createdAt *time.Ticket) int {
+	node, ok := a.nodeMapByCreatedAt[createdAt.Key()]
+	if !ok || node.isRemoved() {
+		return -1
+	}
+
+	return a.nodeMapByIndex.IndexOf(node.indexNode)
+}
+
 // DeleteByCreatedAt deletes the given element.
 func (a *RGATreeList) DeleteByCreatedAt(createdAt *time.Ticket, deletedAt *time.Ticket) *RGATreeListNode {
 	node, ok := a.nodeMapByCreatedAt[createdAt.Key()]
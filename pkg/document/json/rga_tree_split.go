@@ -313,6 +313,24 @@ func (s *RGATreeSplit) findNodePos(index int) *RGATreeSplitNodePos {
 	}
 }
 
+// indexOf returns the index of the given position in the visible content.
+// The position in a removed node is regarded as the front of the node.
+func (s *RGATreeSplit) indexOf(pos *RGATreeSplitNodePos) int {
+	absoluteID := pos.getAbsoluteID()
+	node := s.findFloorNodePreferToLeft(absoluteID)
+
+	// NOTE: The initial head is not linked in the index tree if
+	// it is the only node, but its index is always 0.
+	index := 0
+	if node != s.initialHead {
+		index = s.treeByIndex.IndexOf(node.indexNode)
+	}
+	if node.removedAt == nil {
+		index += absoluteID.offset - node.id.offset
+	}
+	return index
+}
+
 func (s *RGATreeSplit) findNodeWithSplit(
 	pos *RGATreeSplitNodePos,
 	updatedAt *time.Ticket,
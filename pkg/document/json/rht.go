@@ -192,7 +192,7 @@ func (rht *RHT) Marshal() string {
 			sb.WriteString(",")
 		}
 		value := members[k]
-		sb.WriteString(fmt.Sprintf(`"%s":"%s"`, k, value))
+		sb.WriteString(fmt.Sprintf(`%s:%s`, quoteString(k), quoteString(value)))
 	}
 	sb.WriteString("}")
 
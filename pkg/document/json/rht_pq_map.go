@@ -163,6 +163,22 @@ func (rht *RHTPriorityQueueMap) DeleteByCreatedAt(createdAt *time.Ticket, delete
 	return node.elem
 }
 
+// KeyOf returns the key of the element of the given creation time. It
+// returns false if the element is not the value of the key, such as an
+// element removed or overwritten by another element.
+func (rht *RHTPriorityQueueMap) KeyOf(createdAt *time.Ticket) (string, bool) {
+	node, ok := rht.nodeMapByCreatedAt[createdAt.Key()]
+	if !ok || node.isRemoved() {
+		return "", false
+	}
+
+	elem := rht.Get(node.key)
+	if elem == nil || elem.CreatedAt().Compare(createdAt) != 0 {
+		return "", false
+	}
+	return node.key, true
+}
+
 // Elements returns a map of elements because the map easy to use for loop.
 // TODO: If we encounter performance issues, we need to replace this with other solution.
 func (rht *RHTPriorityQueueMap) Elements() map[string]Element {
@@ -228,7 +244,7 @@ func (rht *RHTPriorityQueueMap) Marshal() string {
 			sb.WriteString(",")
 		}
 		value := members[k]
-		sb.WriteString(fmt.Sprintf(`"%s":%s`, k, value.Marshal()))
+		sb.WriteString(fmt.Sprintf(`%s:%s`, quoteString(k), value.Marshal()))
 	}
 	sb.WriteString("}")
 
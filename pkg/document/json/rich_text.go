@@ -68,7 +68,7 @@ func (t *RichTextValue) Len() int {
 
 // String returns the string representation of this value.
 func (t *RichTextValue) String() string {
-	return fmt.Sprintf(`{"attrs":%s,"val":"%s"}`, t.attrs.Marshal(), t.value)
+	return fmt.Sprintf(`{"attrs":%s,"val":%s}`, t.attrs.Marshal(), quoteString(t.value))
 }
 
 // AnnotatedString returns a String containing the meta data of this value
@@ -136,6 +136,21 @@ func (t *RichText) Marshal() string {
 	return fmt.Sprintf("[%s]", strings.Join(values, ","))
 }
 
+// Content returns the visible content of this rich text except the last line.
+func (t *RichText) Content() string {
+	var sb strings.Builder
+
+	node := t.rgaTreeSplit.initialHead.next
+	for node != nil {
+		if node.createdAt().Compare(t.createdAt) != 0 && node.removedAt == nil {
+			sb.WriteString(node.value.(*RichTextValue).value)
+		}
+		node = node.next
+	}
+
+	return sb.String()
+}
+
 // DeepCopy copies itself deeply.
 func (t *RichText) DeepCopy() Element {
 	rgaTreeSplit := NewRGATreeSplit(InitialRichTextNode())
@@ -196,6 +211,11 @@ func (t *RichText) CreateRange(from, to int) (*RGATreeSplitNodePos, *RGATreeSpli
 	return t.rgaTreeSplit.createRange(from, to)
 }
 
+// IndexOf returns the integer offset of the given RGATreeSplitNodePos.
+func (t *RichText) IndexOf(pos *RGATreeSplitNodePos) int {
+	return t.rgaTreeSplit.indexOf(pos)
+}
+
 // Edit edits the given range with the given content and attributes.
 func (t *RichText) Edit(
 	from,
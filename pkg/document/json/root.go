@@ -35,23 +35,30 @@ type ElementPair struct {
 type Root struct {
 	object                               *Object
 	elementMapByCreatedAt                map[string]Element
+	parentMapByCreatedAt                 map[string]Container
 	removedElementPairMapByCreatedAt     map[string]ElementPair
 	removedNodeTextElementMapByCreatedAt map[string]TextElement
+	removedNodeTreeMapByCreatedAt        map[string]*Tree
 }
 
 // NewRoot creates a new instance of Root.
 func NewRoot(root *Object) *Root {
 	r := &Root{
 		elementMapByCreatedAt:                make(map[string]Element),
+		parentMapByCreatedAt:                 make(map[string]Container),
 		removedElementPairMapByCreatedAt:     make(map[string]ElementPair),
 		removedNodeTextElementMapByCreatedAt: make(map[string]TextElement),
+		removedNodeTreeMapByCreatedAt:        make(map[string]*Tree),
 	}
 
 	r.object = root
-	r.RegisterElement(root)
+	r.RegisterElement(nil, root)
 
 	root.Descendants(func(elem Element, parent Container) bool {
-		r.RegisterElement(elem)
+		r.RegisterElement(parent, elem)
+		if tree, ok := elem.(*Tree); ok && len(tree.removedNodeMap) > 0 {
+			r.RegisterRemovedNodeTree(tree)
+		}
 		return false
 	})
 
@@ -68,15 +75,26 @@ func (r *Root) FindByCreatedAt(createdAt *time.Ticket) Element {
 	return r.elementMapByCreatedAt[createdAt.Key()]
 }
 
-// RegisterElement registers the given element to hash table.
-func (r *Root) RegisterElement(elem Element) {
+// FindParentByCreatedAt returns the parent of the element of given creation
+// time. It returns nil for the root object.
+func (r *Root) FindParentByCreatedAt(createdAt *time.Ticket) Container {
+	return r.parentMapByCreatedAt[createdAt.Key()]
+}
+
+// RegisterElement registers the given element of the given parent to hash
+// tables. The parent of the root object is nil.
+func (r *Root) RegisterElement(parent Container, elem Element) {
 	r.elementMapByCreatedAt[elem.CreatedAt().Key()] = elem
+	if parent != nil {
+		r.parentMapByCreatedAt[elem.CreatedAt().Key()] = parent
+	}
 }
 
 // DeregisterElement deregister the given element from hash tables.
 func (r *Root) DeregisterElement(elem Element) {
 	createdAt := elem.CreatedAt().Key()
 	delete(r.elementMapByCreatedAt, createdAt)
+	delete(r.parentMapByCreatedAt, createdAt)
 	delete(r.removedElementPairMapByCreatedAt, createdAt)
 }
 
@@ -93,6 +111,11 @@ func (r *Root) RegisterRemovedNodeTextElement(textType TextElement) {
 	r.removedNodeTextElementMapByCreatedAt[textType.CreatedAt().Key()] = textType
 }
 
+// RegisterRemovedNodeTree register the given tree to hash table.
+func (r *Root) RegisterRemovedNodeTree(tree *Tree) {
+	r.removedNodeTreeMapByCreatedAt[tree.CreatedAt().Key()] = tree
+}
+
 // DeepCopy copies itself deeply.
 func (r *Root) DeepCopy() *Root {
 	return NewRoot(r.object.DeepCopy().(*Object))
@@ -117,6 +140,13 @@ func (r *Root) GarbageCollect(ticket *time.Ticket) int {
 		count += removedNodeCnt
 	}
 
+	for _, tree := range r.removedNodeTreeMapByCreatedAt {
+		count += tree.cleanupRemovedNodes(ticket)
+		if len(tree.removedNodeMap) == 0 {
+			delete(r.removedNodeTreeMapByCreatedAt, tree.CreatedAt().Key())
+		}
+	}
+
 	return count
 }
 
@@ -140,6 +170,10 @@ func (r *Root) GarbageLen() int {
 		count += text.removedNodesLen()
 	}
 
+	for _, tree := range r.removedNodeTreeMapByCreatedAt {
+		count += tree.removedNodesLen()
+	}
+
 	return count
 }
 
@@ -158,7 +192,61 @@ func (r *Root) garbageCollect(elem Element) int {
 		elem.Descendants(callback)
 	case *Array:
 		elem.Descendants(callback)
+	case *Set:
+		elem.Descendants(callback)
 	}
 
 	return count
 }
+
+// MaxLamport returns the maximum lamport of the tickets held by this root,
+// such as the times of the elements, the nodes of texts and trees, and their
+// attributes. Tickets of purged elements and nodes are not counted.
+func (r *Root) MaxLamport() uint64 {
+	var lamport uint64
+	sync := func(ticket *time.Ticket) {
+		if ticket != nil && lamport < ticket.Lamport() {
+			lamport = ticket.Lamport()
+		}
+	}
+	syncRHT := func(rht *RHT) {
+		for _, node := range rht.nodeMapByCreatedAt {
+			sync(node.updatedAt)
+			sync(node.removedAt)
+		}
+	}
+	syncRGATreeSplit := func(s *RGATreeSplit, selectionMap map[string]*Selection) {
+		for _, node := range s.nodes() {
+			sync(node.createdAt())
+			sync(node.removedAt)
+			if value, ok := node.value.(*RichTextValue); ok {
+				syncRHT(value.attrs)
+			}
+		}
+		for _, selection := range selectionMap {
+			sync(selection.updatedAt)
+		}
+	}
+
+	for _, elem := range r.elementMapByCreatedAt {
+		sync(elem.CreatedAt())
+		sync(elem.MovedAt())
+		sync(elem.RemovedAt())
+
+		switch elem := elem.(type) {
+		case *Text:
+			syncRGATreeSplit(elem.rgaTreeSplit, elem.selectionMap)
+		case *RichText:
+			syncRGATreeSplit(elem.rgaTreeSplit, elem.selectionMap)
+		case *Tree:
+			elem.root.descendants(func(node *TreeNode) {
+				sync(node.id)
+				sync(node.movedAt)
+				sync(node.removedAt)
+				syncRHT(node.attrs)
+			})
+		}
+	}
+
+	return lamport
+}
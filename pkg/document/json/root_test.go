@@ -190,4 +190,47 @@ func TestRoot(t *testing.T) {
 		assert.Equal(t, 1, root.GarbageCollect(time.MaxTicket))
 		assert.Equal(t, 0, root.GarbageLen())
 	})
+
+	t.Run("garbage collection for tree test", func(t *testing.T) {
+		root := helper.TestRoot()
+		ctx := helper.TextChangeContext(root)
+		tree := json.NewInitialTree(ctx.IssueTimeTicket())
+
+		p := json.NewTreeNode(ctx.IssueTimeTicket(), "p", "", nil)
+		p.Append(json.NewTreeNode(ctx.IssueTimeTicket(), json.TreeTextType, "Hello", nil))
+		assert.NoError(t, tree.InsertNode(tree.Root().ID(), nil, p, p.ID()))
+		assert.Equal(t, 0, root.GarbageLen())
+
+		removed, err := tree.RemoveNode(p.ID(), ctx.IssueTimeTicket())
+		assert.NoError(t, err)
+		assert.True(t, removed)
+		root.RegisterRemovedNodeTree(tree)
+		assert.Equal(t, `{"type":"root","children":[]}`, tree.Marshal())
+		assert.Equal(t, 2, root.GarbageLen())
+
+		assert.Equal(t, 2, root.GarbageCollect(time.MaxTicket))
+		assert.Equal(t, 0, root.GarbageLen())
+		assert.Len(t, tree.Root().AllChildren(), 0)
+		assert.Nil(t, tree.FindNode(p.ID()))
+	})
+
+	t.Run("garbage collection for set test", func(t *testing.T) {
+		root := helper.TestRoot()
+		ctx := helper.TextChangeContext(root)
+		set := json.NewSet(ctx.IssueTimeTicket())
+
+		set.Add(json.NewPrimitive("a", ctx.IssueTimeTicket()))
+		set.Add(json.NewPrimitive("b", ctx.IssueTimeTicket()))
+		assert.Equal(t, `["a","b"]`, set.Marshal())
+
+		target := set.Elements()[0]
+		set.DeleteByCreatedAt(target.CreatedAt(), ctx.IssueTimeTicket())
+		root.RegisterRemovedElementPair(set, target)
+		assert.Equal(t, `["b"]`, set.Marshal())
+		assert.Equal(t, 1, root.GarbageLen())
+
+		assert.Equal(t, 1, root.GarbageCollect(time.MaxTicket))
+		assert.Equal(t, 0, root.GarbageLen())
+		assert.Len(t, set.Nodes(), 1)
+	})
 }
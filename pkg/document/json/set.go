@@ -0,0 +1,197 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+)
+
+// Set represents a set of primitive values including logical clock. It is an
+// observed-remove set: every addition of a value is kept as an element of its
+// own, and a removal only removes the elements that the remover has observed.
+// So a value added concurrently with its removal remains in the set.
+// Set implements Container interface.
+type Set struct {
+	elementMapByCreatedAt map[string]*Primitive
+	createdAt             *time.Ticket
+	movedAt               *time.Ticket
+	removedAt             *time.Ticket
+}
+
+// NewSet creates a new instance of Set.
+func NewSet(createdAt *time.Ticket) *Set {
+	return &Set{
+		elementMapByCreatedAt: make(map[string]*Primitive),
+		createdAt:             createdAt,
+	}
+}
+
+// Add adds the given element to this set.
+func (s *Set) Add(elem *Primitive) *Set {
+	s.elementMapByCreatedAt[elem.CreatedAt().Key()] = elem
+	return s
+}
+
+// Has returns whether this set has the value of the given element or not.
+func (s *Set) Has(value *Primitive) bool {
+	return len(s.FindAll(value)) > 0
+}
+
+// FindAll returns the elements that are not removed and have the value of the
+// given element.
+func (s *Set) FindAll(value *Primitive) []*Primitive {
+	key := valueKeyOf(value)
+
+	var elems []*Primitive
+	for _, elem := range s.Nodes() {
+		if elem.RemovedAt() == nil && valueKeyOf(elem) == key {
+			elems = append(elems, elem)
+		}
+	}
+	return elems
+}
+
+// Elements returns the distinct values of this set. Each value is represented
+// by the earliest element of it that is not removed.
+func (s *Set) Elements() []*Primitive {
+	added := make(map[string]bool)
+
+	var elems []*Primitive
+	for _, elem := range s.Nodes() {
+		if elem.RemovedAt() != nil {
+			continue
+		}
+
+		key := valueKeyOf(elem)
+		if added[key] {
+			continue
+		}
+		added[key] = true
+		elems = append(elems, elem)
+	}
+	return elems
+}
+
+// Nodes returns all the elements of this set including the removed ones in
+// the order of their creation.
+func (s *Set) Nodes() []*Primitive {
+	var elems []*Primitive
+	for _, elem := range s.elementMapByCreatedAt {
+		elems = append(elems, elem)
+	}
+
+	sort.Slice(elems, func(i, j int) bool {
+		return elems[i].CreatedAt().Compare(elems[j].CreatedAt()) < 0
+	})
+	return elems
+}
+
+// Len returns the number of the distinct values of this set.
+func (s *Set) Len() int {
+	return len(s.Elements())
+}
+
+// Purge physically purge child element.
+func (s *Set) Purge(elem Element) {
+	delete(s.elementMapByCreatedAt, elem.CreatedAt().Key())
+}
+
+// Descendants traverse the descendants of this set.
+func (s *Set) Descendants(callback func(elem Element, parent Container) bool) {
+	for _, elem := range s.Nodes() {
+		if callback(elem, s) {
+			return
+		}
+	}
+}
+
+// DeleteByCreatedAt deletes the element of the given creation time.
+func (s *Set) DeleteByCreatedAt(createdAt *time.Ticket, deletedAt *time.Ticket) Element {
+	elem, ok := s.elementMapByCreatedAt[createdAt.Key()]
+	if !ok {
+		return nil
+	}
+
+	elem.Remove(deletedAt)
+	return elem
+}
+
+// Marshal returns the JSON encoding of this set.
+func (s *Set) Marshal() string {
+	var values []string
+	for _, elem := range s.Elements() {
+		values = append(values, elem.Marshal())
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(values, ","))
+}
+
+// DeepCopy copies itself deeply.
+func (s *Set) DeepCopy() Element {
+	set := NewSet(s.createdAt)
+	for _, elem := range s.elementMapByCreatedAt {
+		set.Add(elem.DeepCopy().(*Primitive))
+	}
+
+	set.movedAt = s.movedAt
+	set.removedAt = s.removedAt
+	return set
+}
+
+// CreatedAt returns the creation time of this set.
+func (s *Set) CreatedAt() *time.Ticket {
+	return s.createdAt
+}
+
+// MovedAt returns the move time of this set.
+func (s *Set) MovedAt() *time.Ticket {
+	return s.movedAt
+}
+
+// SetMovedAt sets the move time of this set.
+func (s *Set) SetMovedAt(movedAt *time.Ticket) {
+	s.movedAt = movedAt
+}
+
+// RemovedAt returns the removal time of this set.
+func (s *Set) RemovedAt() *time.Ticket {
+	return s.removedAt
+}
+
+// SetRemovedAt sets the removal time of this set.
+func (s *Set) SetRemovedAt(removedAt *time.Ticket) {
+	s.removedAt = removedAt
+}
+
+// Remove removes this set.
+func (s *Set) Remove(removedAt *time.Ticket) bool {
+	if (removedAt != nil && removedAt.After(s.createdAt)) &&
+		(s.removedAt == nil || removedAt.After(s.removedAt)) {
+		s.removedAt = removedAt
+		return true
+	}
+	return false
+}
+
+// valueKeyOf returns the key that identifies the value of the given element.
+func valueKeyOf(elem *Primitive) string {
+	return fmt.Sprintf("%d:%x", elem.ValueType(), elem.Bytes())
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/test/helper"
+)
+
+func TestSet(t *testing.T) {
+	t.Run("marshal test", func(t *testing.T) {
+		root := helper.TestRoot()
+		ctx := helper.TextChangeContext(root)
+		set := json.NewSet(ctx.IssueTimeTicket())
+		assert.Equal(t, "[]", set.Marshal())
+
+		set.Add(json.NewPrimitive("a", ctx.IssueTimeTicket()))
+		set.Add(json.NewPrimitive(1, ctx.IssueTimeTicket()))
+		set.Add(json.NewPrimitive("a", ctx.IssueTimeTicket()))
+		assert.Equal(t, `["a",1]`, set.Marshal())
+		assert.Equal(t, 2, set.Len())
+		assert.True(t, set.Has(json.NewPrimitive("a", ctx.IssueTimeTicket())))
+		assert.False(t, set.Has(json.NewPrimitive(int64(1), ctx.IssueTimeTicket())))
+	})
+
+	t.Run("observed remove test", func(t *testing.T) {
+		root := helper.TestRoot()
+		ctx := helper.TextChangeContext(root)
+		set := json.NewSet(ctx.IssueTimeTicket())
+
+		a1 := json.NewPrimitive("a", ctx.IssueTimeTicket())
+		set.Add(a1)
+		observed := set.FindAll(a1)
+		assert.Len(t, observed, 1)
+
+		// a concurrent addition that was not observed by the removal.
+		a2 := json.NewPrimitive("a", ctx.IssueTimeTicket())
+		set.Add(a2)
+
+		for _, elem := range observed {
+			set.DeleteByCreatedAt(elem.CreatedAt(), ctx.IssueTimeTicket())
+		}
+		assert.Equal(t, `["a"]`, set.Marshal())
+		assert.Len(t, set.Nodes(), 2)
+
+		set.DeleteByCreatedAt(a2.CreatedAt(), ctx.IssueTimeTicket())
+		assert.Equal(t, `[]`, set.Marshal())
+		assert.Len(t, set.DeepCopy().(*json.Set).Nodes(), 2)
+	})
+}
@@ -17,7 +17,6 @@
 package json
 
 import (
-	"fmt"
 	"unicode/utf16"
 
 	"github.com/yorkie-team/yorkie/internal/log"
@@ -97,7 +96,12 @@ func NewText(elements *RGATreeSplit, createdAt *time.Ticket) *Text {
 
 // Marshal returns the JSON encoding of this text.
 func (t *Text) Marshal() string {
-	return fmt.Sprintf("\"%s\"", t.rgaTreeSplit.marshal())
+	return quoteString(t.rgaTreeSplit.marshal())
+}
+
+// Content returns the visible content of this text.
+func (t *Text) Content() string {
+	return t.rgaTreeSplit.marshal()
 }
 
 // DeepCopy copies itself deeply.
@@ -160,6 +164,11 @@ func (t *Text) CreateRange(from, to int) (*RGATreeSplitNodePos, *RGATreeSplitNod
 	return t.rgaTreeSplit.createRange(from, to)
 }
 
+// IndexOf returns the integer offset of the given RGATreeSplitNodePos.
+func (t *Text) IndexOf(pos *RGATreeSplitNodePos) int {
+	return t.rgaTreeSplit.indexOf(pos)
+}
+
 // Edit edits the given range with the given content.
 func (t *Text) Edit(
 	from,
@@ -0,0 +1,499 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+)
+
+const (
+	// TreeRootType is the type of the root node of Tree.
+	TreeRootType = "root"
+
+	// TreeTextType is the type of the text node of Tree. A text node has a
+	// value and cannot have children.
+	TreeTextType = "text"
+)
+
+var (
+	// ErrTreeNodeNotFound is returned when the node of the given ID could not
+	// be found in the tree.
+	ErrTreeNodeNotFound = errors.New("tree node not found")
+
+	// ErrInvalidTreeNode is returned when the given node cannot be placed in
+	// the requested position, such as a child of a text node.
+	ErrInvalidTreeNode = errors.New("invalid tree node")
+)
+
+// TreeNode is a node of Tree. An element node such as a paragraph has
+// attributes and children, and a text node has a value.
+//
+// Each node is identified by the time ticket of its creation. Removed nodes
+// are kept as tombstones until they are purged by the garbage collector.
+type TreeNode struct {
+	id        *time.Ticket
+	nodeType  string
+	value     string
+	attrs     *RHT
+	parent    *TreeNode
+	children  []*TreeNode
+	movedAt   *time.Ticket
+	removedAt *time.Ticket
+}
+
+// NewTreeNode creates a new instance of TreeNode.
+func NewTreeNode(id *time.Ticket, nodeType string, value string, attrs *RHT) *TreeNode {
+	if attrs == nil {
+		attrs = NewRHT()
+	}
+
+	return &TreeNode{
+		id:       id,
+		nodeType: nodeType,
+		value:    value,
+		attrs:    attrs,
+	}
+}
+
+// ID returns the ID of this node.
+func (n *TreeNode) ID() *time.Ticket {
+	return n.id
+}
+
+// Type returns the type of this node.
+func (n *TreeNode) Type() string {
+	return n.nodeType
+}
+
+// Value returns the value of this node if it is a text node.
+func (n *TreeNode) Value() string {
+	return n.value
+}
+
+// IsText returns whether this node is a text node or not.
+func (n *TreeNode) IsText() bool {
+	return n.nodeType == TreeTextType
+}
+
+// Attrs returns the attributes of this node.
+func (n *TreeNode) Attrs() *RHT {
+	return n.attrs
+}
+
+// Parent returns the parent of this node.
+func (n *TreeNode) Parent() *TreeNode {
+	return n.parent
+}
+
+// Children returns the children of this node except the removed ones.
+func (n *TreeNode) Children() []*TreeNode {
+	var children []*TreeNode
+	for _, child := range n.children {
+		if child.removedAt == nil {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// AllChildren returns the children of this node including the removed ones.
+func (n *TreeNode) AllChildren() []*TreeNode {
+	return n.children
+}
+
+// Append appends the given nodes to the end of the children of this node
+// as they are. It is used to build a tree from its encoded form.
+func (n *TreeNode) Append(children ...*TreeNode) *TreeNode {
+	for _, child := range children {
+		child.parent = n
+		n.children = append(n.children, child)
+	}
+	return n
+}
+
+// MovedAt returns the move time of this node.
+func (n *TreeNode) MovedAt() *time.Ticket {
+	return n.movedAt
+}
+
+// SetMovedAt sets the move time of this node.
+func (n *TreeNode) SetMovedAt(movedAt *time.Ticket) {
+	n.movedAt = movedAt
+}
+
+// RemovedAt returns the removal time of this node.
+func (n *TreeNode) RemovedAt() *time.Ticket {
+	return n.removedAt
+}
+
+// SetRemovedAt sets the removal time of this node.
+func (n *TreeNode) SetRemovedAt(removedAt *time.Ticket) {
+	n.removedAt = removedAt
+}
+
+// positionedAt returns the time this node was placed at its position.
+func (n *TreeNode) positionedAt() *time.Ticket {
+	if n.movedAt != nil {
+		return n.movedAt
+	}
+	return n.id
+}
+
+// Marshal returns the JSON encoding of this node.
+func (n *TreeNode) Marshal() string {
+	if n.IsText() {
+		return fmt.Sprintf(`{"type":%s,"value":%s}`, quoteString(n.nodeType), quoteString(n.value))
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf(`{"type":%s`, quoteString(n.nodeType)))
+	if len(n.attrs.Elements()) > 0 {
+		sb.WriteString(fmt.Sprintf(`,"attributes":%s`, n.attrs.Marshal()))
+	}
+
+	var children []string
+	for _, child := range n.Children() {
+		children = append(children, child.Marshal())
+	}
+	sb.WriteString(fmt.Sprintf(`,"children":[%s]}`, strings.Join(children, ",")))
+
+	return sb.String()
+}
+
+// DeepCopy copies this node and its descendants deeply.
+func (n *TreeNode) DeepCopy() *TreeNode {
+	node := NewTreeNode(n.id, n.nodeType, n.value, n.attrs.DeepCopy())
+	node.movedAt = n.movedAt
+	node.removedAt = n.removedAt
+	for _, child := range n.children {
+		node.Append(child.DeepCopy())
+	}
+	return node
+}
+
+// descendants calls the given callback for this node and its descendants.
+func (n *TreeNode) descendants(callback func(node *TreeNode)) {
+	callback(n)
+	for _, child := range n.children {
+		child.descendants(callback)
+	}
+}
+
+// hasRemovedAncestor returns whether one of the ancestors of this node has
+// been removed.
+func (n *TreeNode) hasRemovedAncestor() bool {
+	for parent := n.parent; parent != nil; parent = parent.parent {
+		if parent.removedAt != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// indexOf returns the index of the given child in the children of this node.
+func (n *TreeNode) indexOf(child *TreeNode) int {
+	for i, c := range n.children {
+		if c == child {
+			return i
+		}
+	}
+	return -1
+}
+
+// insertAfter inserts the given node after the given previous child. If
+// there are children inserted concurrently after the previous child, the node
+// is placed after the ones that were inserted later, as RGA does.
+func (n *TreeNode) insertAfter(prev *TreeNode, node *TreeNode, executedAt *time.Ticket) {
+	index := 0
+	if prev != nil {
+		index = n.indexOf(prev) + 1
+	}
+
+	for index < len(n.children) && n.children[index].positionedAt().After(executedAt) {
+		index++
+	}
+
+	n.children = append(n.children, nil)
+	copy(n.children[index+1:], n.children[index:])
+	n.children[index] = node
+	node.parent = n
+}
+
+// release detaches the given child from the children of this node.
+func (n *TreeNode) release(child *TreeNode) {
+	if index := n.indexOf(child); index >= 0 {
+		n.children = append(n.children[:index], n.children[index+1:]...)
+	}
+	child.parent = nil
+}
+
+// Tree is an extended data type for the contents of a block-structured
+// document such as paragraphs, lists and tables.
+type Tree struct {
+	root           *TreeNode
+	nodeMapByID    map[string]*TreeNode
+	removedNodeMap map[string]*TreeNode
+	createdAt      *time.Ticket
+	movedAt        *time.Ticket
+	removedAt      *time.Ticket
+}
+
+// NewTree creates a new instance of Tree with the given root node.
+func NewTree(root *TreeNode, createdAt *time.Ticket) *Tree {
+	tree := &Tree{
+		root:           root,
+		nodeMapByID:    make(map[string]*TreeNode),
+		removedNodeMap: make(map[string]*TreeNode),
+		createdAt:      createdAt,
+	}
+
+	root.descendants(func(node *TreeNode) {
+		tree.nodeMapByID[node.id.Key()] = node
+		if node.removedAt != nil {
+			tree.removedNodeMap[node.id.Key()] = node
+		}
+	})
+
+	return tree
+}
+
+// NewInitialTree creates a new instance of Tree with an empty root node.
+func NewInitialTree(createdAt *time.Ticket) *Tree {
+	return NewTree(NewTreeNode(createdAt, TreeRootType, "", nil), createdAt)
+}
+
+// Root returns the root node of this tree.
+func (t *Tree) Root() *TreeNode {
+	return t.root
+}
+
+// FindNode returns the node of the given ID.
+func (t *Tree) FindNode(id *time.Ticket) *TreeNode {
+	return t.nodeMapByID[id.Key()]
+}
+
+// InsertNode inserts the given node and its descendants after the given previous
+// node under the given parent node. If the previous node is nil, the node is
+// inserted at the front of the children.
+func (t *Tree) InsertNode(
+	parentID *time.Ticket,
+	prevID *time.Ticket,
+	node *TreeNode,
+	executedAt *time.Ticket,
+) error {
+	parent, err := t.findNode(parentID)
+	if err != nil {
+		return err
+	}
+	if parent.IsText() {
+		return fmt.Errorf("insert into text node %s: %w", parentID.Key(), ErrInvalidTreeNode)
+	}
+
+	var prev *TreeNode
+	if prevID != nil {
+		if prev, err = t.findNode(prevID); err != nil {
+			return err
+		}
+		if prev.parent != parent {
+			return fmt.Errorf("insert after %s: %w", prevID.Key(), ErrInvalidTreeNode)
+		}
+	}
+
+	parent.insertAfter(prev, node, executedAt)
+	node.descendants(func(node *TreeNode) {
+		t.nodeMapByID[node.id.Key()] = node
+		if node.removedAt != nil {
+			t.removedNodeMap[node.id.Key()] = node
+		}
+	})
+
+	return nil
+}
+
+// RemoveNode removes the node of the given ID and its descendants. It returns
+// whether the node has been removed by this call.
+func (t *Tree) RemoveNode(id *time.Ticket, executedAt *time.Ticket) (bool, error) {
+	node, err := t.findNode(id)
+	if err != nil {
+		return false, err
+	}
+	if node == t.root {
+		return false, fmt.Errorf("remove root node: %w", ErrInvalidTreeNode)
+	}
+
+	if !executedAt.After(node.id) || (node.removedAt != nil && !executedAt.After(node.removedAt)) {
+		return false, nil
+	}
+
+	node.removedAt = executedAt
+	t.removedNodeMap[node.id.Key()] = node
+	return true, nil
+}
+
+// MoveNode moves the node of the given ID after the given previous node among its
+// siblings. If the previous node is nil, the node is moved to the front.
+//
+// NOTE: Moving a node under another parent can make a cycle with
+// a concurrent move, so MoveNode only reorders the node within its parent.
+func (t *Tree) MoveNode(id *time.Ticket, prevID *time.Ticket, executedAt *time.Ticket) error {
+	node, err := t.findNode(id)
+	if err != nil {
+		return err
+	}
+	if node == t.root {
+		return fmt.Errorf("move root node: %w", ErrInvalidTreeNode)
+	}
+
+	var prev *TreeNode
+	if prevID != nil {
+		if prev, err = t.findNode(prevID); err != nil {
+			return err
+		}
+		if prev.parent != node.parent {
+			return fmt.Errorf("move after %s: %w", prevID.Key(), ErrInvalidTreeNode)
+		}
+	}
+
+	if prev == node {
+		return nil
+	}
+
+	if node.movedAt == nil || executedAt.After(node.movedAt) {
+		parent := node.parent
+		parent.release(node)
+		parent.insertAfter(prev, node, executedAt)
+		node.movedAt = executedAt
+	}
+
+	return nil
+}
+
+// StyleNode sets the given attributes to the node of the given ID.
+func (t *Tree) StyleNode(id *time.Ticket, attrs map[string]string, executedAt *time.Ticket) error {
+	node, err := t.findNode(id)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range attrs {
+		node.attrs.Set(k, v, executedAt)
+	}
+
+	return nil
+}
+
+// Marshal returns the JSON encoding of this tree.
+func (t *Tree) Marshal() string {
+	return t.root.Marshal()
+}
+
+// DeepCopy copies itself deeply.
+func (t *Tree) DeepCopy() Element {
+	tree := NewTree(t.root.DeepCopy(), t.createdAt)
+	tree.movedAt = t.movedAt
+	tree.removedAt = t.removedAt
+	return tree
+}
+
+// CreatedAt returns the creation time of this tree.
+func (t *Tree) CreatedAt() *time.Ticket {
+	return t.createdAt
+}
+
+// MovedAt returns the move time of this tree.
+func (t *Tree) MovedAt() *time.Ticket {
+	return t.movedAt
+}
+
+// SetMovedAt sets the move time of this tree.
+func (t *Tree) SetMovedAt(movedAt *time.Ticket) {
+	t.movedAt = movedAt
+}
+
+// RemovedAt returns the removal time of this tree.
+func (t *Tree) RemovedAt() *time.Ticket {
+	return t.removedAt
+}
+
+// SetRemovedAt sets the removal time of this tree.
+func (t *Tree) SetRemovedAt(removedAt *time.Ticket) {
+	t.removedAt = removedAt
+}
+
+// Remove removes this tree.
+func (t *Tree) Remove(removedAt *time.Ticket) bool {
+	if (removedAt != nil && removedAt.After(t.createdAt)) &&
+		(t.removedAt == nil || removedAt.After(t.removedAt)) {
+		t.removedAt = removedAt
+		return true
+	}
+	return false
+}
+
+func (t *Tree) findNode(id *time.Ticket) (*TreeNode, error) {
+	node, ok := t.nodeMapByID[id.Key()]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", id.Key(), ErrTreeNodeNotFound)
+	}
+	return node, nil
+}
+
+// removedNodesLen returns the number of the removed nodes and their
+// descendants.
+func (t *Tree) removedNodesLen() int {
+	count := 0
+	for _, node := range t.removedNodeMap {
+		if node.hasRemovedAncestor() {
+			continue
+		}
+		node.descendants(func(node *TreeNode) {
+			count++
+		})
+	}
+	return count
+}
+
+// cleanupRemovedNodes purges the nodes removed before the given time with
+// their descendants and returns the number of the purged nodes.
+func (t *Tree) cleanupRemovedNodes(ticket *time.Ticket) int {
+	count := 0
+	for key, node := range t.removedNodeMap {
+		if _, ok := t.nodeMapByID[key]; !ok {
+			// NOTE: The node has already been purged along with
+			// its removed ancestor.
+			delete(t.removedNodeMap, key)
+			continue
+		}
+
+		if ticket.Compare(node.removedAt) < 0 {
+			continue
+		}
+
+		node.parent.release(node)
+		node.descendants(func(node *TreeNode) {
+			delete(t.nodeMapByID, node.id.Key())
+			delete(t.removedNodeMap, node.id.Key())
+			count++
+		})
+	}
+
+	return count
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+	"github.com/yorkie-team/yorkie/test/helper"
+)
+
+func TestTree(t *testing.T) {
+	t.Run("marshal test", func(t *testing.T) {
+		root := helper.TestRoot()
+		ctx := helper.TextChangeContext(root)
+		tree := json.NewInitialTree(ctx.IssueTimeTicket())
+		assert.Equal(t, `{"type":"root","children":[]}`, tree.Marshal())
+
+		p := json.NewTreeNode(ctx.IssueTimeTicket(), "p", "", nil)
+		p.Append(json.NewTreeNode(ctx.IssueTimeTicket(), json.TreeTextType, "Hello", nil))
+		assert.NoError(t, tree.InsertNode(tree.Root().ID(), nil, p, p.ID()))
+		assert.Equal(
+			t,
+			`{"type":"root","children":[{"type":"p","children":[{"type":"text","value":"Hello"}]}]}`,
+			tree.Marshal(),
+		)
+
+		assert.NoError(t, tree.StyleNode(p.ID(), map[string]string{"align": "center"}, ctx.IssueTimeTicket()))
+		assert.Equal(
+			t,
+			`{"type":"root","children":[{"type":"p","attributes":{"align":"center"},`+
+				`"children":[{"type":"text","value":"Hello"}]}]}`,
+			tree.Marshal(),
+		)
+
+		text := p.Children()[0]
+		err := tree.InsertNode(text.ID(), nil, json.NewTreeNode(ctx.IssueTimeTicket(), "p", "", nil), text.ID())
+		assert.ErrorIs(t, err, json.ErrInvalidTreeNode)
+
+		_, err = tree.RemoveNode(ctx.IssueTimeTicket(), ctx.IssueTimeTicket())
+		assert.ErrorIs(t, err, json.ErrTreeNodeNotFound)
+	})
+
+	t.Run("concurrent insert test", func(t *testing.T) {
+		root := helper.TestRoot()
+		ctx := helper.TextChangeContext(root)
+		createdAt := ctx.IssueTimeTicket()
+		t1, t2 := ctx.IssueTimeTicket(), ctx.IssueTimeTicket()
+
+		tree1 := json.NewInitialTree(createdAt)
+		assert.NoError(t, tree1.InsertNode(createdAt, nil, json.NewTreeNode(t1, "p", "", nil), t1))
+		assert.NoError(t, tree1.InsertNode(createdAt, nil, json.NewTreeNode(t2, "h1", "", nil), t2))
+
+		tree2 := json.NewInitialTree(createdAt)
+		assert.NoError(t, tree2.InsertNode(createdAt, nil, json.NewTreeNode(t2, "h1", "", nil), t2))
+		assert.NoError(t, tree2.InsertNode(createdAt, nil, json.NewTreeNode(t1, "p", "", nil), t1))
+
+		assert.Equal(t, `{"type":"root","children":[{"type":"h1","children":[]},{"type":"p","children":[]}]}`,
+			tree1.Marshal())
+		assert.Equal(t, tree1.Marshal(), tree2.Marshal())
+	})
+
+	t.Run("move and remove test", func(t *testing.T) {
+		root := helper.TestRoot()
+		ctx := helper.TextChangeContext(root)
+		tree := json.NewInitialTree(ctx.IssueTimeTicket())
+
+		var prevID *time.Ticket
+		for _, nodeType := range []string{"a", "b", "c"} {
+			node := json.NewTreeNode(ctx.IssueTimeTicket(), nodeType, "", nil)
+			assert.NoError(t, tree.InsertNode(tree.Root().ID(), prevID, node, node.ID()))
+			prevID = node.ID()
+		}
+		children := tree.Root().Children()
+
+		assert.NoError(t, tree.MoveNode(children[2].ID(), nil, ctx.IssueTimeTicket()))
+		assert.Equal(t, `{"type":"root","children":[{"type":"c","children":[]},`+
+			`{"type":"a","children":[]},{"type":"b","children":[]}]}`, tree.Marshal())
+
+		removed, err := tree.RemoveNode(children[0].ID(), ctx.IssueTimeTicket())
+		assert.NoError(t, err)
+		assert.True(t, removed)
+		assert.Equal(t, `{"type":"root","children":[{"type":"c","children":[]},{"type":"b","children":[]}]}`,
+			tree.Marshal())
+		assert.Len(t, tree.Root().AllChildren(), 3)
+
+		copied := tree.DeepCopy().(*json.Tree)
+		assert.Equal(t, tree.Marshal(), copied.Marshal())
+		assert.Len(t, copied.Root().AllChildren(), 3)
+	})
+}
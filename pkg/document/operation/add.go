@@ -21,12 +21,14 @@ import (
 	"github.com/yorkie-team/yorkie/pkg/document/time"
 )
 
-// Add is an operation representing adding an element to an Array.
+// Add is an operation representing adding an element to an Array or a Set.
 type Add struct {
-	// parentCreatedAt is the creation time of the Array that executes Add.
+	// parentCreatedAt is the creation time of the Array or the Set that
+	// executes Add.
 	parentCreatedAt *time.Ticket
 
-	// prevCreatedAt is the creation time of the previous element.
+	// prevCreatedAt is the creation time of the previous element. It is nil
+	// if the parent is a Set.
 	prevCreatedAt *time.Ticket
 
 	// value is an element added by the insert operation.
@@ -55,15 +57,22 @@ func NewAdd(
 func (o *Add) Execute(root *json.Root) error {
 	parent := root.FindByCreatedAt(o.parentCreatedAt)
 
-	obj, ok := parent.(*json.Array)
-	if !ok {
+	value := o.value.DeepCopy()
+	switch parent := parent.(type) {
+	case *json.Array:
+		parent.InsertAfter(o.prevCreatedAt, value)
+		root.RegisterElement(parent, value)
+	case *json.Set:
+		primitive, ok := value.(*json.Primitive)
+		if !ok {
+			return ErrNotApplicableDataType
+		}
+		parent.Add(primitive)
+		root.RegisterElement(parent, value)
+	default:
 		return ErrNotApplicableDataType
 	}
 
-	value := o.value.DeepCopy()
-	obj.InsertAfter(o.prevCreatedAt, value)
-
-	root.RegisterElement(value)
 	return nil
 }
 
@@ -72,7 +81,7 @@ func (o *Add) Value() json.Element {
 	return o.value
 }
 
-// ParentCreatedAt returns the creation time of the Array.
+// ParentCreatedAt returns the creation time of the Array or the Set.
 func (o *Add) ParentCreatedAt() *time.Ticket {
 	return o.parentCreatedAt
 }
@@ -63,7 +63,7 @@ func (o *Set) Execute(root *json.Root) error {
 
 	value := o.value.DeepCopy()
 	removed := obj.Set(o.key, value)
-	root.RegisterElement(value)
+	root.RegisterElement(obj, value)
 	if removed != nil {
 		root.RegisterRemovedElementPair(obj, removed)
 	}
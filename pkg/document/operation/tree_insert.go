@@ -0,0 +1,101 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operation
+
+import (
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+)
+
+// TreeInsert is an operation representing inserting a node into Tree.
+type TreeInsert struct {
+	// parentCreatedAt is the creation time of the Tree that executes
+	// TreeInsert.
+	parentCreatedAt *time.Ticket
+
+	// parentID is the ID of the parent node of the inserted node.
+	parentID *time.Ticket
+
+	// prevID is the ID of the previous sibling of the inserted node. If it is
+	// nil, the node is inserted at the front of the children.
+	prevID *time.Ticket
+
+	// node is the node inserted by this operation with its descendants.
+	node *json.TreeNode
+
+	// executedAt is the time the operation was executed.
+	executedAt *time.Ticket
+}
+
+// NewTreeInsert creates a new instance of TreeInsert.
+func NewTreeInsert(
+	parentCreatedAt *time.Ticket,
+	parentID *time.Ticket,
+	prevID *time.Ticket,
+	node *json.TreeNode,
+	executedAt *time.Ticket,
+) *TreeInsert {
+	return &TreeInsert{
+		parentCreatedAt: parentCreatedAt,
+		parentID:        parentID,
+		prevID:          prevID,
+		node:            node,
+		executedAt:      executedAt,
+	}
+}
+
+// Execute executes this operation on the given document(`root`).
+func (o *TreeInsert) Execute(root *json.Root) error {
+	parent := root.FindByCreatedAt(o.parentCreatedAt)
+
+	tree, ok := parent.(*json.Tree)
+	if !ok {
+		return ErrNotApplicableDataType
+	}
+
+	return tree.InsertNode(o.parentID, o.prevID, o.node.DeepCopy(), o.executedAt)
+}
+
+// ParentCreatedAt returns the creation time of the Tree.
+func (o *TreeInsert) ParentCreatedAt() *time.Ticket {
+	return o.parentCreatedAt
+}
+
+// ExecutedAt returns execution time of this operation.
+func (o *TreeInsert) ExecutedAt() *time.Ticket {
+	return o.executedAt
+}
+
+// SetActor sets the given actor to this operation.
+func (o *TreeInsert) SetActor(actorID *time.ActorID) {
+	o.executedAt = o.executedAt.SetActorID(actorID)
+}
+
+// ParentID returns the ID of the parent node.
+func (o *TreeInsert) ParentID() *time.Ticket {
+	return o.parentID
+}
+
+// PrevID returns the ID of the previous sibling.
+func (o *TreeInsert) PrevID() *time.Ticket {
+	return o.prevID
+}
+
+// Node returns the inserted node.
+func (o *TreeInsert) Node() *json.TreeNode {
+	return o.node
+}
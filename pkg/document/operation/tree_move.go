@@ -0,0 +1,91 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operation
+
+import (
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+)
+
+// TreeMove is an operation representing moving a node among its siblings in
+// Tree.
+type TreeMove struct {
+	// parentCreatedAt is the creation time of the Tree that executes TreeMove.
+	parentCreatedAt *time.Ticket
+
+	// id is the ID of the node to move.
+	id *time.Ticket
+
+	// prevID is the ID of the sibling to place the node after. If it is nil,
+	// the node is moved to the front of the children.
+	prevID *time.Ticket
+
+	// executedAt is the time the operation was executed.
+	executedAt *time.Ticket
+}
+
+// NewTreeMove creates a new instance of TreeMove.
+func NewTreeMove(
+	parentCreatedAt *time.Ticket,
+	id *time.Ticket,
+	prevID *time.Ticket,
+	executedAt *time.Ticket,
+) *TreeMove {
+	return &TreeMove{
+		parentCreatedAt: parentCreatedAt,
+		id:              id,
+		prevID:          prevID,
+		executedAt:      executedAt,
+	}
+}
+
+// Execute executes this operation on the given document(`root`).
+func (o *TreeMove) Execute(root *json.Root) error {
+	parent := root.FindByCreatedAt(o.parentCreatedAt)
+
+	tree, ok := parent.(*json.Tree)
+	if !ok {
+		return ErrNotApplicableDataType
+	}
+
+	return tree.MoveNode(o.id, o.prevID, o.executedAt)
+}
+
+// ParentCreatedAt returns the creation time of the Tree.
+func (o *TreeMove) ParentCreatedAt() *time.Ticket {
+	return o.parentCreatedAt
+}
+
+// ExecutedAt returns execution time of this operation.
+func (o *TreeMove) ExecutedAt() *time.Ticket {
+	return o.executedAt
+}
+
+// SetActor sets the given actor to this operation.
+func (o *TreeMove) SetActor(actorID *time.ActorID) {
+	o.executedAt = o.executedAt.SetActorID(actorID)
+}
+
+// ID returns the ID of the node to move.
+func (o *TreeMove) ID() *time.Ticket {
+	return o.id
+}
+
+// PrevID returns the ID of the sibling to place the node after.
+func (o *TreeMove) PrevID() *time.Ticket {
+	return o.prevID
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operation
+
+import (
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+)
+
+// TreeRemove is an operation representing removing a node from Tree.
+type TreeRemove struct {
+	// parentCreatedAt is the creation time of the Tree that executes
+	// TreeRemove.
+	parentCreatedAt *time.Ticket
+
+	// id is the ID of the node to remove.
+	id *time.Ticket
+
+	// executedAt is the time the operation was executed.
+	executedAt *time.Ticket
+}
+
+// NewTreeRemove creates a new instance of TreeRemove.
+func NewTreeRemove(
+	parentCreatedAt *time.Ticket,
+	id *time.Ticket,
+	executedAt *time.Ticket,
+) *TreeRemove {
+	return &TreeRemove{
+		parentCreatedAt: parentCreatedAt,
+		id:              id,
+		executedAt:      executedAt,
+	}
+}
+
+// Execute executes this operation on the given document(`root`).
+func (o *TreeRemove) Execute(root *json.Root) error {
+	parent := root.FindByCreatedAt(o.parentCreatedAt)
+
+	tree, ok := parent.(*json.Tree)
+	if !ok {
+		return ErrNotApplicableDataType
+	}
+
+	removed, err := tree.RemoveNode(o.id, o.executedAt)
+	if err != nil {
+		return err
+	}
+	if removed {
+		root.RegisterRemovedNodeTree(tree)
+	}
+
+	return nil
+}
+
+// ParentCreatedAt returns the creation time of the Tree.
+func (o *TreeRemove) ParentCreatedAt() *time.Ticket {
+	return o.parentCreatedAt
+}
+
+// ExecutedAt returns execution time of this operation.
+func (o *TreeRemove) ExecutedAt() *time.Ticket {
+	return o.executedAt
+}
+
+// SetActor sets the given actor to this operation.
+func (o *TreeRemove) SetActor(actorID *time.ActorID) {
+	o.executedAt = o.executedAt.SetActorID(actorID)
+}
+
+// ID returns the ID of the node to remove.
+func (o *TreeRemove) ID() *time.Ticket {
+	return o.id
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operation
+
+import (
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+)
+
+// TreeStyle is an operation applies the given attributes to a node of Tree.
+type TreeStyle struct {
+	// parentCreatedAt is the creation time of the Tree that executes
+	// TreeStyle.
+	parentCreatedAt *time.Ticket
+
+	// id is the ID of the node to apply the attributes to.
+	id *time.Ticket
+
+	// attributes represents the attributes of the node.
+	attributes map[string]string
+
+	// executedAt is the time the operation was executed.
+	executedAt *time.Ticket
+}
+
+// NewTreeStyle creates a new instance of TreeStyle.
+func NewTreeStyle(
+	parentCreatedAt *time.Ticket,
+	id *time.Ticket,
+	attributes map[string]string,
+	executedAt *time.Ticket,
+) *TreeStyle {
+	return &TreeStyle{
+		parentCreatedAt: parentCreatedAt,
+		id:              id,
+		attributes:      attributes,
+		executedAt:      executedAt,
+	}
+}
+
+// Execute executes this operation on the given document(`root`).
+func (o *TreeStyle) Execute(root *json.Root) error {
+	parent := root.FindByCreatedAt(o.parentCreatedAt)
+
+	tree, ok := parent.(*json.Tree)
+	if !ok {
+		return ErrNotApplicableDataType
+	}
+
+	return tree.StyleNode(o.id, o.attributes, o.executedAt)
+}
+
+// ParentCreatedAt returns the creation time of the Tree.
+func (o *TreeStyle) ParentCreatedAt() *time.Ticket {
+	return o.parentCreatedAt
+}
+
+// ExecutedAt returns execution time of this operation.
+func (o *TreeStyle) ExecutedAt() *time.Ticket {
+	return o.executedAt
+}
+
+// SetActor sets the given actor to this operation.
+func (o *TreeStyle) SetActor(actorID *time.ActorID) {
+	o.executedAt = o.executedAt.SetActorID(actorID)
+}
+
+// ID returns the ID of the node to apply the attributes to.
+func (o *TreeStyle) ID() *time.Ticket {
+	return o.id
+}
+
+// Attributes returns the attributes of this operation.
+func (o *TreeStyle) Attributes() map[string]string {
+	return o.attributes
+}
@@ -17,6 +17,7 @@
 package proxy
 
 import (
+	"fmt"
 	gotime "time"
 
 	"github.com/yorkie-team/yorkie/internal/log"
@@ -135,13 +136,88 @@ func (p *ArrayProxy) AddNewArray() *ArrayProxy {
 	return v.(*ArrayProxy)
 }
 
+// AddNewObject adds a new object at the last.
+func (p *ArrayProxy) AddNewObject() *ObjectProxy {
+	v := p.addInternal(func(ticket *time.Ticket) json.Element {
+		return NewObjectProxy(p.context, json.NewObject(json.NewRHTPriorityQueueMap(), ticket))
+	})
+
+	return v.(*ObjectProxy)
+}
+
+// AddNewText adds a new text at the last.
+func (p *ArrayProxy) AddNewText() *TextProxy {
+	v := p.addInternal(func(ticket *time.Ticket) json.Element {
+		return NewTextProxy(p.context, json.NewText(json.NewRGATreeSplit(json.InitialTextNode()), ticket))
+	})
+
+	return v.(*TextProxy)
+}
+
+// AddNewRichText adds a new rich text at the last.
+func (p *ArrayProxy) AddNewRichText() *RichTextProxy {
+	v := p.addInternal(func(ticket *time.Ticket) json.Element {
+		return NewRichTextProxy(
+			p.context,
+			json.NewInitialRichText(json.NewRGATreeSplit(json.InitialRichTextNode()), ticket),
+		)
+	})
+
+	return v.(*RichTextProxy)
+}
+
+// AddNewCounter adds a new counter at the last.
+func (p *ArrayProxy) AddNewCounter(n interface{}) *CounterProxy {
+	v := p.addInternal(func(ticket *time.Ticket) json.Element {
+		return NewCounterProxy(p.context, json.NewCounter(n, ticket))
+	})
+
+	return v.(*CounterProxy)
+}
+
 // MoveBefore moves the given element to its new position before the given next element.
 func (p *ArrayProxy) MoveBefore(nextCreatedAt, createdAt *time.Ticket) {
 	p.moveBeforeInternal(nextCreatedAt, createdAt)
 }
 
-// InsertIntegerAfter inserts the given integer after the given previous
-// element.
+// MoveAfter moves the element of the given index after the element of the
+// given previous index.
+func (p *ArrayProxy) MoveAfter(prevIndex, index int) *ArrayProxy {
+	p.moveAfterInternal(p.Get(prevIndex).CreatedAt(), p.Get(index).CreatedAt())
+	return p
+}
+
+// MoveFront moves the element of the given index to the front.
+func (p *ArrayProxy) MoveFront(index int) *ArrayProxy {
+	p.moveAfterInternal(time.InitialTicket, p.Get(index).CreatedAt())
+	return p
+}
+
+// MoveLast moves the element of the given index to the last.
+func (p *ArrayProxy) MoveLast(index int) *ArrayProxy {
+	p.moveAfterInternal(p.Array.LastCreatedAt(), p.Get(index).CreatedAt())
+	return p
+}
+
+// InsertNullAfter inserts the null after the element of the given index.
+func (p *ArrayProxy) InsertNullAfter(index int) *ArrayProxy {
+	p.insertAfterInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(nil, ticket)
+	})
+
+	return p
+}
+
+// InsertBoolAfter inserts the given boolean after the element of the given index.
+func (p *ArrayProxy) InsertBoolAfter(index int, v bool) *ArrayProxy {
+	p.insertAfterInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// InsertIntegerAfter inserts the given integer after the element of the given index.
 func (p *ArrayProxy) InsertIntegerAfter(index int, v int) *ArrayProxy {
 	p.insertAfterInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
 		return json.NewPrimitive(v, ticket)
@@ -150,6 +226,339 @@ func (p *ArrayProxy) InsertIntegerAfter(index int, v int) *ArrayProxy {
 	return p
 }
 
+// InsertLongAfter inserts the given long after the element of the given index.
+func (p *ArrayProxy) InsertLongAfter(index int, v int64) *ArrayProxy {
+	p.insertAfterInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// InsertDoubleAfter inserts the given double after the element of the given index.
+func (p *ArrayProxy) InsertDoubleAfter(index int, v float64) *ArrayProxy {
+	p.insertAfterInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// InsertStringAfter inserts the given string after the element of the given index.
+func (p *ArrayProxy) InsertStringAfter(index int, v string) *ArrayProxy {
+	p.insertAfterInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// InsertBytesAfter inserts the given bytes after the element of the given index.
+func (p *ArrayProxy) InsertBytesAfter(index int, v []byte) *ArrayProxy {
+	p.insertAfterInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// InsertDateAfter inserts the given date after the element of the given index.
+func (p *ArrayProxy) InsertDateAfter(index int, v gotime.Time) *ArrayProxy {
+	p.insertAfterInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// InsertNewArrayAfter inserts a new array after the element of the given index.
+func (p *ArrayProxy) InsertNewArrayAfter(index int) *ArrayProxy {
+	v := p.insertAfterInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return NewArrayProxy(p.context, json.NewArray(json.NewRGATreeList(), ticket))
+	})
+
+	return v.(*ArrayProxy)
+}
+
+// InsertNewObjectAfter inserts a new object after the element of the given index.
+func (p *ArrayProxy) InsertNewObjectAfter(index int) *ObjectProxy {
+	v := p.insertAfterInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return NewObjectProxy(p.context, json.NewObject(json.NewRHTPriorityQueueMap(), ticket))
+	})
+
+	return v.(*ObjectProxy)
+}
+
+// InsertNewTextAfter inserts a new text after the element of the given index.
+func (p *ArrayProxy) InsertNewTextAfter(index int) *TextProxy {
+	v := p.insertAfterInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return NewTextProxy(p.context, json.NewText(json.NewRGATreeSplit(json.InitialTextNode()), ticket))
+	})
+
+	return v.(*TextProxy)
+}
+
+// InsertNewRichTextAfter inserts a new rich text after the element of the given index.
+func (p *ArrayProxy) InsertNewRichTextAfter(index int) *RichTextProxy {
+	v := p.insertAfterInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return NewRichTextProxy(
+			p.context,
+			json.NewInitialRichText(json.NewRGATreeSplit(json.InitialRichTextNode()), ticket),
+		)
+	})
+
+	return v.(*RichTextProxy)
+}
+
+// InsertNewCounterAfter inserts a new counter after the element of the given index.
+func (p *ArrayProxy) InsertNewCounterAfter(index int, n interface{}) *CounterProxy {
+	v := p.insertAfterInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return NewCounterProxy(p.context, json.NewCounter(n, ticket))
+	})
+
+	return v.(*CounterProxy)
+}
+
+// InsertNullBefore inserts the null before the element of the given index.
+func (p *ArrayProxy) InsertNullBefore(index int) *ArrayProxy {
+	p.insertBeforeInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(nil, ticket)
+	})
+
+	return p
+}
+
+// InsertBoolBefore inserts the given boolean before the element of the given index.
+func (p *ArrayProxy) InsertBoolBefore(index int, v bool) *ArrayProxy {
+	p.insertBeforeInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// InsertIntegerBefore inserts the given integer before the element of the given index.
+func (p *ArrayProxy) InsertIntegerBefore(index int, v int) *ArrayProxy {
+	p.insertBeforeInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// InsertLongBefore inserts the given long before the element of the given index.
+func (p *ArrayProxy) InsertLongBefore(index int, v int64) *ArrayProxy {
+	p.insertBeforeInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// InsertDoubleBefore inserts the given double before the element of the given index.
+func (p *ArrayProxy) InsertDoubleBefore(index int, v float64) *ArrayProxy {
+	p.insertBeforeInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// InsertStringBefore inserts the given string before the element of the given index.
+func (p *ArrayProxy) InsertStringBefore(index int, v string) *ArrayProxy {
+	p.insertBeforeInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// InsertBytesBefore inserts the given bytes before the element of the given index.
+func (p *ArrayProxy) InsertBytesBefore(index int, v []byte) *ArrayProxy {
+	p.insertBeforeInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// InsertDateBefore inserts the given date before the element of the given index.
+func (p *ArrayProxy) InsertDateBefore(index int, v gotime.Time) *ArrayProxy {
+	p.insertBeforeInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// InsertNewArrayBefore inserts a new array before the element of the given index.
+func (p *ArrayProxy) InsertNewArrayBefore(index int) *ArrayProxy {
+	v := p.insertBeforeInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return NewArrayProxy(p.context, json.NewArray(json.NewRGATreeList(), ticket))
+	})
+
+	return v.(*ArrayProxy)
+}
+
+// InsertNewObjectBefore inserts a new object before the element of the given index.
+func (p *ArrayProxy) InsertNewObjectBefore(index int) *ObjectProxy {
+	v := p.insertBeforeInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return NewObjectProxy(p.context, json.NewObject(json.NewRHTPriorityQueueMap(), ticket))
+	})
+
+	return v.(*ObjectProxy)
+}
+
+// InsertNewTextBefore inserts a new text before the element of the given index.
+func (p *ArrayProxy) InsertNewTextBefore(index int) *TextProxy {
+	v := p.insertBeforeInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return NewTextProxy(p.context, json.NewText(json.NewRGATreeSplit(json.InitialTextNode()), ticket))
+	})
+
+	return v.(*TextProxy)
+}
+
+// InsertNewRichTextBefore inserts a new rich text before the element of the given index.
+func (p *ArrayProxy) InsertNewRichTextBefore(index int) *RichTextProxy {
+	v := p.insertBeforeInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return NewRichTextProxy(
+			p.context,
+			json.NewInitialRichText(json.NewRGATreeSplit(json.InitialRichTextNode()), ticket),
+		)
+	})
+
+	return v.(*RichTextProxy)
+}
+
+// InsertNewCounterBefore inserts a new counter before the element of the given index.
+func (p *ArrayProxy) InsertNewCounterBefore(index int, n interface{}) *CounterProxy {
+	v := p.insertBeforeInternal(p.Get(index).CreatedAt(), func(ticket *time.Ticket) json.Element {
+		return NewCounterProxy(p.context, json.NewCounter(n, ticket))
+	})
+
+	return v.(*CounterProxy)
+}
+
+// SetNull replaces the element of the given index with the null.
+func (p *ArrayProxy) SetNull(index int) *ArrayProxy {
+	p.setInternal(index, func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(nil, ticket)
+	})
+
+	return p
+}
+
+// SetBool replaces the element of the given index with the given boolean.
+func (p *ArrayProxy) SetBool(index int, v bool) *ArrayProxy {
+	p.setInternal(index, func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// SetInteger replaces the element of the given index with the given integer.
+func (p *ArrayProxy) SetInteger(index int, v int) *ArrayProxy {
+	p.setInternal(index, func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// SetLong replaces the element of the given index with the given long.
+func (p *ArrayProxy) SetLong(index int, v int64) *ArrayProxy {
+	p.setInternal(index, func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// SetDouble replaces the element of the given index with the given double.
+func (p *ArrayProxy) SetDouble(index int, v float64) *ArrayProxy {
+	p.setInternal(index, func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// SetString replaces the element of the given index with the given string.
+func (p *ArrayProxy) SetString(index int, v string) *ArrayProxy {
+	p.setInternal(index, func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// SetBytes replaces the element of the given index with the given bytes.
+func (p *ArrayProxy) SetBytes(index int, v []byte) *ArrayProxy {
+	p.setInternal(index, func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// SetDate replaces the element of the given index with the given date.
+func (p *ArrayProxy) SetDate(index int, v gotime.Time) *ArrayProxy {
+	p.setInternal(index, func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(v, ticket)
+	})
+
+	return p
+}
+
+// SetNewArray replaces the element of the given index with a new array.
+func (p *ArrayProxy) SetNewArray(index int) *ArrayProxy {
+	v := p.setInternal(index, func(ticket *time.Ticket) json.Element {
+		return NewArrayProxy(p.context, json.NewArray(json.NewRGATreeList(), ticket))
+	})
+
+	return v.(*ArrayProxy)
+}
+
+// SetNewObject replaces the element of the given index with a new object.
+func (p *ArrayProxy) SetNewObject(index int) *ObjectProxy {
+	v := p.setInternal(index, func(ticket *time.Ticket) json.Element {
+		return NewObjectProxy(p.context, json.NewObject(json.NewRHTPriorityQueueMap(), ticket))
+	})
+
+	return v.(*ObjectProxy)
+}
+
+// SetNewText replaces the element of the given index with a new text.
+func (p *ArrayProxy) SetNewText(index int) *TextProxy {
+	v := p.setInternal(index, func(ticket *time.Ticket) json.Element {
+		return NewTextProxy(p.context, json.NewText(json.NewRGATreeSplit(json.InitialTextNode()), ticket))
+	})
+
+	return v.(*TextProxy)
+}
+
+// SetNewRichText replaces the element of the given index with a new rich text.
+func (p *ArrayProxy) SetNewRichText(index int) *RichTextProxy {
+	v := p.setInternal(index, func(ticket *time.Ticket) json.Element {
+		return NewRichTextProxy(
+			p.context,
+			json.NewInitialRichText(json.NewRGATreeSplit(json.InitialRichTextNode()), ticket),
+		)
+	})
+
+	return v.(*RichTextProxy)
+}
+
+// SetNewCounter replaces the element of the given index with a new counter.
+func (p *ArrayProxy) SetNewCounter(index int, n interface{}) *CounterProxy {
+	v := p.setInternal(index, func(ticket *time.Ticket) json.Element {
+		return NewCounterProxy(p.context, json.NewCounter(n, ticket))
+	})
+
+	return v.(*CounterProxy)
+}
+
 // Delete deletes the element of the given index.
 func (p *ArrayProxy) Delete(idx int) json.Element {
 	if p.Len() <= idx {
@@ -173,6 +582,91 @@ func (p *ArrayProxy) Len() int {
 	return p.Array.Len()
 }
 
+// GetBool returns the boolean of the given index.
+func (p *ArrayProxy) GetBool(index int) (bool, error) {
+	v, err := p.valueOf(index, json.Boolean)
+	if err != nil {
+		return false, err
+	}
+	return v.(bool), nil
+}
+
+// GetInteger returns the integer of the given index.
+func (p *ArrayProxy) GetInteger(index int) (int, error) {
+	v, err := p.valueOf(index, json.Integer)
+	if err != nil {
+		return 0, err
+	}
+	return v.(int), nil
+}
+
+// GetLong returns the long of the given index.
+func (p *ArrayProxy) GetLong(index int) (int64, error) {
+	v, err := p.valueOf(index, json.Long)
+	if err != nil {
+		return 0, err
+	}
+	return v.(int64), nil
+}
+
+// GetDouble returns the double of the given index.
+func (p *ArrayProxy) GetDouble(index int) (float64, error) {
+	v, err := p.valueOf(index, json.Double)
+	if err != nil {
+		return 0, err
+	}
+	return v.(float64), nil
+}
+
+// GetString returns the string of the given index.
+func (p *ArrayProxy) GetString(index int) (string, error) {
+	v, err := p.valueOf(index, json.String)
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// GetBytes returns the bytes of the given index.
+func (p *ArrayProxy) GetBytes(index int) ([]byte, error) {
+	v, err := p.valueOf(index, json.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return v.([]byte), nil
+}
+
+// GetDate returns the date of the given index.
+func (p *ArrayProxy) GetDate(index int) (gotime.Time, error) {
+	v, err := p.valueOf(index, json.Date)
+	if err != nil {
+		return gotime.Time{}, err
+	}
+	return v.(gotime.Time), nil
+}
+
+// Range calls the given function for each element of this array in order.
+// If the function returns false, Range stops the iteration.
+func (p *ArrayProxy) Range(f func(index int, elem json.Element) bool) {
+	for i, elem := range p.Elements() {
+		if !f(i, elem) {
+			return
+		}
+	}
+}
+
+func (p *ArrayProxy) valueOf(index int, valueType json.ValueType) (interface{}, error) {
+	if index < 0 || index >= p.Len() {
+		return nil, fmt.Errorf("%d: %w", index, json.ErrElementNotFound)
+	}
+
+	v, err := valueOf(p.Get(index), valueType)
+	if err != nil {
+		return nil, fmt.Errorf("%d: %w", index, err)
+	}
+	return v, nil
+}
+
 func (p *ArrayProxy) addInternal(
 	creator func(ticket *time.Ticket) json.Element,
 ) json.Element {
@@ -195,15 +689,49 @@ func (p *ArrayProxy) insertAfterInternal(
 	))
 
 	p.InsertAfter(prevCreatedAt, value)
-	p.context.RegisterElement(value)
+	p.context.RegisterElement(p.Array, value)
 
 	return proxy
 }
 
-func (p *ArrayProxy) moveBeforeInternal(nextCreatedAt, createdAt *time.Ticket) {
+func (p *ArrayProxy) insertBeforeInternal(
+	nextCreatedAt *time.Ticket,
+	creator func(ticket *time.Ticket) json.Element,
+) json.Element {
+	return p.insertAfterInternal(p.FindPrevCreatedAt(nextCreatedAt), creator)
+}
+
+// setInternal inserts a new element after the element of the given index and
+// removes the element, so that the new element takes its place.
+func (p *ArrayProxy) setInternal(
+	index int,
+	creator func(ticket *time.Ticket) json.Element,
+) json.Element {
+	target := p.Get(index)
+	proxy := p.insertAfterInternal(target.CreatedAt(), creator)
+
 	ticket := p.context.IssueTimeTicket()
+	deleted := p.DeleteByCreatedAt(target.CreatedAt(), ticket)
+	p.context.Push(operation.NewRemove(
+		p.CreatedAt(),
+		deleted.CreatedAt(),
+		ticket,
+	))
+	p.context.RegisterRemovedElementPair(p, deleted)
 
-	prevCreatedAt := p.FindPrevCreatedAt(nextCreatedAt)
+	return proxy
+}
+
+func (p *ArrayProxy) moveBeforeInternal(nextCreatedAt, createdAt *time.Ticket) {
+	p.moveAfterInternal(p.FindPrevCreatedAt(nextCreatedAt), createdAt)
+}
+
+func (p *ArrayProxy) moveAfterInternal(prevCreatedAt, createdAt *time.Ticket) {
+	if prevCreatedAt.Compare(createdAt) == 0 {
+		return
+	}
+
+	ticket := p.context.IssueTimeTicket()
 
 	p.context.Push(operation.NewMove(
 		p.Array.CreatedAt(),
@@ -212,5 +740,5 @@ func (p *ArrayProxy) moveBeforeInternal(nextCreatedAt, createdAt *time.Ticket) {
 		ticket,
 	))
 
-	p.MoveAfter(prevCreatedAt, createdAt, ticket)
+	p.Array.MoveAfter(prevCreatedAt, createdAt, ticket)
 }
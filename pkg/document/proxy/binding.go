@@ -0,0 +1,700 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proxy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	gotime "time"
+	"unicode/utf16"
+
+	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+)
+
+// ErrUnsupportedValue is returned when the given Go value cannot be bound to
+// an element of the document.
+var ErrUnsupportedValue = errors.New("unsupported value")
+
+var timeType = reflect.TypeOf(gotime.Time{})
+
+// tagOptions is the options of a field given by its `yorkie` struct tag.
+//
+// The tag has the form of `yorkie:"name,opt1,opt2"`. The name is the key of
+// the member in the object and defaults to the name of the field. A field
+// with the tag `yorkie:"-"` is ignored. The options are:
+//   - text: the string field is bound to Text.
+//   - richtext: the string field is bound to RichText.
+//   - counter: the numeric field is bound to Counter.
+//   - omitempty: the member is deleted if the field has the empty value.
+type tagOptions struct {
+	text      bool
+	richText  bool
+	counter   bool
+	omitEmpty bool
+}
+
+// fieldInfo is the information of a struct field to be bound to a member.
+type fieldInfo struct {
+	index int
+	key   string
+	opts  tagOptions
+}
+
+// elementSetter sets the element created by the given creator in the place
+// of a member of an object or an element of an array and returns it.
+type elementSetter func(creator func(ticket *time.Ticket) json.Element) json.Element
+
+// member is a member of the object to be bound to the given value.
+type member struct {
+	key   string
+	value reflect.Value
+	opts  tagOptions
+}
+
+// Marshal updates the given object to represent the given struct or map.
+// Only the operations needed to move the object from its current state to
+// the given value are generated, and the members that do not exist in the
+// given value are deleted.
+//
+// Structs and maps are bound to Object, slices and arrays to Array, and
+// time.Time, []byte and the other basic types to Primitive. nil pointers,
+// maps and slices are bound to null.
+func Marshal(root *ObjectProxy, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return fmt.Errorf("%T: %w", v, ErrUnsupportedValue)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct && rv.Kind() != reflect.Map {
+		return fmt.Errorf("%T: %w", v, ErrUnsupportedValue)
+	}
+
+	return marshalObject(root, rv)
+}
+
+// Unmarshal stores the content of the given object into the value pointed
+// to by v. It is the inverse of Marshal, and the members that do not have
+// the corresponding fields are ignored.
+func Unmarshal(obj *json.Object, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("%T: %w", v, ErrUnsupportedValue)
+	}
+
+	return unmarshalValue(obj, rv.Elem())
+}
+
+func marshalObject(p *ObjectProxy, v reflect.Value) error {
+	members, err := membersOf(v)
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]bool)
+	for _, m := range members {
+		if m.opts.omitEmpty && isEmpty(m.value) {
+			continue
+		}
+		keys[m.key] = true
+
+		key := m.key
+		if err := marshalValue(p.context, p.Object.Get(key), m.value, m.opts, func(
+			creator func(ticket *time.Ticket) json.Element,
+		) json.Element {
+			return p.setInternal(key, creator)
+		}); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	for _, k := range p.Keys() {
+		if !keys[k] {
+			p.Delete(k)
+		}
+	}
+
+	return nil
+}
+
+func marshalArray(p *ArrayProxy, v reflect.Value) error {
+	for p.Len() > v.Len() {
+		p.Delete(p.Len() - 1)
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		index := i
+		var err error
+		if index < p.Len() {
+			err = marshalValue(p.context, p.Get(index), v.Index(index), tagOptions{}, func(
+				creator func(ticket *time.Ticket) json.Element,
+			) json.Element {
+				return p.setInternal(index, creator)
+			})
+		} else {
+			err = marshalValue(p.context, nil, v.Index(index), tagOptions{}, p.addInternal)
+		}
+		if err != nil {
+			return fmt.Errorf("%d: %w", index, err)
+		}
+	}
+
+	return nil
+}
+
+// marshalValue binds the given value to the given current element. If the
+// current element cannot represent the value, a new element is created and
+// set in its place by the given setter.
+func marshalValue(
+	ctx *change.Context,
+	current json.Element,
+	v reflect.Value,
+	opts tagOptions,
+	set elementSetter,
+) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return marshalPrimitive(current, nil, set)
+		}
+		v = v.Elem()
+	}
+
+	switch {
+	case opts.text:
+		return marshalText(ctx, current, v, set)
+	case opts.richText:
+		return marshalRichText(ctx, current, v, set)
+	case opts.counter:
+		return marshalCounter(ctx, current, v, set)
+	case v.Type() == timeType:
+		return marshalPrimitive(current, v.Interface(), set)
+	}
+
+	switch v.Kind() {
+	case reflect.Map, reflect.Struct:
+		if v.Kind() == reflect.Map && v.IsNil() {
+			return marshalPrimitive(current, nil, set)
+		}
+
+		if obj, ok := current.(*json.Object); ok {
+			return marshalObject(NewObjectProxy(ctx, obj), v)
+		}
+		p := set(func(ticket *time.Ticket) json.Element {
+			return NewObjectProxy(ctx, json.NewObject(json.NewRHTPriorityQueueMap(), ticket))
+		})
+		return marshalObject(p.(*ObjectProxy), v)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return marshalPrimitive(current, nil, set)
+		}
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return marshalPrimitive(current, v.Bytes(), set)
+		}
+
+		if arr, ok := current.(*json.Array); ok {
+			return marshalArray(NewArrayProxy(ctx, arr), v)
+		}
+		p := set(func(ticket *time.Ticket) json.Element {
+			return NewArrayProxy(ctx, json.NewArray(json.NewRGATreeList(), ticket))
+		})
+		return marshalArray(p.(*ArrayProxy), v)
+	}
+
+	value, err := primitiveValueOf(v)
+	if err != nil {
+		return err
+	}
+	return marshalPrimitive(current, value, set)
+}
+
+// marshalPrimitive sets a new primitive of the given value unless the
+// current element is a primitive with the same value.
+func marshalPrimitive(
+	current json.Element,
+	value interface{},
+	set elementSetter,
+) error {
+	if primitive, ok := current.(*json.Primitive); ok {
+		target := json.NewPrimitive(value, time.InitialTicket)
+		if primitive.ValueType() == target.ValueType() && bytes.Equal(primitive.Bytes(), target.Bytes()) {
+			return nil
+		}
+	}
+
+	set(func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(value, ticket)
+	})
+	return nil
+}
+
+// marshalText edits the range between the common prefix and the common
+// suffix of the current text and the given string.
+func marshalText(
+	ctx *change.Context,
+	current json.Element,
+	v reflect.Value,
+	set elementSetter,
+) error {
+	if v.Kind() != reflect.String {
+		return fmt.Errorf("%s: %w", v.Type(), ErrUnsupportedValue)
+	}
+
+	text, ok := current.(*json.Text)
+	if !ok {
+		p := set(func(ticket *time.Ticket) json.Element {
+			return NewTextProxy(ctx, json.NewText(json.NewRGATreeSplit(json.InitialTextNode()), ticket))
+		}).(*TextProxy)
+		if v.Len() > 0 {
+			p.Edit(0, 0, v.String())
+		}
+		return nil
+	}
+
+	from := utf16.Encode([]rune(text.Content()))
+	to := utf16.Encode([]rune(v.String()))
+	prefix, suffix := TextAffixLen(from, to, func(i, j int) bool {
+		return from[i] == to[j]
+	})
+	if prefix == len(from)-suffix && prefix == len(to)-suffix {
+		return nil
+	}
+
+	NewTextProxy(ctx, text).Edit(prefix, len(from)-suffix, string(utf16.Decode(to[prefix:len(to)-suffix])))
+	return nil
+}
+
+// marshalRichText edits the range between the common prefix and the common
+// suffix of the current rich text and the given string. The styles of the
+// content out of the range are kept, and the new content has no style.
+func marshalRichText(
+	ctx *change.Context,
+	current json.Element,
+	v reflect.Value,
+	set elementSetter,
+) error {
+	if v.Kind() != reflect.String {
+		return fmt.Errorf("%s: %w", v.Type(), ErrUnsupportedValue)
+	}
+
+	text, ok := current.(*json.RichText)
+	if !ok {
+		p := set(func(ticket *time.Ticket) json.Element {
+			return NewRichTextProxy(
+				ctx,
+				json.NewInitialRichText(json.NewRGATreeSplit(json.InitialRichTextNode()), ticket),
+			)
+		}).(*RichTextProxy)
+		if v.Len() > 0 {
+			p.Edit(0, 0, v.String(), nil)
+		}
+		return nil
+	}
+
+	from := utf16.Encode([]rune(text.Content()))
+	to := utf16.Encode([]rune(v.String()))
+	prefix, suffix := TextAffixLen(from, to, func(i, j int) bool {
+		return from[i] == to[j]
+	})
+	if prefix == len(from)-suffix && prefix == len(to)-suffix {
+		return nil
+	}
+
+	NewRichTextProxy(ctx, text).Edit(
+		prefix,
+		len(from)-suffix,
+		string(utf16.Decode(to[prefix:len(to)-suffix])),
+		nil,
+	)
+	return nil
+}
+
+// marshalCounter increases the current counter by the difference between
+// the given value and the value of the counter.
+func marshalCounter(
+	ctx *change.Context,
+	current json.Element,
+	v reflect.Value,
+	set elementSetter,
+) error {
+	switch v.Kind() {
+	case reflect.Bool, reflect.String:
+		return fmt.Errorf("%s: %w", v.Type(), ErrUnsupportedValue)
+	}
+	value, err := primitiveValueOf(v)
+	if err != nil {
+		return err
+	}
+
+	target := json.NewCounter(value, time.InitialTicket)
+	counter, ok := current.(*json.Counter)
+	if !ok || counter.ValueType() != target.ValueType() {
+		set(func(ticket *time.Ticket) json.Element {
+			return NewCounterProxy(ctx, json.NewCounter(value, ticket))
+		})
+		return nil
+	}
+
+	from := json.CounterValueFromBytes(counter.ValueType(), counter.Bytes())
+	switch to := json.CounterValueFromBytes(target.ValueType(), target.Bytes()).(type) {
+	case int:
+		if delta := to - from.(int); delta != 0 {
+			NewCounterProxy(ctx, counter).Increase(delta)
+		}
+	case int64:
+		if delta := to - from.(int64); delta != 0 {
+			NewCounterProxy(ctx, counter).Increase(delta)
+		}
+	case float64:
+		if delta := to - from.(float64); delta != 0 {
+			NewCounterProxy(ctx, counter).Increase(delta)
+		}
+	}
+	return nil
+}
+
+func unmarshalValue(elem json.Element, v reflect.Value) error {
+	if primitive, ok := elem.(*json.Primitive); ok && primitive.ValueType() == json.Null {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return unmarshalValue(elem, v.Elem())
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return fmt.Errorf("%s: %w", v.Type(), ErrUnsupportedValue)
+		}
+		t, err := naturalTypeOf(elem)
+		if err != nil {
+			return err
+		}
+		value := reflect.New(t).Elem()
+		if err := unmarshalValue(elem, value); err != nil {
+			return err
+		}
+		v.Set(value)
+		return nil
+	}
+
+	switch elem := elem.(type) {
+	case *json.Object:
+		return unmarshalObject(elem, v)
+	case *json.Array:
+		return unmarshalArray(elem.Elements(), v)
+	case *json.Set:
+		var elems []json.Element
+		for _, primitive := range elem.Elements() {
+			elems = append(elems, primitive)
+		}
+		return unmarshalArray(elems, v)
+	case *json.Text:
+		return setString(v, elem.Content())
+	case *json.RichText:
+		return setString(v, elem.Content())
+	case *json.Counter:
+		return setNumber(v, json.CounterValueFromBytes(elem.ValueType(), elem.Bytes()))
+	case *json.Primitive:
+		return unmarshalPrimitive(elem, v)
+	}
+
+	return fmt.Errorf("%T: %w", elem, json.ErrUnexpectedType)
+}
+
+func unmarshalObject(obj *json.Object, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Struct:
+		for _, field := range structFields(v.Type()) {
+			elem := obj.Get(field.key)
+			if elem == nil {
+				continue
+			}
+			if err := unmarshalValue(elem, v.Field(field.index)); err != nil {
+				return fmt.Errorf("%s: %w", field.key, err)
+			}
+		}
+		return nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("%s: %w", v.Type(), ErrUnsupportedValue)
+		}
+
+		members := obj.Members()
+		m := reflect.MakeMapWithSize(v.Type(), len(members))
+		for k, elem := range members {
+			value := reflect.New(v.Type().Elem()).Elem()
+			if err := unmarshalValue(elem, value); err != nil {
+				return fmt.Errorf("%s: %w", k, err)
+			}
+			m.SetMapIndex(reflect.ValueOf(k).Convert(v.Type().Key()), value)
+		}
+		v.Set(m)
+		return nil
+	}
+
+	return fmt.Errorf("%s: %w", v.Type(), json.ErrUnexpectedType)
+}
+
+func unmarshalArray(elems []json.Element, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Slice:
+		s := reflect.MakeSlice(v.Type(), len(elems), len(elems))
+		for i, elem := range elems {
+			if err := unmarshalValue(elem, s.Index(i)); err != nil {
+				return fmt.Errorf("%d: %w", i, err)
+			}
+		}
+		v.Set(s)
+		return nil
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if i >= len(elems) {
+				v.Index(i).Set(reflect.Zero(v.Type().Elem()))
+				continue
+			}
+			if err := unmarshalValue(elems[i], v.Index(i)); err != nil {
+				return fmt.Errorf("%d: %w", i, err)
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%s: %w", v.Type(), json.ErrUnexpectedType)
+}
+
+func unmarshalPrimitive(primitive *json.Primitive, v reflect.Value) error {
+	switch primitive.ValueType() {
+	case json.Boolean:
+		if v.Kind() == reflect.Bool {
+			v.SetBool(primitive.Value().(bool))
+			return nil
+		}
+	case json.Integer, json.Long, json.Double:
+		return setNumber(v, primitive.Value())
+	case json.String:
+		return setString(v, primitive.Value().(string))
+	case json.Bytes:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes(append([]byte(nil), primitive.Value().([]byte)...))
+			return nil
+		}
+	case json.Date:
+		if v.Type() == timeType {
+			v.Set(reflect.ValueOf(primitive.Value()))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s: %w", v.Type(), json.ErrUnexpectedType)
+}
+
+func setString(v reflect.Value, s string) error {
+	if v.Kind() != reflect.String {
+		return fmt.Errorf("%s: %w", v.Type(), json.ErrUnexpectedType)
+	}
+
+	v.SetString(s)
+	return nil
+}
+
+func setNumber(v reflect.Value, value interface{}) error {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := toInt64(value)
+		if v.OverflowInt(n) {
+			return fmt.Errorf("%d overflows %s: %w", n, v.Type(), json.ErrUnexpectedType)
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := toInt64(value)
+		if n < 0 || v.OverflowUint(uint64(n)) {
+			return fmt.Errorf("%d overflows %s: %w", n, v.Type(), json.ErrUnexpectedType)
+		}
+		v.SetUint(uint64(n))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(toFloat64(value))
+		return nil
+	}
+
+	return fmt.Errorf("%s: %w", v.Type(), json.ErrUnexpectedType)
+}
+
+// naturalTypeOf returns the Go type to store the given element into an
+// empty interface.
+func naturalTypeOf(elem json.Element) (reflect.Type, error) {
+	switch elem := elem.(type) {
+	case *json.Object:
+		return reflect.TypeOf(map[string]interface{}{}), nil
+	case *json.Array, *json.Set:
+		return reflect.TypeOf([]interface{}{}), nil
+	case *json.Text, *json.RichText:
+		return reflect.TypeOf(""), nil
+	case *json.Counter:
+		return reflect.TypeOf(json.CounterValueFromBytes(elem.ValueType(), elem.Bytes())), nil
+	case *json.Primitive:
+		return reflect.TypeOf(elem.Value()), nil
+	}
+
+	return nil, fmt.Errorf("%T: %w", elem, json.ErrUnexpectedType)
+}
+
+// membersOf returns the members of the given struct or map. The members of a
+// map are sorted by their keys.
+func membersOf(v reflect.Value) ([]member, error) {
+	var members []member
+
+	switch v.Kind() {
+	case reflect.Struct:
+		for _, field := range structFields(v.Type()) {
+			members = append(members, member{
+				key:   field.key,
+				value: v.Field(field.index),
+				opts:  field.opts,
+			})
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%s: %w", v.Type(), ErrUnsupportedValue)
+		}
+		for _, k := range v.MapKeys() {
+			members = append(members, member{
+				key:   k.String(),
+				value: v.MapIndex(k),
+			})
+		}
+		sort.Slice(members, func(i, j int) bool {
+			return members[i].key < members[j].key
+		})
+	default:
+		return nil, fmt.Errorf("%s: %w", v.Type(), ErrUnsupportedValue)
+	}
+
+	return members, nil
+}
+
+// structFields returns the exported fields of the given struct type that are
+// not ignored by their tags.
+func structFields(t reflect.Type) []fieldInfo {
+	var fields []fieldInfo
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := field.Tag.Get("yorkie")
+		if tag == "-" {
+			continue
+		}
+
+		info := fieldInfo{index: i, key: field.Name}
+		name, opts := tag, ""
+		if idx := strings.Index(tag, ","); idx != -1 {
+			name, opts = tag[:idx], tag[idx+1:]
+		}
+		if name != "" {
+			info.key = name
+		}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "text":
+				info.opts.text = true
+			case "richtext":
+				info.opts.richText = true
+			case "counter":
+				info.opts.counter = true
+			case "omitempty":
+				info.opts.omitEmpty = true
+			}
+		}
+
+		fields = append(fields, info)
+	}
+	return fields
+}
+
+// primitiveValueOf converts the given value of a basic type to the value of
+// Primitive.
+func primitiveValueOf(v reflect.Value) (interface{}, error) {
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return int(v.Int()), nil
+	case reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint8, reflect.Uint16:
+		return int(v.Uint()), nil
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("%d: %w", v.Uint(), ErrUnsupportedValue)
+		}
+		return int64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		return v.String(), nil
+	}
+
+	return nil, fmt.Errorf("%s: %w", v.Type(), ErrUnsupportedValue)
+}
+
+// isEmpty returns whether the given value is the empty value of omitempty.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+func toInt64(value interface{}) int64 {
+	switch value := value.(type) {
+	case int:
+		return int64(value)
+	case int64:
+		return value
+	case float64:
+		return int64(value)
+	}
+	return 0
+}
+
+func toFloat64(value interface{}) float64 {
+	switch value := value.(type) {
+	case int:
+		return float64(value)
+	case int64:
+		return float64(value)
+	case float64:
+		return value
+	}
+	return 0
+}
@@ -81,6 +81,7 @@ func (p *CounterProxy) Increase(v interface{}) *CounterProxy {
 		primitive,
 		ticket,
 	))
+	p.Counter.Increase(primitive)
 
 	return p
 }
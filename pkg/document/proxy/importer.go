@@ -0,0 +1,221 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proxy
+
+import (
+	"bytes"
+	gojson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/yorkie-team/yorkie/internal/log"
+	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+)
+
+// ErrInvalidJSON is returned when the given data is not a valid JSON.
+var ErrInvalidJSON = errors.New("invalid json")
+
+// ImportHint is the type of the element to be created for a value of the
+// imported JSON instead of the default one.
+type ImportHint int
+
+// The values below are the hints for the values of the imported JSON.
+const (
+	// TextHint creates Text for a string.
+	TextHint ImportHint = iota + 1
+
+	// CounterHint creates Counter for a number.
+	CounterHint
+)
+
+// ImportHints maps the paths of the values in the imported JSON to hints.
+// The paths have the same form as the ones of json.Root.FindByPath.
+// e.g. `$.todos[2].title`
+type ImportHints map[string]ImportHint
+
+// ImportJSON sets the members of the given JSON object to the given root
+// object. The other members of the root are kept. The paths of the hints
+// start from the root.
+//
+// Objects, arrays, strings, booleans and null of the JSON are created as
+// Object, Array and Primitive. Numbers without a fraction and an exponent are
+// created as Integer, or Long if they do not fit in 32 bits, and the other
+// numbers as Double.
+func ImportJSON(root *ObjectProxy, data []byte, hints ImportHints) error {
+	value, err := decodeJSON(data)
+	if err != nil {
+		return err
+	}
+	normalized, err := normalizeHints(hints)
+	if err != nil {
+		return err
+	}
+
+	members, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("$: %w", json.ErrUnexpectedType)
+	}
+	return importMembers(root, "$", members, normalized)
+}
+
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := gojson.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		log.Logger.Error(err)
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidJSON)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("trailing data: %w", ErrInvalidJSON)
+	}
+
+	return value, nil
+}
+
+// normalizeHints returns the hints whose paths are in the canonical form.
+func normalizeHints(hints ImportHints) (ImportHints, error) {
+	normalized := make(ImportHints)
+	for path, hint := range hints {
+		canonical, err := json.NormalizePath(path)
+		if err != nil {
+			return nil, err
+		}
+		normalized[canonical] = hint
+	}
+	return normalized, nil
+}
+
+func importMembers(p *ObjectProxy, path string, members map[string]interface{}, hints ImportHints) error {
+	keys := make([]string, 0, len(members))
+	for k := range members {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		key := k
+		if err := importValue(p.context, path+json.QuotedKeyPathSegment(key), members[key], hints, func(
+			creator func(ticket *time.Ticket) json.Element,
+		) json.Element {
+			return p.setInternal(key, creator)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importValue creates the element of the given decoded value and sets it by
+// the given setter.
+func importValue(
+	ctx *change.Context,
+	path string,
+	value interface{},
+	hints ImportHints,
+	set elementSetter,
+) error {
+	hint, hasHint := hints[path]
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if hasHint {
+			return fmt.Errorf("%s: %w", path, json.ErrUnexpectedType)
+		}
+		p := set(func(ticket *time.Ticket) json.Element {
+			return NewObjectProxy(ctx, json.NewObject(json.NewRHTPriorityQueueMap(), ticket))
+		})
+		return importMembers(p.(*ObjectProxy), path, value, hints)
+	case []interface{}:
+		if hasHint {
+			return fmt.Errorf("%s: %w", path, json.ErrUnexpectedType)
+		}
+		p := set(func(ticket *time.Ticket) json.Element {
+			return NewArrayProxy(ctx, json.NewArray(json.NewRGATreeList(), ticket))
+		}).(*ArrayProxy)
+		for i, elem := range value {
+			if err := importValue(ctx, fmt.Sprintf("%s[%d]", path, i), elem, hints, p.addInternal); err != nil {
+				return err
+			}
+		}
+		return nil
+	case string:
+		if hasHint && hint != TextHint {
+			return fmt.Errorf("%s: %w", path, json.ErrUnexpectedType)
+		}
+		if hint == TextHint {
+			p := set(func(ticket *time.Ticket) json.Element {
+				return NewTextProxy(ctx, json.NewText(json.NewRGATreeSplit(json.InitialTextNode()), ticket))
+			}).(*TextProxy)
+			if len(value) > 0 {
+				p.Edit(0, 0, value)
+			}
+			return nil
+		}
+		return importPrimitive(value, set)
+	case gojson.Number:
+		if hasHint && hint != CounterHint {
+			return fmt.Errorf("%s: %w", path, json.ErrUnexpectedType)
+		}
+		n, err := numberOf(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if hint == CounterHint {
+			set(func(ticket *time.Ticket) json.Element {
+				return NewCounterProxy(ctx, json.NewCounter(n, ticket))
+			})
+			return nil
+		}
+		return importPrimitive(n, set)
+	default:
+		if hasHint {
+			return fmt.Errorf("%s: %w", path, json.ErrUnexpectedType)
+		}
+		return importPrimitive(value, set)
+	}
+}
+
+func importPrimitive(value interface{}, set elementSetter) error {
+	set(func(ticket *time.Ticket) json.Element {
+		return json.NewPrimitive(value, ticket)
+	})
+	return nil
+}
+
+// numberOf returns the value of the given number as int, int64 or float64.
+func numberOf(number gojson.Number) (interface{}, error) {
+	if n, err := number.Int64(); err == nil {
+		if n < math.MinInt32 || n > math.MaxInt32 {
+			return n, nil
+		}
+		return int(n), nil
+	}
+
+	f, err := number.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", number, ErrInvalidJSON)
+	}
+	return f, nil
+}
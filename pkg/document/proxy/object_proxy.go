@@ -17,6 +17,8 @@
 package proxy
 
 import (
+	"fmt"
+	"sort"
 	gotime "time"
 
 	"github.com/yorkie-team/yorkie/pkg/document/change"
@@ -81,6 +83,24 @@ func (p *ObjectProxy) SetNewRichText(k string) *RichTextProxy {
 	return v.(*RichTextProxy)
 }
 
+// SetNewTree sets a new Tree for the given key.
+func (p *ObjectProxy) SetNewTree(k string) *TreeProxy {
+	v := p.setInternal(k, func(ticket *time.Ticket) json.Element {
+		return NewTreeProxy(p.context, json.NewInitialTree(ticket))
+	})
+
+	return v.(*TreeProxy)
+}
+
+// SetNewSet sets a new Set for the given key.
+func (p *ObjectProxy) SetNewSet(k string) *SetProxy {
+	v := p.setInternal(k, func(ticket *time.Ticket) json.Element {
+		return json.NewSet(ticket)
+	})
+
+	return NewSetProxy(p.context, v.(*json.Set))
+}
+
 // SetNewCounter sets a new NewCounter for the given key.
 func (p *ObjectProxy) SetNewCounter(k string, n interface{}) *CounterProxy {
 	v := p.setInternal(k, func(ticket *time.Ticket) json.Element {
@@ -165,6 +185,26 @@ func (p *ObjectProxy) SetDate(k string, v gotime.Time) *ObjectProxy {
 	return p
 }
 
+// SetFromJSON sets the element built from the given JSON for the given key.
+// The paths of the hints start from the value of the JSON, and the elements
+// are created as described in ImportJSON.
+func (p *ObjectProxy) SetFromJSON(k string, data []byte, hints ImportHints) error {
+	value, err := decodeJSON(data)
+	if err != nil {
+		return err
+	}
+	normalized, err := normalizeHints(hints)
+	if err != nil {
+		return err
+	}
+
+	return importValue(p.context, "$", value, normalized, func(
+		creator func(ticket *time.Ticket) json.Element,
+	) json.Element {
+		return p.setInternal(k, creator)
+	})
+}
+
 // Delete deletes the value of the given key.
 func (p *ObjectProxy) Delete(k string) json.Element {
 	if !p.Object.Has(k) {
@@ -250,6 +290,38 @@ func (p *ObjectProxy) GetRichText(k string) *RichTextProxy {
 	}
 }
 
+// GetSet returns SetProxy of the given key.
+func (p *ObjectProxy) GetSet(k string) *SetProxy {
+	elem := p.Object.Get(k)
+	if elem == nil {
+		return nil
+	}
+
+	switch elem := elem.(type) {
+	case *json.Set:
+		return NewSetProxy(p.context, elem)
+	default:
+		panic("unsupported type")
+	}
+}
+
+// GetTree returns TreeProxy of the given key.
+func (p *ObjectProxy) GetTree(k string) *TreeProxy {
+	elem := p.Object.Get(k)
+	if elem == nil {
+		return nil
+	}
+
+	switch elem := p.Object.Get(k).(type) {
+	case *json.Tree:
+		return NewTreeProxy(p.context, elem)
+	case *TreeProxy:
+		return elem
+	default:
+		panic("unsupported type")
+	}
+}
+
 // GetCounter returns CounterProxy of the given key.
 func (p *ObjectProxy) GetCounter(k string) *CounterProxy {
 	elem := p.Object.Get(k)
@@ -267,6 +339,109 @@ func (p *ObjectProxy) GetCounter(k string) *CounterProxy {
 	}
 }
 
+// GetBool returns the boolean of the given key.
+func (p *ObjectProxy) GetBool(k string) (bool, error) {
+	v, err := p.valueOf(k, json.Boolean)
+	if err != nil {
+		return false, err
+	}
+	return v.(bool), nil
+}
+
+// GetInteger returns the integer of the given key.
+func (p *ObjectProxy) GetInteger(k string) (int, error) {
+	v, err := p.valueOf(k, json.Integer)
+	if err != nil {
+		return 0, err
+	}
+	return v.(int), nil
+}
+
+// GetLong returns the long of the given key.
+func (p *ObjectProxy) GetLong(k string) (int64, error) {
+	v, err := p.valueOf(k, json.Long)
+	if err != nil {
+		return 0, err
+	}
+	return v.(int64), nil
+}
+
+// GetDouble returns the double of the given key.
+func (p *ObjectProxy) GetDouble(k string) (float64, error) {
+	v, err := p.valueOf(k, json.Double)
+	if err != nil {
+		return 0, err
+	}
+	return v.(float64), nil
+}
+
+// GetString returns the string of the given key.
+func (p *ObjectProxy) GetString(k string) (string, error) {
+	v, err := p.valueOf(k, json.String)
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// GetBytes returns the bytes of the given key.
+func (p *ObjectProxy) GetBytes(k string) ([]byte, error) {
+	v, err := p.valueOf(k, json.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return v.([]byte), nil
+}
+
+// GetDate returns the date of the given key.
+func (p *ObjectProxy) GetDate(k string) (gotime.Time, error) {
+	v, err := p.valueOf(k, json.Date)
+	if err != nil {
+		return gotime.Time{}, err
+	}
+	return v.(gotime.Time), nil
+}
+
+// Len returns the number of the members of this object.
+func (p *ObjectProxy) Len() int {
+	return len(p.Members())
+}
+
+// Keys returns the keys of the members of this object in sorted order.
+func (p *ObjectProxy) Keys() []string {
+	var keys []string
+	for k := range p.Members() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Range calls the given function for each member of this object in the
+// order of the keys. If the function returns false, Range stops the
+// iteration.
+func (p *ObjectProxy) Range(f func(k string, elem json.Element) bool) {
+	members := p.Members()
+	for _, k := range p.Keys() {
+		if !f(k, members[k]) {
+			return
+		}
+	}
+}
+
+func (p *ObjectProxy) valueOf(k string, valueType json.ValueType) (interface{}, error) {
+	elem := p.Object.Get(k)
+	if elem == nil {
+		return nil, fmt.Errorf("%s: %w", k, json.ErrElementNotFound)
+	}
+
+	v, err := valueOf(elem, valueType)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", k, err)
+	}
+	return v, nil
+}
+
 func (p *ObjectProxy) setInternal(
 	k string,
 	creator func(ticket *time.Ticket) json.Element,
@@ -283,7 +458,7 @@ func (p *ObjectProxy) setInternal(
 	))
 
 	removed := p.Set(k, value)
-	p.context.RegisterElement(value)
+	p.context.RegisterElement(p.Object, value)
 	if removed != nil {
 		p.context.RegisterRemovedElementPair(p, removed)
 	}
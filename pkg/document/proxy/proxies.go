@@ -17,6 +17,8 @@
 package proxy
 
 import (
+	"fmt"
+
 	"github.com/yorkie-team/yorkie/pkg/document/json"
 )
 
@@ -32,9 +34,27 @@ func toOriginal(elem json.Element) json.Element {
 		return elem.RichText
 	case *CounterProxy:
 		return elem.Counter
+	case *TreeProxy:
+		return elem.Tree
+	case *json.Set:
+		return elem
 	case *json.Primitive:
 		return elem
 	}
 
 	panic("unsupported type")
 }
+
+// valueOf returns the value of the given element if it is a primitive of the
+// given value type.
+func valueOf(elem json.Element, valueType json.ValueType) (interface{}, error) {
+	primitive, ok := elem.(*json.Primitive)
+	if !ok {
+		return nil, fmt.Errorf("%T: %w", elem, json.ErrUnexpectedType)
+	}
+	if primitive.ValueType() != valueType {
+		return nil, fmt.Errorf("%s: %w", primitive.Marshal(), json.ErrUnexpectedType)
+	}
+
+	return primitive.Value(), nil
+}
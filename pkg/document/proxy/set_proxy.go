@@ -0,0 +1,98 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proxy
+
+import (
+	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/pkg/document/operation"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+)
+
+// SetProxy is a proxy representing Set.
+//
+// NOTE: SetProxy is not an Element because its Remove removes
+// the given values instead of the set itself. Use Set to get the element.
+type SetProxy struct {
+	*json.Set
+	context *change.Context
+}
+
+// NewSetProxy creates a new instance of SetProxy.
+func NewSetProxy(ctx *change.Context, set *json.Set) *SetProxy {
+	return &SetProxy{
+		Set:     set,
+		context: ctx,
+	}
+}
+
+// Add adds the given values to the set. Only primitive values are allowed.
+// If the set already has a value, the value is added again in place of the
+// old one so that it wins over the concurrent removals of the value.
+func (p *SetProxy) Add(values ...interface{}) *SetProxy {
+	for _, value := range values {
+		ticket := p.context.IssueTimeTicket()
+		elem := json.NewPrimitive(value, ticket)
+
+		observed := p.FindAll(elem)
+
+		p.context.Push(operation.NewAdd(
+			p.CreatedAt(),
+			nil,
+			elem.DeepCopy(),
+			ticket,
+		))
+		p.Set.Add(elem)
+		p.context.RegisterElement(p.Set, elem)
+
+		for _, old := range observed {
+			p.removeInternal(old)
+		}
+	}
+
+	return p
+}
+
+// Remove removes the given values from the set.
+func (p *SetProxy) Remove(values ...interface{}) *SetProxy {
+	for _, value := range values {
+		elem := json.NewPrimitive(value, time.InitialTicket)
+
+		for _, observed := range p.FindAll(elem) {
+			p.removeInternal(observed)
+		}
+	}
+
+	return p
+}
+
+// Has returns whether the set has the given value or not.
+func (p *SetProxy) Has(value interface{}) bool {
+	return p.Set.Has(json.NewPrimitive(value, time.InitialTicket))
+}
+
+func (p *SetProxy) removeInternal(elem *json.Primitive) {
+	ticket := p.context.IssueTimeTicket()
+	removed := p.DeleteByCreatedAt(elem.CreatedAt(), ticket)
+
+	p.context.Push(operation.NewRemove(
+		p.CreatedAt(),
+		elem.CreatedAt(),
+		ticket,
+	))
+	p.context.RegisterRemovedElementPair(p.Set, removed)
+}
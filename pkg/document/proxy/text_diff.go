@@ -0,0 +1,56 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proxy
+
+// CommonAffixLen returns the lengths of the common prefix and the common
+// suffix of two sequences of the given lengths, where equal reports whether
+// the i-th item of the first and the j-th item of the second are the same.
+// The suffix does not overlap the prefix in either sequence.
+func CommonAffixLen(fromLen, toLen int, equal func(i, j int) bool) (int, int) {
+	prefix := 0
+	for prefix < fromLen && prefix < toLen && equal(prefix, prefix) {
+		prefix++
+	}
+	suffix := 0
+	for suffix < fromLen-prefix && suffix < toLen-prefix && equal(fromLen-1-suffix, toLen-1-suffix) {
+		suffix++
+	}
+	return prefix, suffix
+}
+
+// TextAffixLen returns the lengths of the common prefix and the common suffix
+// of the given UTF-16 contents like CommonAffixLen. The range between them is
+// the range of the content to be edited, so they are shortened not to split a
+// surrogate pair.
+func TextAffixLen(from, to []uint16, equal func(i, j int) bool) (int, int) {
+	prefix, suffix := CommonAffixLen(len(from), len(to), equal)
+	if prefix > 0 && isHighSurrogate(from[prefix-1]) {
+		prefix--
+	}
+	if suffix > 0 && isLowSurrogate(from[len(from)-suffix]) {
+		suffix--
+	}
+	return prefix, suffix
+}
+
+func isHighSurrogate(u uint16) bool {
+	return 0xd800 <= u && u < 0xdc00
+}
+
+func isLowSurrogate(u uint16) bool {
+	return 0xdc00 <= u && u < 0xe000
+}
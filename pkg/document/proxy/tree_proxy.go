@@ -0,0 +1,274 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package proxy
+
+import (
+	"github.com/yorkie-team/yorkie/internal/log"
+	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/pkg/document/operation"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+)
+
+// TreeNode is a node to be inserted into Tree. A text node has the type of
+// json.TreeTextType and a value, and the other nodes have attributes and
+// children.
+type TreeNode struct {
+	Type       string
+	Value      string
+	Attributes map[string]string
+	Children   []TreeNode
+}
+
+// TreeProxy is a proxy representing Tree.
+//
+// The nodes of Tree are addressed by their paths. A path is a list of the
+// indexes of the children from the root node to the node, and only the nodes
+// that are not removed are counted.
+//
+// Like the indexes of TextProxy, the paths are preconditions of the methods.
+// The methods panic if a path is out of range or the nodes cannot be placed
+// at the path, and the errors of Tree cannot occur after the paths are
+// checked.
+type TreeProxy struct {
+	*json.Tree
+	context *change.Context
+}
+
+// NewTreeProxy creates a new instance of TreeProxy.
+func NewTreeProxy(ctx *change.Context, tree *json.Tree) *TreeProxy {
+	return &TreeProxy{
+		Tree:    tree,
+		context: ctx,
+	}
+}
+
+// Insert inserts the given nodes at the given path. The last index of the
+// path is the position among the children of the parent node.
+//
+// It panics if the path is empty or out of range, if the parent is a text
+// node, or if the given nodes include a root node or a text node with
+// children.
+func (p *TreeProxy) Insert(path []int, nodes ...TreeNode) *TreeProxy {
+	parent, prev := p.positionOf(path)
+	for _, node := range nodes {
+		checkTreeNode(node)
+	}
+	log.Logger.Debugf("TINS: p:%v, n:%d", path, len(nodes))
+
+	for _, node := range nodes {
+		prev = p.insertInternal(parent, prev, node)
+	}
+
+	return p
+}
+
+// Delete deletes the node at the given path with its descendants.
+//
+// It panics if the path is out of range or points to the root node.
+func (p *TreeProxy) Delete(path []int) *TreeProxy {
+	node := p.nodeAt(path)
+	if node == p.Root() {
+		panic("root node cannot be deleted")
+	}
+	log.Logger.Debugf("TREM: p:%v", path)
+
+	ticket := p.context.IssueTimeTicket()
+	if _, err := p.Tree.RemoveNode(node.ID(), ticket); err != nil {
+		panic(err) // the node is a live node other than the root.
+	}
+
+	p.context.Push(operation.NewTreeRemove(
+		p.CreatedAt(),
+		node.ID(),
+		ticket,
+	))
+	p.context.RegisterRemovedNodeTree(p.Tree)
+
+	return p
+}
+
+// Move moves the node at the given path to the given target path among its
+// siblings. Both paths point to the positions before the move.
+//
+// NOTE: Tree only moves a node within its parent, because moving it under
+// another parent can make a cycle with a concurrent move. Deleting the node
+// and inserting its copy under another parent does not keep the identity of
+// the node, and the concurrent changes of the node are lost.
+//
+// It panics if either path is out of range, if the node is the root node or
+// if the target is under another parent.
+func (p *TreeProxy) Move(from []int, to []int) *TreeProxy {
+	node := p.nodeAt(from)
+	if node == p.Root() {
+		panic("root node cannot be moved")
+	}
+	parent, prev := p.positionOf(to)
+	if parent != node.Parent() {
+		panic("node cannot be moved under another parent")
+	}
+	log.Logger.Debugf("TMOV: f:%v, t:%v", from, to)
+
+	var prevID *time.Ticket
+	if prev != nil {
+		if prev == node {
+			return p
+		}
+		prevID = prev.ID()
+	}
+
+	ticket := p.context.IssueTimeTicket()
+	if err := p.Tree.MoveNode(node.ID(), prevID, ticket); err != nil {
+		panic(err) // the previous node is a sibling of the node.
+	}
+
+	p.context.Push(operation.NewTreeMove(
+		p.CreatedAt(),
+		node.ID(),
+		prevID,
+		ticket,
+	))
+
+	return p
+}
+
+// Style sets the given attributes to the node at the given path.
+//
+// It panics if the path is out of range.
+func (p *TreeProxy) Style(path []int, attributes map[string]string) *TreeProxy {
+	node := p.nodeAt(path)
+	log.Logger.Debugf("TSTY: p:%v, attrs:%v", path, attributes)
+
+	ticket := p.context.IssueTimeTicket()
+	if err := p.Tree.StyleNode(node.ID(), attributes, ticket); err != nil {
+		panic(err) // the node is a live node.
+	}
+
+	p.context.Push(operation.NewTreeStyle(
+		p.CreatedAt(),
+		node.ID(),
+		attributes,
+		ticket,
+	))
+
+	return p
+}
+
+// insertInternal inserts the given node after the given previous node under
+// the given parent and returns the inserted node.
+func (p *TreeProxy) insertInternal(parent, prev *json.TreeNode, spec TreeNode) *json.TreeNode {
+	ticket := p.context.IssueTimeTicket()
+	node := p.newNode(ticket, spec)
+
+	var prevID *time.Ticket
+	if prev != nil {
+		prevID = prev.ID()
+	}
+
+	p.context.Push(operation.NewTreeInsert(
+		p.CreatedAt(),
+		parent.ID(),
+		prevID,
+		node.DeepCopy(),
+		ticket,
+	))
+
+	if err := p.Tree.InsertNode(parent.ID(), prevID, node, ticket); err != nil {
+		panic(err) // the parent is not a text node and the previous node is its child.
+	}
+
+	return node
+}
+
+// newNode creates a node of the given spec with its descendants. Each of
+// the descendants has its own time ticket.
+func (p *TreeProxy) newNode(ticket *time.Ticket, spec TreeNode) *json.TreeNode {
+	attrs := json.NewRHT()
+	for k, v := range spec.Attributes {
+		attrs.Set(k, v, ticket)
+	}
+
+	node := json.NewTreeNode(ticket, spec.Type, spec.Value, attrs)
+	for _, child := range spec.Children {
+		node.Append(p.newNode(p.context.IssueTimeTicket(), child))
+	}
+	return node
+}
+
+// checkTreeNode checks whether the given spec and its descendants can be
+// inserted into Tree, so that none of them is inserted if one of them cannot.
+func checkTreeNode(spec TreeNode) {
+	if spec.Type == json.TreeTextType && len(spec.Children) > 0 {
+		panic("text node cannot have children")
+	}
+	if spec.Type == json.TreeRootType {
+		panic("root node cannot be inserted")
+	}
+
+	for _, child := range spec.Children {
+		checkTreeNode(child)
+	}
+}
+
+// nodeAt returns the node at the given path.
+func (p *TreeProxy) nodeAt(path []int) *json.TreeNode {
+	node := p.Root()
+	for _, index := range path {
+		children := node.Children()
+		if index < 0 || index >= len(children) {
+			panic("index out of range")
+		}
+		node = children[index]
+	}
+	return node
+}
+
+// positionOf returns the parent node and the previous node of the position
+// of the given path. The previous node is nil if the position is the front.
+func (p *TreeProxy) positionOf(path []int) (*json.TreeNode, *json.TreeNode) {
+	if len(path) == 0 {
+		panic("path should not be empty")
+	}
+
+	parent := p.nodeAt(path[:len(path)-1])
+	if parent.IsText() {
+		panic("text node cannot have children")
+	}
+
+	index := path[len(path)-1]
+	children := parent.Children()
+	if index < 0 || index > len(children) {
+		panic("index out of range")
+	}
+	if index == 0 {
+		return parent, nil
+	}
+	return parent, children[index-1]
+}
+
+// ToTreeNode returns the spec of the given node and its descendants that
+// are not removed.
+func ToTreeNode(node *json.TreeNode) TreeNode {
+	spec := TreeNode{
+		Type:       node.Type(),
+		Value:      node.Value(),
+		Attributes: node.Attrs().Elements(),
+	}
+	for _, child := range node.Children() {
+		spec.Children = append(spec.Children, ToTreeNode(child))
+	}
+	return spec
+}
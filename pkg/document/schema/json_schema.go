@@ -0,0 +1,352 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	gojson "encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+)
+
+// The types of the `type` keyword of JSON Schema.
+const (
+	typeObject  = "object"
+	typeArray   = "array"
+	typeString  = "string"
+	typeNumber  = "number"
+	typeInteger = "integer"
+	typeBoolean = "boolean"
+	typeNull    = "null"
+)
+
+// The values of the `yorkieType` keyword.
+const (
+	yorkieObject    = "Object"
+	yorkieArray     = "Array"
+	yorkiePrimitive = "Primitive"
+	yorkieText      = "Text"
+	yorkieRichText  = "RichText"
+	yorkieCounter   = "Counter"
+	yorkieTree      = "Tree"
+	yorkieSet       = "Set"
+)
+
+// annotations are the keywords that do not affect the validation.
+var annotations = map[string]bool{
+	"$schema":     true,
+	"$id":         true,
+	"$comment":    true,
+	"title":       true,
+	"description": true,
+}
+
+// JSONSchema is a Schema written in a subset of JSON Schema. The supported
+// keywords are as follows:
+//   - type: "object", "array", "string", "number", "integer", "boolean" or
+//     "null", or an array of them. Text and RichText are strings, Tree is an
+//     object and Set is an array.
+//   - properties, required and additionalProperties for objects.
+//   - items for arrays and sets.
+//   - yorkieType: the type of the element, one of "Object", "Array",
+//     "Primitive", "Text", "RichText", "Counter", "Tree" and "Set".
+//
+// Annotations such as title and description are ignored, and the other
+// keywords are rejected so that a constraint is not silently ignored.
+//
+// e.g. `todos` must be an array of objects with a `title` Text:
+//
+//	{
+//	  "type": "object",
+//	  "properties": {
+//	    "todos": {
+//	      "type": "array",
+//	      "items": {
+//	        "type": "object",
+//	        "properties": {"title": {"yorkieType": "Text"}},
+//	        "required": ["title"]
+//	      }
+//	    }
+//	  }
+//	}
+type JSONSchema struct {
+	root *node
+}
+
+// node is a schema of an element. A boolean schema is a node that accepts
+// every element(true) or no element(false).
+type node struct {
+	never      bool
+	types      []string
+	yorkieType string
+	properties map[string]*node
+	required   []string
+	additional *node
+	items      *node
+}
+
+// NewJSONSchema parses the given JSON Schema.
+func NewJSONSchema(data []byte) (*JSONSchema, error) {
+	root, err := parseNode(data, "#")
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONSchema{root: root}, nil
+}
+
+// Validate validates the given root of a document.
+func (s *JSONSchema) Validate(root *json.Object) error {
+	return s.root.validate(root, "$")
+}
+
+func parseNode(data []byte, location string) (*node, error) {
+	var isAllowed bool
+	if err := gojson.Unmarshal(data, &isAllowed); err == nil {
+		return &node{never: !isAllowed}, nil
+	}
+
+	var keywords map[string]gojson.RawMessage
+	if err := gojson.Unmarshal(data, &keywords); err != nil {
+		return nil, fmt.Errorf("%s: %v: %w", location, err, ErrInvalidSchema)
+	}
+
+	n := &node{}
+	for keyword, value := range keywords {
+		var err error
+		switch keyword {
+		case "type":
+			n.types, err = parseTypes(value)
+		case "yorkieType":
+			n.yorkieType, err = parseYorkieType(value)
+		case "properties":
+			n.properties, err = parseProperties(value, location+"/properties")
+		case "required":
+			err = gojson.Unmarshal(value, &n.required)
+		case "additionalProperties":
+			n.additional, err = parseNode(value, location+"/additionalProperties")
+		case "items":
+			n.items, err = parseNode(value, location+"/items")
+		default:
+			if !annotations[keyword] {
+				err = fmt.Errorf("unsupported keyword %q", keyword)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v: %w", location, err, ErrInvalidSchema)
+		}
+	}
+
+	return n, nil
+}
+
+func parseTypes(data []byte) ([]string, error) {
+	var types []string
+	var typ string
+	if err := gojson.Unmarshal(data, &typ); err == nil {
+		types = []string{typ}
+	} else if err := gojson.Unmarshal(data, &types); err != nil {
+		return nil, err
+	}
+
+	for _, typ := range types {
+		switch typ {
+		case typeObject, typeArray, typeString, typeNumber, typeInteger, typeBoolean, typeNull:
+		default:
+			return nil, fmt.Errorf("unsupported type %q", typ)
+		}
+	}
+
+	return types, nil
+}
+
+func parseYorkieType(data []byte) (string, error) {
+	var typ string
+	if err := gojson.Unmarshal(data, &typ); err != nil {
+		return "", err
+	}
+
+	switch typ {
+	case yorkieObject, yorkieArray, yorkiePrimitive, yorkieText,
+		yorkieRichText, yorkieCounter, yorkieTree, yorkieSet:
+		return typ, nil
+	}
+
+	return "", fmt.Errorf("unsupported yorkieType %q", typ)
+}
+
+func parseProperties(data []byte, location string) (map[string]*node, error) {
+	var values map[string]gojson.RawMessage
+	if err := gojson.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	properties := make(map[string]*node)
+	for key, value := range values {
+		property, err := parseNode(value, location+"/"+key)
+		if err != nil {
+			return nil, err
+		}
+		properties[key] = property
+	}
+
+	return properties, nil
+}
+
+func (n *node) validate(elem json.Element, path string) error {
+	if n.never {
+		return violationOf(path, "is not allowed")
+	}
+
+	if len(n.types) > 0 && !matchesTypes(elem, n.types) {
+		return violationOf(path, "expected %s", strings.Join(n.types, " or "))
+	}
+
+	if n.yorkieType != "" && yorkieTypeOf(elem) != n.yorkieType {
+		return violationOf(path, "expected %s", n.yorkieType)
+	}
+
+	switch elem := elem.(type) {
+	case *json.Object:
+		return n.validateMembers(elem.Members(), path)
+	case *json.Array:
+		if n.items == nil {
+			return nil
+		}
+		for i, child := range elem.Elements() {
+			if err := n.items.validate(child, path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+	case *json.Set:
+		if n.items == nil {
+			return nil
+		}
+		for i, child := range elem.Elements() {
+			if err := n.items.validate(child, path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (n *node) validateMembers(members map[string]json.Element, path string) error {
+	for _, key := range n.required {
+		if _, ok := members[key]; !ok {
+			return violationOf(path, "missing required property %q", key)
+		}
+	}
+
+	var keys []string
+	for key := range members {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		property, ok := n.properties[key]
+		if !ok {
+			property = n.additional
+		}
+		if property == nil {
+			continue
+		}
+
+		if err := property.validate(members[key], path+json.KeyPathSegment(key)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// matchesTypes returns whether the given element is one of the given types
+// of JSON Schema.
+func matchesTypes(elem json.Element, types []string) bool {
+	for _, typ := range types {
+		if matchesType(elem, typ) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesType(elem json.Element, typ string) bool {
+	switch elem := elem.(type) {
+	case *json.Object, *json.Tree:
+		return typ == typeObject
+	case *json.Array, *json.Set:
+		return typ == typeArray
+	case *json.Text, *json.RichText:
+		return typ == typeString
+	case *json.Counter:
+		if elem.ValueType() == json.DoubleCnt {
+			return typ == typeNumber
+		}
+		return typ == typeNumber || typ == typeInteger
+	case *json.Primitive:
+		switch elem.ValueType() {
+		case json.Null:
+			return typ == typeNull
+		case json.Boolean:
+			return typ == typeBoolean
+		case json.Integer, json.Long:
+			return typ == typeNumber || typ == typeInteger
+		case json.Double:
+			value := elem.Value().(float64)
+			return typ == typeNumber || (typ == typeInteger && value == math.Trunc(value))
+		case json.String, json.Bytes, json.Date:
+			return typ == typeString
+		}
+	}
+
+	return false
+}
+
+func yorkieTypeOf(elem json.Element) string {
+	switch elem.(type) {
+	case *json.Object:
+		return yorkieObject
+	case *json.Array:
+		return yorkieArray
+	case *json.Primitive:
+		return yorkiePrimitive
+	case *json.Text:
+		return yorkieText
+	case *json.RichText:
+		return yorkieRichText
+	case *json.Counter:
+		return yorkieCounter
+	case *json.Tree:
+		return yorkieTree
+	case *json.Set:
+		return yorkieSet
+	}
+
+	return ""
+}
+
+func violationOf(path string, format string, args ...interface{}) error {
+	return fmt.Errorf("%s: %s: %w", path, fmt.Sprintf(format, args...), ErrViolation)
+}
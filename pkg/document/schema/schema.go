@@ -0,0 +1,101 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package schema provides the validation of the shape of documents. A schema
+// is registered for a collection on the agent to reject the changes that
+// violate it, and is set to a document to validate local updates.
+package schema
+
+import (
+	"errors"
+	"fmt"
+	gosync "sync"
+
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+)
+
+var (
+	// ErrViolation is returned when a document does not conform to its
+	// schema.
+	ErrViolation = errors.New("schema violation")
+
+	// ErrInvalidSchema is returned when the given schema is malformed or uses
+	// a keyword that is not supported.
+	ErrInvalidSchema = errors.New("invalid schema")
+)
+
+// Schema validates the shape of documents.
+type Schema interface {
+	// Validate returns an error wrapping ErrViolation if the given root of a
+	// document does not conform to this schema.
+	Validate(root *json.Object) error
+}
+
+// Func is an adapter to use a Go function as a Schema. The error returned by
+// the function is wrapped with ErrViolation.
+type Func func(root *json.Object) error
+
+// Validate calls f(root).
+func (f Func) Validate(root *json.Object) error {
+	err := f(root)
+	if err == nil || errors.Is(err, ErrViolation) {
+		return err
+	}
+
+	return fmt.Errorf("%v: %w", err, ErrViolation)
+}
+
+// Registry holds the schemas of collections. It is safe for concurrent use.
+type Registry struct {
+	mu      gosync.RWMutex
+	schemas map[string]Schema
+}
+
+// NewRegistry creates a new instance of Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		schemas: make(map[string]Schema),
+	}
+}
+
+// Register sets the schema of the given collection. The previous schema of
+// the collection is replaced.
+func (r *Registry) Register(collection string, schema Schema) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.schemas[collection] = schema
+}
+
+// Get returns the schema of the given collection.
+func (r *Registry) Get(collection string) (Schema, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schema, ok := r.schemas[collection]
+	return schema, ok
+}
+
+// Validate validates the given root of a document in the given collection. It
+// returns nil if the collection has no schema.
+func (r *Registry) Validate(collection string, root *json.Object) error {
+	schema, ok := r.Get(collection)
+	if !ok {
+		return nil
+	}
+
+	return schema.Validate(root)
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yorkie-team/yorkie/pkg/document"
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/pkg/document/proxy"
+	"github.com/yorkie-team/yorkie/pkg/document/schema"
+)
+
+const todosSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "todos",
+  "type": "object",
+  "properties": {
+    "todos": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "title": {"yorkieType": "Text"},
+          "done": {"type": "boolean"},
+          "priority": {"type": ["integer", "null"]}
+        },
+        "required": ["title"],
+        "additionalProperties": false
+      }
+    },
+    "tags": {"type": "array", "items": {"type": "string"}}
+  }
+}`
+
+func TestJSONSchema(t *testing.T) {
+	s, err := schema.NewJSONSchema([]byte(todosSchema))
+	assert.NoError(t, err)
+
+	validate := func(updater func(root *proxy.ObjectProxy)) error {
+		doc := document.New("c1", "d1")
+		assert.NoError(t, doc.Update(func(root *proxy.ObjectProxy) error {
+			updater(root)
+			return nil
+		}))
+		return s.Validate(doc.RootObject())
+	}
+
+	t.Run("valid document test", func(t *testing.T) {
+		assert.NoError(t, validate(func(root *proxy.ObjectProxy) {}))
+		assert.NoError(t, validate(func(root *proxy.ObjectProxy) {
+			todo := root.SetNewArray("todos").AddNewObject()
+			todo.SetNewText("title").Edit(0, 0, "a")
+			todo.SetBool("done", true).SetDouble("priority", 2)
+			root.SetNewArray("todos").AddNewObject().SetNewText("title")
+			root.SetNewSet("tags").Add("a", "b")
+			root.SetInteger("extra", 1)
+		}))
+	})
+
+	t.Run("invalid document test", func(t *testing.T) {
+		err := validate(func(root *proxy.ObjectProxy) {
+			root.SetNewObject("todos")
+		})
+		assert.ErrorIs(t, err, schema.ErrViolation)
+		assert.Contains(t, err.Error(), "$.todos: expected array")
+
+		err = validate(func(root *proxy.ObjectProxy) {
+			root.SetNewArray("todos").AddNewObject().SetString("title", "a")
+		})
+		assert.ErrorIs(t, err, schema.ErrViolation)
+		assert.Contains(t, err.Error(), "$.todos[0].title: expected Text")
+
+		err = validate(func(root *proxy.ObjectProxy) {
+			root.SetNewArray("todos").AddNewObject().SetBool("done", true)
+		})
+		assert.ErrorIs(t, err, schema.ErrViolation)
+		assert.Contains(t, err.Error(), `$.todos[0]: missing required property "title"`)
+
+		err = validate(func(root *proxy.ObjectProxy) {
+			todo := root.SetNewArray("todos").AddNewObject()
+			todo.SetNewText("title")
+			todo.SetString("due.date", "today")
+		})
+		assert.ErrorIs(t, err, schema.ErrViolation)
+		assert.Contains(t, err.Error(), "$.todos[0]['due.date']: is not allowed")
+
+		err = validate(func(root *proxy.ObjectProxy) {
+			todo := root.SetNewArray("todos").AddNewObject()
+			todo.SetNewText("title").Edit(0, 0, "a")
+			todo.SetDouble("priority", 1.5)
+		})
+		assert.ErrorIs(t, err, schema.ErrViolation)
+		assert.Contains(t, err.Error(), "$.todos[0].priority: expected integer or null")
+
+		err = validate(func(root *proxy.ObjectProxy) {
+			root.SetNewSet("tags").Add("a", 1)
+		})
+		assert.ErrorIs(t, err, schema.ErrViolation)
+		assert.Contains(t, err.Error(), "expected string")
+	})
+
+	t.Run("invalid schema test", func(t *testing.T) {
+		for _, data := range []string{
+			`[]`,
+			`{"type": "map"}`,
+			`{"yorkieType": "Map"}`,
+			`{"properties": {"a": {"minLength": 1}}}`,
+			`{"required": "a"}`,
+		} {
+			_, err := schema.NewJSONSchema([]byte(data))
+			assert.ErrorIs(t, err, schema.ErrInvalidSchema, data)
+		}
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("func schema test", func(t *testing.T) {
+		registry := schema.NewRegistry()
+		registry.Register("todos", schema.Func(func(root *json.Object) error {
+			if !root.Has("todos") {
+				return errors.New("todos is required")
+			}
+			return nil
+		}))
+
+		doc := document.New("todos", "d1")
+		assert.NoError(t, registry.Validate("notes", doc.RootObject()))
+
+		err := registry.Validate("todos", doc.RootObject())
+		assert.ErrorIs(t, err, schema.ErrViolation)
+		assert.Contains(t, err.Error(), "todos is required")
+
+		assert.NoError(t, doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetNewArray("todos")
+			return nil
+		}))
+		assert.NoError(t, registry.Validate("todos", doc.RootObject()))
+	})
+}
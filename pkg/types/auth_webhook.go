@@ -48,12 +48,15 @@ type Method string
 
 // Belows are the names of RPCs.
 const (
-	ActivateClient   Method = "ActivateClient"
-	DeactivateClient Method = "DeactivateClient"
-	AttachDocument   Method = "AttachDocument"
-	DetachDocument   Method = "DetachDocument"
-	PushPull         Method = "PushPull"
-	WatchDocuments   Method = "WatchDocuments"
+	ActivateClient     Method = "ActivateClient"
+	DeactivateClient   Method = "DeactivateClient"
+	AttachDocument     Method = "AttachDocument"
+	DetachDocument     Method = "DetachDocument"
+	PushPull           Method = "PushPull"
+	WatchDocuments     Method = "WatchDocuments"
+	GetDocumentHistory Method = "GetDocumentHistory"
+	GetDocumentAt      Method = "GetDocumentAt"
+	RevertDocument     Method = "RevertDocument"
 )
 
 // IsAuthMethod returns whether the given method can be used for authorization.
@@ -75,6 +78,9 @@ func AuthMethods() []Method {
 		DetachDocument,
 		PushPull,
 		WatchDocuments,
+		GetDocumentHistory,
+		GetDocumentAt,
+		RevertDocument,
 	}
 }
 
@@ -0,0 +1,32 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	gotime "time"
+
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+)
+
+// ChangeSummary represents a summary of a change stored in the agent. It is
+// used to browse the history of a document.
+type ChangeSummary struct {
+	ServerSeq uint64
+	Actor     *time.ActorID
+	Message   string
+	CreatedAt gotime.Time
+}
@@ -0,0 +1,38 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+// PreconditionType represents the type of the precondition that a change pack
+// violates. The Agent puts it in the details of the gRPC status so that the
+// client can tell how to recover.
+type PreconditionType string
+
+const (
+	// ClientSeqGap means that some changes are missing between the checkpoint
+	// and the changes of the pack. The client can recover by sending the
+	// missing changes again.
+	ClientSeqGap PreconditionType = "client-seq-gap"
+
+	// ChangesOutOfOrder means that the changes of the pack are not sorted by
+	// client seq. The client can recover by sending the changes again in order.
+	ChangesOutOfOrder PreconditionType = "changes-out-of-order"
+
+	// InvalidServerSeq means that the server seq of the checkpoint is ahead
+	// of the document in the Agent. The client can recover by attaching the
+	// document again.
+	InvalidServerSeq PreconditionType = "invalid-server-seq"
+)
@@ -39,6 +39,7 @@ var testStartedAt int64
 const (
 	RPCPort                   = 21101
 	MetricsPort               = 21102
+	RPCClusterPort            = 21103
 	MongoConnectionURI        = "mongodb://localhost:27017"
 	MongoConnectionTimeoutSec = 5
 	MongoPingTimeoutSec       = 5
@@ -114,7 +115,8 @@ func TestConfig(authWebhook string) *yorkie.Config {
 	portOffset += 100
 	return &yorkie.Config{
 		RPC: &rpc.Config{
-			Port: RPCPort + portOffset,
+			Port:        RPCPort + portOffset,
+			ClusterPort: RPCClusterPort + portOffset,
 		},
 		Metrics: &prometheus.Config{
 			Port: MetricsPort + portOffset,
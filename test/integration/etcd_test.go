@@ -1,3 +1,4 @@
+//go:build integration
 // +build integration
 
 /*
@@ -19,11 +20,16 @@
 package integration
 
 import (
+	"context"
 	"testing"
+	gotime "time"
 
 	"github.com/rs/xid"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/yorkie-team/yorkie/pkg/document/key"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+	"github.com/yorkie-team/yorkie/pkg/types"
 	"github.com/yorkie-team/yorkie/test/helper"
 	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
 	"github.com/yorkie-team/yorkie/yorkie/backend/sync/etcd"
@@ -35,7 +41,7 @@ func TestETCD(t *testing.T) {
 			Endpoints: helper.ETCDEndpoints,
 		}, &sync.AgentInfo{
 			ID: xid.New().String(),
-		})
+		}, nil, nil)
 		assert.NoError(t, err)
 
 		defer func() {
@@ -43,4 +49,122 @@ func TestETCD(t *testing.T) {
 			assert.NoError(t, err)
 		}()
 	})
+	t.Run("try lock test", func(t *testing.T) {
+		ctx := context.Background()
+		cli, err := etcd.Dial(&etcd.Config{
+			Endpoints: helper.ETCDEndpoints,
+		}, &sync.AgentInfo{
+			ID: xid.New().String(),
+		}, nil, nil)
+		assert.NoError(t, err)
+		defer func() {
+			assert.NoError(t, cli.Close())
+		}()
+
+		lockKey := sync.NewKey(t.Name() + xid.New().String())
+		lockerA, err := cli.NewLocker(ctx, lockKey)
+		assert.NoError(t, err)
+		lockerB, err := cli.NewLocker(ctx, lockKey)
+		assert.NoError(t, err)
+
+		locked, err := lockerA.TryLock(ctx)
+		assert.NoError(t, err)
+		assert.True(t, locked)
+
+		locked, err = lockerB.TryLock(ctx)
+		assert.NoError(t, err)
+		assert.False(t, locked)
+
+		assert.NoError(t, lockerA.Unlock(ctx))
+
+		locked, err = lockerB.TryLock(ctx)
+		assert.NoError(t, err)
+		assert.True(t, locked)
+		assert.NoError(t, lockerB.Unlock(ctx))
+	})
+
+	t.Run("watch propagation test", func(t *testing.T) {
+		ctx := context.Background()
+		newClient := func() *etcd.Client {
+			cli, err := etcd.Dial(&etcd.Config{
+				Endpoints:        helper.ETCDEndpoints,
+				EventPropagation: etcd.WatchPropagation,
+			}, &sync.AgentInfo{
+				ID: xid.New().String(),
+			}, nil, nil)
+			assert.NoError(t, err)
+			assert.NoError(t, cli.Initialize())
+			return cli
+		}
+
+		cliA := newClient()
+		defer func() {
+			assert.NoError(t, cliA.Close())
+		}()
+		cliB := newClient()
+		defer func() {
+			assert.NoError(t, cliB.Close())
+		}()
+
+		actorA := types.Client{ID: &time.ActorID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}}
+		actorB := types.Client{ID: &time.ActorID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}}
+		docKeys := []*key.Key{{Collection: helper.Collection, Document: t.Name()}}
+
+		subB, _, err := cliB.Subscribe(actorB, docKeys)
+		assert.NoError(t, err)
+		defer cliB.Unsubscribe(docKeys, subB)
+
+		event := sync.DocEvent{
+			Type:         types.DocumentsChangedEvent,
+			Publisher:    actorA,
+			DocumentKeys: docKeys,
+		}
+		cliA.Publish(ctx, actorA.ID, event)
+
+		select {
+		case e := <-subB.Events():
+			assert.Equal(t, event.Type, e.Type)
+			assert.Equal(t, event.DocumentKeys[0].BSONKey(), e.DocumentKeys[0].BSONKey())
+		case <-gotime.After(5 * gotime.Second):
+			assert.Fail(t, "event should be propagated by watch")
+		}
+	})
+
+	t.Run("broadcast to unreachable member test", func(t *testing.T) {
+		newClient := func(clusterAddr string) *etcd.Client {
+			cli, err := etcd.Dial(&etcd.Config{
+				Endpoints:           helper.ETCDEndpoints,
+				BroadcastTimeoutSec: 1,
+			}, &sync.AgentInfo{
+				ID:          xid.New().String(),
+				ClusterAddr: clusterAddr,
+			}, nil, nil)
+			assert.NoError(t, err)
+			assert.NoError(t, cli.Initialize())
+			return cli
+		}
+
+		cliA := newClient("localhost:11201")
+		defer func() {
+			assert.NoError(t, cliA.Close())
+		}()
+		cliB := newClient("localhost:11202")
+		defer func() {
+			assert.NoError(t, cliB.Close())
+		}()
+
+		actorA := types.Client{ID: &time.ActorID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}}
+		docKeys := []*key.Key{{Collection: helper.Collection, Document: t.Name()}}
+
+		// the publisher is not blocked even if the other member is unreachable.
+		start := gotime.Now()
+		for i := 0; i < 10; i++ {
+			cliA.Publish(context.Background(), actorA.ID, sync.DocEvent{
+				Type:         types.DocumentsChangedEvent,
+				Publisher:    actorA,
+				DocumentKeys: docKeys,
+			})
+		}
+		assert.Less(t, int64(gotime.Since(start)), int64(gotime.Second))
+	})
 }
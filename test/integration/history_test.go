@@ -0,0 +1,148 @@
+// +build integration
+
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yorkie-team/yorkie/pkg/document"
+	"github.com/yorkie-team/yorkie/pkg/document/proxy"
+	"github.com/yorkie-team/yorkie/test/helper"
+)
+
+func TestHistory(t *testing.T) {
+	clients := createActivatedClients(t, 2)
+	c1 := clients[0]
+	c2 := clients[1]
+	defer func() {
+		cleanupClients(t, clients)
+	}()
+
+	t.Run("get document history test", func(t *testing.T) {
+		ctx := context.Background()
+
+		d1 := document.New(helper.Collection, t.Name())
+		assert.NoError(t, c1.Attach(ctx, d1))
+
+		for i := 0; i < 5; i++ {
+			assert.NoError(t, d1.Update(func(root *proxy.ObjectProxy) error {
+				root.SetInteger(fmt.Sprintf("k%d", i), i)
+				return nil
+			}, fmt.Sprintf("set k%d", i)))
+		}
+		assert.NoError(t, c1.Sync(ctx))
+
+		summaries, next, err := c1.GetDocumentHistory(ctx, d1.Key(), 0, 3)
+		assert.NoError(t, err)
+		assert.Len(t, summaries, 3)
+		assert.Equal(t, uint64(4), next)
+		for i, summary := range summaries {
+			assert.Equal(t, uint64(i+1), summary.ServerSeq)
+			assert.Equal(t, fmt.Sprintf("set k%d", i), summary.Message)
+			assert.Equal(t, c1.ID().String(), summary.Actor.String())
+			assert.False(t, summary.CreatedAt.IsZero())
+		}
+
+		summaries, next, err = c1.GetDocumentHistory(ctx, d1.Key(), next, 3)
+		assert.NoError(t, err)
+		assert.Len(t, summaries, 2)
+		assert.Equal(t, uint64(0), next)
+	})
+
+	t.Run("get document at server seq test", func(t *testing.T) {
+		ctx := context.Background()
+
+		d1 := document.New(helper.Collection, t.Name())
+		assert.NoError(t, c1.Attach(ctx, d1))
+
+		for i := 0; i < helper.SnapshotThreshold+1; i++ {
+			assert.NoError(t, d1.Update(func(root *proxy.ObjectProxy) error {
+				root.SetInteger("k", i)
+				return nil
+			}))
+			assert.NoError(t, c1.Sync(ctx))
+		}
+
+		// NOTE: waiting for snapshot.
+		time.Sleep(500 * time.Millisecond)
+
+		for _, serverSeq := range []uint64{1, 5, uint64(helper.SnapshotThreshold + 1)} {
+			doc, err := c1.GetDocumentAt(ctx, d1.Key(), serverSeq)
+			assert.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf(`{"k":%d}`, serverSeq-1), doc.Marshal())
+		}
+
+		_, err := c1.GetDocumentAt(ctx, d1.Key(), 100)
+		assert.Error(t, err)
+	})
+
+	t.Run("revert document test", func(t *testing.T) {
+		ctx := context.Background()
+
+		d1 := document.New(helper.Collection, t.Name())
+		assert.NoError(t, c1.Attach(ctx, d1))
+		d2 := document.New(helper.Collection, t.Name())
+		assert.NoError(t, c2.Attach(ctx, d2))
+
+		assert.NoError(t, d1.Update(func(root *proxy.ObjectProxy) error {
+			root.SetString("k1", "v1")
+			root.SetNewArray("k2").AddInteger(1, 2, 3)
+			root.SetNewText("k3").Edit(0, 0, "hello world")
+			root.SetNewCounter("k4", 10)
+			return nil
+		}, "initializes"))
+		assert.NoError(t, c1.Sync(ctx))
+		expected := d1.Marshal()
+
+		assert.NoError(t, d2.Update(func(root *proxy.ObjectProxy) error {
+			root.Delete("k1")
+			root.SetString("k5", "v5")
+			return nil
+		}, "updates by c2"))
+		assert.NoError(t, c2.Sync(ctx))
+		assert.NoError(t, c1.Sync(ctx))
+
+		assert.NoError(t, d1.Update(func(root *proxy.ObjectProxy) error {
+			root.GetArray("k2").Delete(0)
+			root.GetText("k3").Edit(6, 11, "yorkie")
+			root.GetCounter("k4").Increase(5)
+			return nil
+		}, "updates by c1"))
+		assert.NoError(t, c1.Sync(ctx))
+		assert.NotEqual(t, expected, d1.Marshal())
+
+		serverSeq, err := c1.RevertDocument(ctx, d1.Key(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(4), serverSeq)
+
+		assert.NoError(t, c1.Sync(ctx))
+		assert.NoError(t, c2.Sync(ctx))
+		assert.Equal(t, expected, d1.Marshal())
+		assert.Equal(t, expected, d2.Marshal())
+
+		summaries, _, err := c1.GetDocumentHistory(ctx, d1.Key(), serverSeq, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "revert to 1", summaries[0].Message)
+	})
+}
@@ -0,0 +1,48 @@
+// +build integration
+
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package integration
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yorkie-team/yorkie/test/helper"
+	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+	"github.com/yorkie-team/yorkie/yorkie/backend/db/dbtest"
+	"github.com/yorkie-team/yorkie/yorkie/backend/db/mongo"
+)
+
+func TestMongo(t *testing.T) {
+	t.Run("conformance test", func(t *testing.T) {
+		count := 0
+		dbtest.RunConformance(t, func(t *testing.T) db.DB {
+			count++
+			cli, err := mongo.Dial(&mongo.Config{
+				ConnectionURI:        helper.MongoConnectionURI,
+				ConnectionTimeoutSec: helper.MongoConnectionTimeoutSec,
+				PingTimeoutSec:       helper.MongoPingTimeoutSec,
+				YorkieDatabase:       fmt.Sprintf("%s-conformance-%d", helper.TestDBName(), count),
+			})
+			assert.NoError(t, err)
+			return cli
+		})
+	})
+}
@@ -39,7 +39,7 @@ func TestETCDStress(t *testing.T) {
 			Endpoints: helper.ETCDEndpoints,
 		}, &sync.AgentInfo{
 			ID: xid.New().String(),
-		})
+		}, nil, nil)
 		assert.NoError(t, err)
 		defer func() {
 			err := cli.Close()
@@ -35,7 +35,7 @@ func TestSyncStress(t *testing.T) {
 	t.Run("lock/unlock stress test", func(t *testing.T) {
 		start := time.Now()
 
-		coordinator := memory.NewCoordinator(nil)
+		coordinator := memory.NewCoordinator(nil, nil, nil)
 
 		size := 100
 		sum := 0
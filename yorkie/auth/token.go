@@ -6,9 +6,11 @@ type key int
 
 const tokenKey key = 0
 
-// TokenFromCtx returns the tokenKey from the given context.
+// TokenFromCtx returns the tokenKey from the given context. It returns an
+// empty string if the context does not have the token.
 func TokenFromCtx(ctx context.Context) string {
-	return ctx.Value(tokenKey).(string)
+	token, _ := ctx.Value(tokenKey).(string)
+	return token
 }
 
 // CtxWithToken creates a new context with the given token.
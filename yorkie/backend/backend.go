@@ -19,14 +19,19 @@ package backend
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	gosync "sync"
 	"time"
 
 	"github.com/rs/xid"
 
 	"github.com/yorkie-team/yorkie/internal/log"
+	"github.com/yorkie-team/yorkie/pkg/document/schema"
 	"github.com/yorkie-team/yorkie/pkg/types"
+	"github.com/yorkie-team/yorkie/yorkie/backend/cache"
 	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+	"github.com/yorkie-team/yorkie/yorkie/backend/db/bolt"
+	dbmemory "github.com/yorkie-team/yorkie/yorkie/backend/db/memory"
 	"github.com/yorkie-team/yorkie/yorkie/backend/db/mongo"
 	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
 	"github.com/yorkie-team/yorkie/yorkie/backend/sync/etcd"
@@ -34,8 +39,40 @@ import (
 	"github.com/yorkie-team/yorkie/yorkie/metrics"
 )
 
+// Below are the types of the database that Backend can use.
+const (
+	// MongoDB stores Yorkie data in MongoDB. It is used by default.
+	MongoDB = "mongo"
+
+	// MemoryDB stores Yorkie data in memory. The data is lost when the agent
+	// is stopped, so it is suitable for tests or small single-node agents.
+	MemoryDB = "memory"
+
+	// BoltDB stores Yorkie data in an embedded bbolt database file. It is
+	// suitable for single-node agents that do not want to run MongoDB.
+	BoltDB = "bolt"
+)
+
+// DefaultDocCacheSize is the default number of documents cached to validate
+// the changes pushed by clients.
+const DefaultDocCacheSize = 1000
+
+// The names of the limits of Config. They are used as the label of the metric
+// of the requests rejected by the limits.
+const (
+	LimitChangesPerPack             = "changes_per_pack"
+	LimitPackBytes                  = "pack_bytes"
+	LimitDocumentBytes              = "document_bytes"
+	LimitAttachedDocumentsPerClient = "attached_documents_per_client"
+	LimitWatchersPerDocument        = "watchers_per_document"
+)
+
 // Config is the configuration for creating a Backend instance.
 type Config struct {
+	// DBType is the type of the database to store Yorkie data. If it is empty,
+	// MongoDB is used.
+	DBType string `json:"DBType"`
+
 	// SnapshotThreshold is the threshold that determines if changes should be
 	// sent with snapshot when the number of changes is greater than this value.
 	SnapshotThreshold uint64 `json:"SnapshotThreshold"`
@@ -43,6 +80,45 @@ type Config struct {
 	// SnapshotInterval is the interval of changes to create a snapshot.
 	SnapshotInterval uint64 `json:"SnapshotInterval"`
 
+	// SubscriptionQueueSize is the maximum number of events queued for a
+	// subscription of WatchDocuments. If it is zero, the default is used.
+	SubscriptionQueueSize int `json:"SubscriptionQueueSize"`
+
+	// SlowConsumerPolicy is the policy applied to a subscription whose queue
+	// is full: "drop" or "resync". If it is empty, "resync" is used.
+	SlowConsumerPolicy string `json:"SlowConsumerPolicy"`
+
+	// DocCacheSize is the maximum number of documents cached to validate the
+	// changes pushed by clients. If it is zero, the default is used.
+	DocCacheSize int `json:"DocCacheSize"`
+
+	// SchemaFiles is the paths of JSON Schema files by collection. The
+	// changes that make a document violate the schema of its collection are
+	// rejected.
+	SchemaFiles map[string]string `json:"SchemaFiles"`
+
+	// MaxChangesPerPack is the maximum number of changes in a pack pushed by
+	// a client. If it is zero, the number is not limited.
+	MaxChangesPerPack int `json:"MaxChangesPerPack"`
+
+	// MaxPackBytes is the maximum size of a pack pushed by a client in bytes.
+	// If it is zero, the size is not limited.
+	MaxPackBytes int `json:"MaxPackBytes"`
+
+	// MaxDocumentBytes is the maximum size of the snapshot of a document in
+	// bytes. The changes that make the document larger are rejected. If it is
+	// zero, the size is not limited.
+	MaxDocumentBytes int `json:"MaxDocumentBytes"`
+
+	// MaxAttachedDocumentsPerClient is the maximum number of documents
+	// attached to a client at the same time. If it is zero, the number is not
+	// limited.
+	MaxAttachedDocumentsPerClient int `json:"MaxAttachedDocumentsPerClient"`
+
+	// MaxWatchersPerDocument is the maximum number of watchers of a document
+	// on an agent. If it is zero, the number is not limited.
+	MaxWatchersPerDocument int `json:"MaxWatchersPerDocument"`
+
 	// AuthorizationWebhookURL is the url of the authorization webhook.
 	AuthorizationWebhookURL string `json:"AuthorizationWebhookURL"`
 
@@ -71,12 +147,34 @@ func (c *Config) RequireAuth(method types.Method) bool {
 
 // Validate validates this config.
 func (c *Config) Validate() error {
+	if c.DBType != "" && c.DBType != MongoDB && c.DBType != MemoryDB && c.DBType != BoltDB {
+		return fmt.Errorf("not supported database type: %s", c.DBType)
+	}
+
+	if c.SlowConsumerPolicy != "" &&
+		c.SlowConsumerPolicy != sync.SlowConsumerDrop &&
+		c.SlowConsumerPolicy != sync.SlowConsumerResync {
+		return fmt.Errorf("not supported slow consumer policy: %s", c.SlowConsumerPolicy)
+	}
+
 	for _, method := range c.AuthorizationWebhookMethods {
 		if !types.IsAuthMethod(method) {
 			return fmt.Errorf("not supported method for authorization webhook: %s", method)
 		}
 	}
 
+	for name, limit := range map[string]int{
+		LimitChangesPerPack:             c.MaxChangesPerPack,
+		LimitPackBytes:                  c.MaxPackBytes,
+		LimitDocumentBytes:              c.MaxDocumentBytes,
+		LimitAttachedDocumentsPerClient: c.MaxAttachedDocumentsPerClient,
+		LimitWatchersPerDocument:        c.MaxWatchersPerDocument,
+	} {
+		if limit < 0 {
+			return fmt.Errorf("negative limit of %s: %d", name, limit)
+		}
+	}
+
 	return nil
 }
 
@@ -90,6 +188,12 @@ type Backend struct {
 	Coordinator sync.Coordinator
 	Metrics     metrics.Metrics
 
+	// DocCache holds the documents to validate the changes pushed by clients.
+	DocCache *cache.LRU
+
+	// Schemas holds the schemas of collections to validate the documents.
+	Schemas *schema.Registry
+
 	// closing is closed by backend close.
 	closing chan struct{}
 
@@ -105,8 +209,9 @@ type Backend struct {
 func New(
 	conf *Config,
 	mongoConf *mongo.Config,
+	boltConf *bolt.Config,
 	etcdConf *etcd.Config,
-	rpcAddr string,
+	clusterAddr string,
 	met metrics.Metrics,
 ) (*Backend, error) {
 	hostname, err := os.Hostname()
@@ -115,20 +220,33 @@ func New(
 	}
 
 	agentInfo := &sync.AgentInfo{
-		ID:        xid.New().String(),
-		Hostname:  hostname,
-		RPCAddr:   rpcAddr,
-		UpdatedAt: time.Now(),
+		ID:          xid.New().String(),
+		Hostname:    hostname,
+		ClusterAddr: clusterAddr,
+		UpdatedAt:   time.Now(),
 	}
 
-	mongoClient, err := mongo.Dial(mongoConf)
+	var database db.DB
+	switch conf.DBType {
+	case MemoryDB:
+		database, err = dbmemory.New()
+	case BoltDB:
+		if boltConf == nil {
+			return nil, fmt.Errorf("bolt config is required for %s", BoltDB)
+		}
+		database, err = bolt.Open(boltConf)
+	default:
+		database, err = mongo.Dial(mongoConf)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	pubSubConf := sync.NewPubSubConfig(conf.SubscriptionQueueSize, conf.SlowConsumerPolicy)
+
 	var coordinator sync.Coordinator
 	if etcdConf != nil {
-		etcdClient, err := etcd.Dial(etcdConf, agentInfo)
+		etcdClient, err := etcd.Dial(etcdConf, agentInfo, pubSubConf, met)
 		if err != nil {
 			return nil, err
 		}
@@ -138,21 +256,33 @@ func New(
 
 		coordinator = etcdClient
 	} else {
-		coordinator = memory.NewCoordinator(agentInfo)
+		coordinator = memory.NewCoordinator(agentInfo, pubSubConf, met)
+	}
+
+	docCacheSize := conf.DocCacheSize
+	if docCacheSize <= 0 {
+		docCacheSize = DefaultDocCacheSize
+	}
+
+	schemas, err := loadSchemas(conf.SchemaFiles)
+	if err != nil {
+		return nil, err
 	}
 
 	log.Logger.Infof(
-		"backend created: id: %s, rpc: %s",
+		"backend created: id: %s, cluster: %s",
 		agentInfo.ID,
-		agentInfo.RPCAddr,
+		agentInfo.ClusterAddr,
 	)
 
 	return &Backend{
 		Config:      conf,
 		agentInfo:   agentInfo,
-		DB:          mongoClient,
+		DB:          database,
 		Coordinator: coordinator,
 		Metrics:     met,
+		DocCache:    cache.NewLRU(docCacheSize),
+		Schemas:     schemas,
 		closing:     make(chan struct{}),
 	}, nil
 }
@@ -175,9 +305,9 @@ func (b *Backend) Close() error {
 	}
 
 	log.Logger.Infof(
-		"backend stoped: id: %s, rpc: %s",
+		"backend stoped: id: %s, cluster: %s",
 		b.agentInfo.ID,
-		b.agentInfo.RPCAddr,
+		b.agentInfo.ClusterAddr,
 	)
 
 	return nil
@@ -207,3 +337,24 @@ func (b *Backend) AttachGoroutine(f func()) {
 func (b *Backend) Members() map[string]*sync.AgentInfo {
 	return b.Coordinator.Members()
 }
+
+// loadSchemas creates a registry of the schemas in the given JSON Schema files
+// by collection.
+func loadSchemas(files map[string]string) (*schema.Registry, error) {
+	schemas := schema.NewRegistry()
+	for collection, file := range files {
+		data, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			log.Logger.Error(err)
+			return nil, err
+		}
+
+		s, err := schema.NewJSONSchema(data)
+		if err != nil {
+			return nil, fmt.Errorf("schema of %s: %w", collection, err)
+		}
+		schemas.Register(collection, s)
+	}
+
+	return schemas, nil
+}
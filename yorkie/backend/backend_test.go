@@ -17,12 +17,17 @@
 package backend_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/yorkie-team/yorkie/pkg/document/schema"
 	"github.com/yorkie-team/yorkie/pkg/types"
 	"github.com/yorkie-team/yorkie/yorkie/backend"
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+	"github.com/yorkie-team/yorkie/yorkie/metrics/prometheus"
 )
 
 func TestConfig(t *testing.T) {
@@ -49,4 +54,67 @@ func TestConfig(t *testing.T) {
 		assert.True(t, conf3.RequireAuth(types.ActivateClient))
 		assert.True(t, conf3.RequireAuth(types.DetachDocument))
 	})
+
+	t.Run("database type config test", func(t *testing.T) {
+		conf := backend.Config{DBType: "InvalidDBType"}
+		assert.Error(t, conf.Validate())
+
+		conf2 := backend.Config{DBType: backend.MemoryDB}
+		assert.NoError(t, conf2.Validate())
+
+		conf3 := backend.Config{}
+		assert.NoError(t, conf3.Validate())
+
+		conf4 := backend.Config{DBType: backend.BoltDB}
+		assert.NoError(t, conf4.Validate())
+	})
+
+	t.Run("slow consumer policy config test", func(t *testing.T) {
+		conf := backend.Config{SlowConsumerPolicy: "InvalidPolicy"}
+		assert.Error(t, conf.Validate())
+
+		conf2 := backend.Config{SlowConsumerPolicy: sync.SlowConsumerDrop}
+		assert.NoError(t, conf2.Validate())
+
+		conf3 := backend.Config{SlowConsumerPolicy: sync.SlowConsumerResync}
+		assert.NoError(t, conf3.Validate())
+	})
+
+	t.Run("limits config test", func(t *testing.T) {
+		conf := backend.Config{MaxPackBytes: -1}
+		assert.Error(t, conf.Validate())
+
+		conf2 := backend.Config{MaxChangesPerPack: 100, MaxWatchersPerDocument: 10}
+		assert.NoError(t, conf2.Validate())
+	})
+}
+
+func TestBackend(t *testing.T) {
+	t.Run("schema files test", func(t *testing.T) {
+		dir := t.TempDir()
+		validFile := filepath.Join(dir, "valid.json")
+		invalidFile := filepath.Join(dir, "invalid.json")
+		assert.NoError(t, os.WriteFile(validFile, []byte(`{"type": "object"}`), 0600))
+		assert.NoError(t, os.WriteFile(invalidFile, []byte(`{"type": "map"}`), 0600))
+
+		_, err := backend.New(&backend.Config{
+			DBType:      backend.MemoryDB,
+			SchemaFiles: map[string]string{"todos": invalidFile},
+		}, nil, nil, nil, "", prometheus.NewMetrics())
+		assert.ErrorIs(t, err, schema.ErrInvalidSchema)
+
+		be, err := backend.New(&backend.Config{
+			DBType:      backend.MemoryDB,
+			SchemaFiles: map[string]string{"todos": validFile},
+		}, nil, nil, nil, "", prometheus.NewMetrics())
+		assert.NoError(t, err)
+		defer func() {
+			assert.NoError(t, be.Close())
+		}()
+
+		_, ok := be.Schemas.Get("todos")
+		assert.True(t, ok)
+		_, ok = be.Schemas.Get("notes")
+		assert.False(t, ok)
+	})
 }
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yorkie-team/yorkie/yorkie/backend/cache"
+)
+
+func TestLRU(t *testing.T) {
+	t.Run("eviction test", func(t *testing.T) {
+		c := cache.NewLRU(2)
+		c.Add("a", 1)
+		c.Add("b", 2)
+
+		// "a" becomes the most recently used, so "b" is evicted.
+		value, ok := c.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, value)
+		c.Add("c", 3)
+		assert.Equal(t, 2, c.Len())
+
+		_, ok = c.Get("b")
+		assert.False(t, ok)
+		value, ok = c.Get("c")
+		assert.True(t, ok)
+		assert.Equal(t, 3, value)
+	})
+
+	t.Run("update and remove test", func(t *testing.T) {
+		c := cache.NewLRU(2)
+		c.Add("a", 1)
+		c.Add("a", 2)
+		assert.Equal(t, 1, c.Len())
+
+		value, ok := c.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 2, value)
+
+		c.Remove("a")
+		c.Remove("none")
+		_, ok = c.Get("a")
+		assert.False(t, ok)
+		assert.Equal(t, 0, c.Len())
+	})
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bolt
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/yorkie-team/yorkie/internal/log"
+)
+
+// Below are names of buckets that stores Yorkie data.
+//
+// Keys of the buckets for changes, snapshots and syncedseqs start with the ID
+// of the document and are followed by the big-endian encoded server seq, so
+// that the entries of a document are sorted by server seq and can be read by
+// range scans.
+var (
+	// BktClients stores clients by ID.
+	BktClients = []byte("clients")
+	// BktClientKeys stores IDs of clients by key.
+	BktClientKeys = []byte("client_keys")
+
+	// BktDocuments stores documents by ID.
+	BktDocuments = []byte("documents")
+	// BktDocumentKeys stores IDs of documents by key.
+	BktDocumentKeys = []byte("document_keys")
+
+	// BktChanges stores changes by (doc_id, server_seq).
+	BktChanges = []byte("changes")
+
+	// BktSnapshots stores snapshots by (doc_id, server_seq).
+	BktSnapshots = []byte("snapshots")
+
+	// BktSyncedSeqs stores server seqs by (doc_id, client_id).
+	BktSyncedSeqs = []byte("syncedseqs")
+	// BktSyncedSeqsBySeq stores empty values by (doc_id, server_seq, client_id)
+	// to find the min synced seq of a document.
+	BktSyncedSeqsBySeq = []byte("syncedseqs_by_seq")
+)
+
+// errNotFound is returned when the value of the given key is not in the bucket.
+var errNotFound = errors.New("not found")
+
+var buckets = [][]byte{
+	BktClients,
+	BktClientKeys,
+	BktDocuments,
+	BktDocumentKeys,
+	BktChanges,
+	BktSnapshots,
+	BktSyncedSeqs,
+	BktSyncedSeqsBySeq,
+}
+
+func ensureBuckets(db *bolt.DB) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		for _, name := range buckets {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				log.Logger.Error(err)
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// seqKey creates a key that consists of the given prefix and the big-endian
+// encoded seq.
+func seqKey(prefix []byte, seq uint64) []byte {
+	key := make([]byte, len(prefix)+8)
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], seq)
+	return key
+}
+
+// seqFromKey returns the seq encoded right after the given size of prefix.
+func seqFromKey(key []byte, prefixSize int) uint64 {
+	return binary.BigEndian.Uint64(key[prefixSize : prefixSize+8])
+}
+
+// get reads the value of the given key in the bucket and decodes it into the
+// given value.
+func get(bkt *bolt.Bucket, key []byte, value interface{}) error {
+	encoded := bkt.Get(key)
+	if encoded == nil {
+		return errNotFound
+	}
+
+	return json.Unmarshal(encoded, value)
+}
+
+// put encodes the given value and writes it to the given key of the bucket.
+func put(bkt *bolt.Bucket, key []byte, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return bkt.Put(key, encoded)
+}
@@ -0,0 +1,689 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	gotime "time"
+
+	"github.com/rs/xid"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/yorkie-team/yorkie/api/converter"
+	"github.com/yorkie-team/yorkie/internal/log"
+	"github.com/yorkie-team/yorkie/pkg/document"
+	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+)
+
+// idSize is the size of ID in bytes. It is the same as the size of ObjectID
+// in MongoDB so that IDs can be used as ActorIDs of clients.
+const idSize = 12
+
+// Config is the configuration for creating a Client instance.
+type Config struct {
+	Path           string          `json:"Path"`
+	OpenTimeoutSec gotime.Duration `json:"OpenTimeoutSec"`
+}
+
+// Client is a client that opens an embedded bbolt database file and reads or
+// saves Yorkie data.
+type Client struct {
+	config *Config
+	db     *bolt.DB
+}
+
+// Open creates an instance of Client and opens the database file of the given
+// path. If the file does not exist, it is created.
+func Open(conf *Config) (*Client, error) {
+	boltDB, err := bolt.Open(conf.Path, 0600, &bolt.Options{
+		Timeout: conf.OpenTimeoutSec * gotime.Second,
+	})
+	if err != nil {
+		log.Logger.Errorf("fail to open %s in %d sec", conf.Path, conf.OpenTimeoutSec)
+		return nil, err
+	}
+
+	if err := ensureBuckets(boltDB); err != nil {
+		if err := boltDB.Close(); err != nil {
+			log.Logger.Error(err)
+		}
+		return nil, err
+	}
+
+	log.Logger.Infof("bbolt opened, Path: %s", conf.Path)
+
+	return &Client{
+		config: conf,
+		db:     boltDB,
+	}, nil
+}
+
+// Close all resources of this client.
+func (c *Client) Close() error {
+	if err := c.db.Close(); err != nil {
+		log.Logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// ActivateClient activates the client of the given key.
+func (c *Client) ActivateClient(ctx context.Context, key string) (*db.ClientInfo, error) {
+	var clientInfo *db.ClientInfo
+	if err := c.db.Update(func(tx *bolt.Tx) error {
+		now := gotime.Now()
+
+		id := tx.Bucket(BktClientKeys).Get([]byte(key))
+		if id == nil {
+			clientInfo = &db.ClientInfo{
+				ID:        newID(),
+				Key:       key,
+				CreatedAt: now,
+			}
+			if err := tx.Bucket(BktClientKeys).Put(
+				[]byte(key),
+				clientInfo.ID.Bytes(),
+			); err != nil {
+				return err
+			}
+		} else {
+			clientInfo = &db.ClientInfo{}
+			if err := get(tx.Bucket(BktClients), id, clientInfo); err != nil {
+				return err
+			}
+		}
+
+		clientInfo.Status = db.ClientActivated
+		clientInfo.UpdatedAt = now
+		return put(tx.Bucket(BktClients), clientInfo.ID.Bytes(), clientInfo)
+	}); err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	return clientInfo, nil
+}
+
+// DeactivateClient deactivates the client of the given ID.
+func (c *Client) DeactivateClient(ctx context.Context, clientID db.ID) (*db.ClientInfo, error) {
+	if err := validateID(clientID); err != nil {
+		return nil, err
+	}
+
+	clientInfo := &db.ClientInfo{}
+	if err := c.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(BktClients)
+		if err := get(bkt, clientID.Bytes(), clientInfo); err != nil {
+			if err == errNotFound {
+				return fmt.Errorf("%s: %w", clientID, db.ErrClientNotFound)
+			}
+			return err
+		}
+
+		clientInfo.Status = db.ClientDeactivated
+		clientInfo.UpdatedAt = gotime.Now()
+		return put(bkt, clientID.Bytes(), clientInfo)
+	}); err != nil {
+		return nil, err
+	}
+
+	return clientInfo, nil
+}
+
+// FindClientInfoByID finds the client of the given ID.
+func (c *Client) FindClientInfoByID(ctx context.Context, clientID db.ID) (*db.ClientInfo, error) {
+	if err := validateID(clientID); err != nil {
+		return nil, err
+	}
+
+	clientInfo := &db.ClientInfo{}
+	if err := c.db.View(func(tx *bolt.Tx) error {
+		return get(tx.Bucket(BktClients), clientID.Bytes(), clientInfo)
+	}); err != nil {
+		if err == errNotFound {
+			return nil, fmt.Errorf("%s: %w", clientID, db.ErrClientNotFound)
+		}
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	return clientInfo, nil
+}
+
+// UpdateClientInfoAfterPushPull updates the client from the given clientInfo
+// after handling PushPull.
+func (c *Client) UpdateClientInfoAfterPushPull(
+	ctx context.Context,
+	clientInfo *db.ClientInfo,
+	docInfo *db.DocInfo,
+) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return updateClientInfo(tx, clientInfo, docInfo)
+	})
+}
+
+// FindDocInfoByKey finds the document of the given key. If the
+// createDocIfNotExist condition is true, create the document if it does not
+// exist.
+func (c *Client) FindDocInfoByKey(
+	ctx context.Context,
+	clientInfo *db.ClientInfo,
+	bsonDocKey string,
+	createDocIfNotExist bool,
+) (*db.DocInfo, error) {
+	if err := validateID(clientInfo.ID); err != nil {
+		return nil, err
+	}
+
+	var docInfo *db.DocInfo
+	if err := c.db.Update(func(tx *bolt.Tx) error {
+		now := gotime.Now()
+
+		id := tx.Bucket(BktDocumentKeys).Get([]byte(bsonDocKey))
+		if id == nil {
+			if !createDocIfNotExist {
+				return fmt.Errorf("%s: %w", bsonDocKey, db.ErrDocumentNotFound)
+			}
+
+			docInfo = &db.DocInfo{
+				ID:        newID(),
+				Key:       bsonDocKey,
+				ServerSeq: 0,
+				Owner:     clientInfo.ID,
+				CreatedAt: now,
+			}
+			if err := tx.Bucket(BktDocumentKeys).Put(
+				[]byte(bsonDocKey),
+				docInfo.ID.Bytes(),
+			); err != nil {
+				log.Logger.Error(err)
+				return err
+			}
+		} else {
+			docInfo = &db.DocInfo{}
+			if err := get(tx.Bucket(BktDocuments), id, docInfo); err != nil {
+				log.Logger.Error(err)
+				return err
+			}
+		}
+
+		docInfo.AccessedAt = now
+		return put(tx.Bucket(BktDocuments), docInfo.ID.Bytes(), docInfo)
+	}); err != nil {
+		return nil, err
+	}
+
+	return docInfo, nil
+}
+
+// StoreChangeInfos stores the given changes then updates the given docInfo,
+// the checkpoint of the given clientInfo and the synced server seq of the
+// client in a single transaction.
+func (c *Client) StoreChangeInfos(
+	ctx context.Context,
+	clientInfo *db.ClientInfo,
+	docInfo *db.DocInfo,
+	initialServerSeq uint64,
+	changes []*change.Change,
+	syncedServerSeq uint64,
+) error {
+	if err := validateID(docInfo.ID); err != nil {
+		return err
+	}
+
+	return c.db.Update(func(tx *bolt.Tx) error {
+		loaded := &db.DocInfo{}
+		if err := get(tx.Bucket(BktDocuments), docInfo.ID.Bytes(), loaded); err != nil {
+			if err == errNotFound {
+				return fmt.Errorf("%s: %w", docInfo.ID, db.ErrDocumentNotFound)
+			}
+			log.Logger.Error(err)
+			return err
+		}
+		if loaded.ServerSeq != initialServerSeq {
+			return fmt.Errorf("%s: %w", docInfo.ID, db.ErrConflictOnUpdate)
+		}
+
+		now := gotime.Now()
+		bkt := tx.Bucket(BktChanges)
+		for _, cn := range changes {
+			encodedOperations, err := db.EncodeOperations(cn.Operations())
+			if err != nil {
+				return err
+			}
+
+			if err := put(bkt, seqKey(docInfo.ID.Bytes(), cn.ServerSeq()), &db.ChangeInfo{
+				DocID:      docInfo.ID,
+				ServerSeq:  cn.ServerSeq(),
+				ClientSeq:  cn.ClientSeq(),
+				Lamport:    cn.ID().Lamport(),
+				Actor:      db.ID(cn.ID().Actor().String()),
+				Message:    cn.Message(),
+				Operations: encodedOperations,
+				CreatedAt:  now,
+			}); err != nil {
+				return err
+			}
+		}
+
+		loaded.ServerSeq = docInfo.ServerSeq
+		loaded.UpdatedAt = now
+		if err := put(tx.Bucket(BktDocuments), docInfo.ID.Bytes(), loaded); err != nil {
+			return err
+		}
+
+		if err := updateClientInfo(tx, clientInfo, docInfo); err != nil {
+			return err
+		}
+
+		return updateSyncedSeq(tx, clientInfo, docInfo.ID, syncedServerSeq)
+	})
+}
+
+// CreateSnapshotInfo stores the snapshot of the given document.
+func (c *Client) CreateSnapshotInfo(
+	ctx context.Context,
+	docID db.ID,
+	doc *document.InternalDocument,
+) error {
+	if err := validateID(docID); err != nil {
+		return err
+	}
+	snapshot, err := converter.ObjectToBytes(doc.RootObject())
+	if err != nil {
+		return err
+	}
+
+	return c.db.Update(func(tx *bolt.Tx) error {
+		serverSeq := doc.Checkpoint().ServerSeq
+		return put(tx.Bucket(BktSnapshots), seqKey(docID.Bytes(), serverSeq), &db.SnapshotInfo{
+			ID:        newID(),
+			DocID:     docID,
+			ServerSeq: serverSeq,
+			Snapshot:  snapshot,
+			CreatedAt: gotime.Now(),
+		})
+	})
+}
+
+// FindChangeInfosBetweenServerSeqs returns the changes between two server sequences.
+func (c *Client) FindChangeInfosBetweenServerSeqs(
+	ctx context.Context,
+	docID db.ID,
+	from uint64,
+	to uint64,
+) ([]*change.Change, error) {
+	infos, err := c.FindChangeInfos(ctx, docID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*change.Change
+	for _, info := range infos {
+		cn, err := info.ToChange()
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, cn)
+	}
+
+	return changes, nil
+}
+
+// FindChangeInfos returns the information of the changes between two server
+// sequences, including their messages and creation times.
+func (c *Client) FindChangeInfos(
+	ctx context.Context,
+	docID db.ID,
+	from uint64,
+	to uint64,
+) ([]*db.ChangeInfo, error) {
+	if err := validateID(docID); err != nil {
+		return nil, err
+	}
+
+	var infos []*db.ChangeInfo
+	if err := c.db.View(func(tx *bolt.Tx) error {
+		prefix := docID.Bytes()
+		cursor := tx.Bucket(BktChanges).Cursor()
+		for k, v := cursor.Seek(seqKey(prefix, from)); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
+			if seqFromKey(k, len(prefix)) > to {
+				break
+			}
+
+			changeInfo := &db.ChangeInfo{}
+			if err := json.Unmarshal(v, changeInfo); err != nil {
+				log.Logger.Error(err)
+				return err
+			}
+			infos = append(infos, changeInfo)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return infos, nil
+}
+
+// UpdateAndFindMinSyncedTicket updates the given serverSeq of the given client
+// and returns the min synced ticket.
+func (c *Client) UpdateAndFindMinSyncedTicket(
+	ctx context.Context,
+	clientInfo *db.ClientInfo,
+	docID db.ID,
+	serverSeq uint64,
+) (*time.Ticket, error) {
+	if err := validateID(docID); err != nil {
+		return nil, err
+	}
+	if err := validateID(clientInfo.ID); err != nil {
+		return nil, err
+	}
+
+	var ticket *time.Ticket
+	if err := c.db.Update(func(tx *bolt.Tx) error {
+		if err := updateSyncedSeq(tx, clientInfo, docID, serverSeq); err != nil {
+			return err
+		}
+
+		var err error
+		ticket, err = findMinSyncedTicket(tx, docID)
+		return err
+	}); err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// FindMinSyncedTicket returns the min synced ticket of the given document.
+func (c *Client) FindMinSyncedTicket(ctx context.Context, docID db.ID) (*time.Ticket, error) {
+	if err := validateID(docID); err != nil {
+		return nil, err
+	}
+
+	var ticket *time.Ticket
+	if err := c.db.View(func(tx *bolt.Tx) error {
+		var err error
+		ticket, err = findMinSyncedTicket(tx, docID)
+		return err
+	}); err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// FindLastSnapshotInfo finds the last snapshot of the given document.
+func (c *Client) FindLastSnapshotInfo(
+	ctx context.Context,
+	docID db.ID,
+) (*db.SnapshotInfo, error) {
+	return c.FindClosestSnapshotInfo(ctx, docID, math.MaxUint64)
+}
+
+// FindClosestSnapshotInfo finds the last snapshot of the given document whose
+// server seq is less than or equal to the given server seq.
+func (c *Client) FindClosestSnapshotInfo(
+	ctx context.Context,
+	docID db.ID,
+	serverSeq uint64,
+) (*db.SnapshotInfo, error) {
+	if err := validateID(docID); err != nil {
+		return nil, err
+	}
+
+	snapshotInfo := &db.SnapshotInfo{}
+	if err := c.db.View(func(tx *bolt.Tx) error {
+		prefix := docID.Bytes()
+		closest := seqKey(prefix, serverSeq)
+
+		cursor := tx.Bucket(BktSnapshots).Cursor()
+		k, v := cursor.Seek(closest)
+		if k == nil {
+			k, v = cursor.Last()
+		} else if !bytes.Equal(k, closest) {
+			k, v = cursor.Prev()
+		}
+		if k == nil || !bytes.HasPrefix(k, prefix) {
+			return nil
+		}
+
+		return json.Unmarshal(v, snapshotInfo)
+	}); err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	return snapshotInfo, nil
+}
+
+// updateSyncedSeq updates the given serverSeq of the given client in the given
+// transaction. If the document is detached, the synced seq is deleted.
+func updateSyncedSeq(
+	tx *bolt.Tx,
+	clientInfo *db.ClientInfo,
+	docID db.ID,
+	serverSeq uint64,
+) error {
+	isAttached, err := clientInfo.IsAttached(docID)
+	if err != nil {
+		return err
+	}
+
+	docIDBytes := docID.Bytes()
+	syncedSeqKey := append(docID.Bytes(), clientInfo.ID.Bytes()...)
+	syncedSeqs := tx.Bucket(BktSyncedSeqs)
+	syncedSeqsBySeq := tx.Bucket(BktSyncedSeqsBySeq)
+
+	if prev := syncedSeqs.Get(syncedSeqKey); prev != nil {
+		if err := syncedSeqsBySeq.Delete(append(
+			seqKey(docIDBytes, seqFromKey(prev, 0)),
+			clientInfo.ID.Bytes()...,
+		)); err != nil {
+			return err
+		}
+	}
+
+	if !isAttached {
+		return syncedSeqs.Delete(syncedSeqKey)
+	}
+
+	if err := syncedSeqs.Put(syncedSeqKey, seqKey(nil, serverSeq)); err != nil {
+		return err
+	}
+	return syncedSeqsBySeq.Put(append(
+		seqKey(docIDBytes, serverSeq),
+		clientInfo.ID.Bytes()...,
+	), []byte{})
+}
+
+// findMinSyncedTicket returns the min synced ticket of the given document in
+// the given transaction.
+func findMinSyncedTicket(tx *bolt.Tx, docID db.ID) (*time.Ticket, error) {
+	docIDBytes := docID.Bytes()
+	k, _ := tx.Bucket(BktSyncedSeqsBySeq).Cursor().Seek(docIDBytes)
+	if k == nil || !bytes.HasPrefix(k, docIDBytes) {
+		return time.InitialTicket, nil
+	}
+
+	minSyncedSeq := seqFromKey(k, len(docIDBytes))
+	if minSyncedSeq == 0 {
+		return time.InitialTicket, nil
+	}
+
+	changeInfo := &db.ChangeInfo{}
+	if err := get(tx.Bucket(BktChanges), seqKey(docIDBytes, minSyncedSeq), changeInfo); err != nil {
+		if err == errNotFound {
+			return nil, fmt.Errorf("%s: %w", docID.String(), db.ErrDocumentNotFound)
+		}
+		return nil, err
+	}
+
+	actorID, err := time.ActorIDFromHex(changeInfo.Actor.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return time.NewTicket(
+		changeInfo.Lamport,
+		time.MaxDelimiter,
+		actorID,
+	), nil
+}
+
+// FindDocInfosWithOrphanedChanges finds the documents that have changes
+// stored past their server seq.
+func (c *Client) FindDocInfosWithOrphanedChanges(ctx context.Context) ([]*db.DocInfo, error) {
+	var docInfos []*db.DocInfo
+	if err := c.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(BktDocuments).ForEach(func(k, v []byte) error {
+			docInfo := &db.DocInfo{}
+			if err := json.Unmarshal(v, docInfo); err != nil {
+				return err
+			}
+
+			if len(findOrphanedChangeKeys(tx, docInfo)) > 0 {
+				docInfos = append(docInfos, docInfo)
+			}
+			return nil
+		})
+	}); err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	return docInfos, nil
+}
+
+// DeleteOrphanedChangeInfos deletes the changes of the given document stored
+// past the server seq of the document and returns the number of them.
+func (c *Client) DeleteOrphanedChangeInfos(ctx context.Context, docID db.ID) (int, error) {
+	if err := validateID(docID); err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	if err := c.db.Update(func(tx *bolt.Tx) error {
+		docInfo := &db.DocInfo{}
+		if err := get(tx.Bucket(BktDocuments), docID.Bytes(), docInfo); err != nil {
+			if err == errNotFound {
+				return fmt.Errorf("%s: %w", docID, db.ErrDocumentNotFound)
+			}
+			return err
+		}
+
+		for _, k := range findOrphanedChangeKeys(tx, docInfo) {
+			if err := tx.Bucket(BktChanges).Delete(k); err != nil {
+				return err
+			}
+			deleted++
+		}
+		return nil
+	}); err != nil {
+		log.Logger.Error(err)
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// findOrphanedChangeKeys returns the keys of the changes of the given document
+// stored past the server seq of the document.
+func findOrphanedChangeKeys(tx *bolt.Tx, docInfo *db.DocInfo) [][]byte {
+	prefix := docInfo.ID.Bytes()
+	cursor := tx.Bucket(BktChanges).Cursor()
+
+	var keys [][]byte
+	k, _ := cursor.Seek(seqKey(prefix, docInfo.ServerSeq+1))
+	for ; k != nil && bytes.HasPrefix(k, prefix); k, _ = cursor.Next() {
+		keys = append(keys, append([]byte{}, k...))
+	}
+	return keys
+}
+
+// updateClientInfo updates the checkpoint and the status of the given document
+// of the client in the given transaction.
+func updateClientInfo(
+	tx *bolt.Tx,
+	clientInfo *db.ClientInfo,
+	docInfo *db.DocInfo,
+) error {
+	clientDocInfo := clientInfo.Documents[docInfo.ID]
+	attached, err := clientInfo.IsAttached(docInfo.ID)
+	if err != nil {
+		return err
+	}
+
+	id := tx.Bucket(BktClientKeys).Get([]byte(clientInfo.Key))
+	if id == nil {
+		return fmt.Errorf("%s: %w", clientInfo.Key, db.ErrClientNotFound)
+	}
+
+	loaded := &db.ClientInfo{}
+	if err := get(tx.Bucket(BktClients), id, loaded); err != nil {
+		log.Logger.Error(err)
+		return err
+	}
+	if loaded.Documents == nil {
+		loaded.Documents = make(map[db.ID]*db.ClientDocInfo)
+	}
+
+	// NOTE: Like `$max` of MongoDB, the checkpoint of the attached document
+	// only moves forward so that it is not overwritten by a delayed request.
+	loadedDocInfo, ok := loaded.Documents[docInfo.ID]
+	if !attached || !ok {
+		loadedDocInfo = &db.ClientDocInfo{}
+		loaded.Documents[docInfo.ID] = loadedDocInfo
+	}
+	if attached {
+		if clientDocInfo.ServerSeq > loadedDocInfo.ServerSeq {
+			loadedDocInfo.ServerSeq = clientDocInfo.ServerSeq
+		}
+		if clientDocInfo.ClientSeq > loadedDocInfo.ClientSeq {
+			loadedDocInfo.ClientSeq = clientDocInfo.ClientSeq
+		}
+	}
+	loadedDocInfo.Status = clientDocInfo.Status
+	loaded.UpdatedAt = clientInfo.UpdatedAt
+
+	return put(tx.Bucket(BktClients), id, loaded)
+}
+
+// newID creates a new ID that has the same size as ObjectID of MongoDB.
+func newID() db.ID {
+	return db.IDFromBytes(xid.New().Bytes())
+}
+
+// validateID validates the given ID like ObjectID of MongoDB does.
+func validateID(id db.ID) error {
+	if len(id.Bytes()) != idSize {
+		return fmt.Errorf("%s: %w", id, db.ErrInvalidID)
+	}
+
+	return nil
+}
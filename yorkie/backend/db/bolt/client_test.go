@@ -0,0 +1,150 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bolt_test
+
+import (
+	"context"
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	bbolt "go.etcd.io/bbolt"
+
+	"github.com/yorkie-team/yorkie/test/helper"
+	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+	"github.com/yorkie-team/yorkie/yorkie/backend/db/bolt"
+	"github.com/yorkie-team/yorkie/yorkie/backend/db/dbtest"
+)
+
+func TestClient(t *testing.T) {
+	dbtest.RunConformance(t, func(t *testing.T) db.DB {
+		cli, err := bolt.Open(&bolt.Config{
+			Path:           filepath.Join(t.TempDir(), "yorkie.db"),
+			OpenTimeoutSec: 1,
+		})
+		assert.NoError(t, err)
+		return cli
+	})
+}
+
+func TestClientReopen(t *testing.T) {
+	ctx := context.Background()
+	conf := &bolt.Config{
+		Path:           filepath.Join(t.TempDir(), "yorkie.db"),
+		OpenTimeoutSec: 1,
+	}
+
+	cli, err := bolt.Open(conf)
+	assert.NoError(t, err)
+
+	clientInfo, err := cli.ActivateClient(ctx, t.Name())
+	assert.NoError(t, err)
+	bsonDocKey := helper.Collection + "$" + t.Name()
+	docInfo, err := cli.FindDocInfoByKey(ctx, clientInfo, bsonDocKey, true)
+	assert.NoError(t, err)
+	assert.NoError(t, clientInfo.AttachDocument(docInfo.ID))
+	assert.NoError(t, cli.StoreChangeInfos(ctx, clientInfo, docInfo, 0, dbtest.CreateChanges(t, docInfo, 3), 0))
+
+	assert.NoError(t, cli.Close())
+
+	// the data should be kept after reopening the file.
+	cli, err = bolt.Open(conf)
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cli.Close())
+	}()
+
+	found, err := cli.FindClientInfoByID(ctx, clientInfo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, clientInfo.Key, found.Key)
+
+	foundDocInfo, err := cli.FindDocInfoByKey(ctx, clientInfo, bsonDocKey, false)
+	assert.NoError(t, err)
+	assert.Equal(t, docInfo.ID, foundDocInfo.ID)
+	assert.Equal(t, uint64(3), foundDocInfo.ServerSeq)
+
+	changes, err := cli.FindChangeInfosBetweenServerSeqs(ctx, docInfo.ID, 1, 3)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 3)
+}
+
+func TestClientRepair(t *testing.T) {
+	ctx := context.Background()
+	conf := &bolt.Config{
+		Path:           filepath.Join(t.TempDir(), "yorkie.db"),
+		OpenTimeoutSec: 1,
+	}
+
+	cli, err := bolt.Open(conf)
+	assert.NoError(t, err)
+
+	clientInfo, err := cli.ActivateClient(ctx, t.Name())
+	assert.NoError(t, err)
+	docInfo, err := cli.FindDocInfoByKey(ctx, clientInfo, helper.Collection+"$"+t.Name(), true)
+	assert.NoError(t, err)
+	assert.NoError(t, clientInfo.AttachDocument(docInfo.ID))
+	assert.NoError(t, cli.StoreChangeInfos(ctx, clientInfo, docInfo, 0, dbtest.CreateChanges(t, docInfo, 3), 0))
+	assert.NoError(t, cli.Close())
+
+	// leave the changes past the server seq of the document like a crash
+	// while storing changes.
+	raw, err := bbolt.Open(conf.Path, 0600, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, raw.Update(func(tx *bbolt.Tx) error {
+		bkt := tx.Bucket(bolt.BktChanges)
+		value := bkt.Get(changeKey(docInfo.ID.Bytes(), 3))
+		for _, serverSeq := range []uint64{4, 5} {
+			if err := bkt.Put(changeKey(docInfo.ID.Bytes(), serverSeq), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	assert.NoError(t, raw.Close())
+
+	cli, err = bolt.Open(conf)
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cli.Close())
+	}()
+
+	docInfos, err := cli.FindDocInfosWithOrphanedChanges(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, docInfos, 1)
+	assert.Equal(t, docInfo.ID, docInfos[0].ID)
+
+	deleted, err := cli.DeleteOrphanedChangeInfos(ctx, docInfo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	docInfos, err = cli.FindDocInfosWithOrphanedChanges(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, docInfos, 0)
+
+	changes, err := cli.FindChangeInfosBetweenServerSeqs(ctx, docInfo.ID, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 3)
+}
+
+// changeKey creates the key of the change in the changes bucket.
+func changeKey(docID []byte, serverSeq uint64) []byte {
+	key := make([]byte, len(docID)+8)
+	copy(key, docID)
+	binary.BigEndian.PutUint64(key[len(docID):], serverSeq)
+	return key
+}
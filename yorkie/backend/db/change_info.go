@@ -18,6 +18,7 @@ package db
 
 import (
 	"errors"
+	gotime "time"
 
 	"github.com/yorkie-team/yorkie/api"
 	"github.com/yorkie-team/yorkie/api/converter"
@@ -29,13 +30,14 @@ import (
 
 // ChangeInfo is a structure representing information of a change.
 type ChangeInfo struct {
-	DocID      ID       `bson:"doc_id_fake"`
-	ServerSeq  uint64   `bson:"server_seq"`
-	ClientSeq  uint32   `bson:"client_seq"`
-	Lamport    uint64   `bson:"lamport"`
-	Actor      ID       `bson:"actor_fake"`
-	Message    string   `bson:"message"`
-	Operations [][]byte `bson:"operations"`
+	DocID      ID          `bson:"doc_id_fake"`
+	ServerSeq  uint64      `bson:"server_seq"`
+	ClientSeq  uint32      `bson:"client_seq"`
+	Lamport    uint64      `bson:"lamport"`
+	Actor      ID          `bson:"actor_fake"`
+	Message    string      `bson:"message"`
+	Operations [][]byte    `bson:"operations"`
+	CreatedAt  gotime.Time `bson:"created_at"`
 }
 
 // EncodeOperations encodes the given operations into bytes array.
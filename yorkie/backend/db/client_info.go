@@ -146,6 +146,47 @@ func (i *ClientInfo) EnsureDocumentAttached(docID ID) error {
 	return nil
 }
 
+// AttachedDocumentsLen returns the number of the documents attached to this
+// client.
+func (i *ClientInfo) AttachedDocumentsLen() int {
+	count := 0
+	for _, docInfo := range i.Documents {
+		if docInfo.Status == documentAttached {
+			count++
+		}
+	}
+
+	return count
+}
+
 func (i *ClientInfo) hasDocument(docID ID) bool {
 	return i.Documents != nil && i.Documents[docID] != nil
 }
+
+// DeepCopy returns a deep copy of this client info.
+func (i *ClientInfo) DeepCopy() *ClientInfo {
+	if i == nil {
+		return nil
+	}
+
+	var documents map[ID]*ClientDocInfo
+	if i.Documents != nil {
+		documents = make(map[ID]*ClientDocInfo, len(i.Documents))
+		for docID, docInfo := range i.Documents {
+			documents[docID] = &ClientDocInfo{
+				Status:    docInfo.Status,
+				ServerSeq: docInfo.ServerSeq,
+				ClientSeq: docInfo.ClientSeq,
+			}
+		}
+	}
+
+	return &ClientInfo{
+		ID:        i.ID,
+		Key:       i.Key,
+		Status:    i.Status,
+		Documents: documents,
+		CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt,
+	}
+}
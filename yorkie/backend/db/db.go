@@ -74,12 +74,17 @@ type DB interface {
 		createDocIfNotExist bool,
 	) (*DocInfo, error)
 
-	// StoreChangeInfos stores the given changes then updates the given docInfo.
+	// StoreChangeInfos stores the given changes then updates the given docInfo,
+	// the checkpoint of the given clientInfo and the given synced server seq of
+	// the client. They should be committed atomically, or stored idempotently
+	// so that the changes sent again by the client are not stored twice.
 	StoreChangeInfos(
 		ctx context.Context,
+		clientInfo *ClientInfo,
 		docInfo *DocInfo,
 		initialServerSeq uint64,
 		changes []*change.Change,
+		syncedServerSeq uint64,
 	) error
 
 	// CreateSnapshotInfo stores the snapshot of the given document.
@@ -93,6 +98,15 @@ type DB interface {
 		to uint64,
 	) ([]*change.Change, error)
 
+	// FindChangeInfos returns the information of the changes between two
+	// server sequences, including their messages and creation times.
+	FindChangeInfos(
+		ctx context.Context,
+		docID ID,
+		from uint64,
+		to uint64,
+	) ([]*ChangeInfo, error)
+
 	// UpdateAndFindMinSyncedTicket updates the given serverSeq of the given client
 	// and returns the min synced ticket.
 	UpdateAndFindMinSyncedTicket(
@@ -102,6 +116,22 @@ type DB interface {
 		serverSeq uint64,
 	) (*time.Ticket, error)
 
+	// FindMinSyncedTicket returns the min synced ticket of the given document.
+	FindMinSyncedTicket(ctx context.Context, docID ID) (*time.Ticket, error)
+
 	// FindLastSnapshotInfo finds the last snapshot of the given document.
 	FindLastSnapshotInfo(ctx context.Context, docID ID) (*SnapshotInfo, error)
+
+	// FindClosestSnapshotInfo finds the last snapshot of the given document
+	// whose server seq is less than or equal to the given server seq.
+	FindClosestSnapshotInfo(ctx context.Context, docID ID, serverSeq uint64) (*SnapshotInfo, error)
+
+	// FindDocInfosWithOrphanedChanges finds the documents that have changes
+	// stored past their server seq.
+	FindDocInfosWithOrphanedChanges(ctx context.Context) ([]*DocInfo, error)
+
+	// DeleteOrphanedChangeInfos deletes the changes of the given document
+	// stored past the server seq of the document and returns the number of
+	// them.
+	DeleteOrphanedChangeInfos(ctx context.Context, docID ID) (int, error)
 }
@@ -0,0 +1,563 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package dbtest provides a conformance test suite that checks whether an
+// implementation of db.DB keeps the contract of the interface.
+package dbtest
+
+import (
+	"context"
+	"fmt"
+	gosync "sync"
+	"testing"
+	gotime "time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yorkie-team/yorkie/pkg/document"
+	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/proxy"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+)
+
+const collection = "dbtest-collection"
+
+// notExistsID is a valid ID that is not issued by any database.
+var notExistsID = db.ID("000000000000000000000000")
+
+// Factory creates an empty database to be tested. The database is closed by
+// the suite after the subtest that uses it finishes.
+type Factory func(t *testing.T) db.DB
+
+// RunConformance runs the conformance test suite against the databases that
+// are created by the given factory. Each subtest uses its own database.
+func RunConformance(t *testing.T, factory Factory) {
+	run := func(name string, f func(t *testing.T, d db.DB)) {
+		t.Run(name, func(t *testing.T) {
+			d := factory(t)
+			defer func() {
+				assert.NoError(t, d.Close())
+			}()
+			f(t, d)
+		})
+	}
+
+	run("activate/deactivate client test", testActivateDeactivateClient)
+	run("invalid ID test", testInvalidID)
+	run("attach/detach checkpoint test", testAttachDetachCheckpoint)
+	run("find docInfo test", testFindDocInfo)
+	run("store changes test", testStoreChanges)
+	run("store changes conflict test", testStoreChangesConflict)
+	run("store changes with checkpoint test", testStoreChangesWithCheckpoint)
+	run("orphaned changes test", testOrphanedChanges)
+	run("find change infos test", testFindChangeInfos)
+	run("store and find snapshot test", testSnapshot)
+	run("update and find min synced ticket test", testMinSyncedTicket)
+	run("min synced ticket with concurrent clients test", testMinSyncedTicketConcurrently)
+}
+
+func testActivateDeactivateClient(t *testing.T, d db.DB) {
+	ctx := context.Background()
+
+	// try to deactivate or find the client with not exists ID.
+	_, err := d.DeactivateClient(ctx, notExistsID)
+	assert.ErrorIs(t, err, db.ErrClientNotFound)
+	_, err = d.FindClientInfoByID(ctx, notExistsID)
+	assert.ErrorIs(t, err, db.ErrClientNotFound)
+
+	clientInfo, err := d.ActivateClient(ctx, t.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, t.Name(), clientInfo.Key)
+	assert.Equal(t, db.ClientActivated, clientInfo.Status)
+
+	// activating the client twice should return the same client.
+	clientInfo2, err := d.ActivateClient(ctx, t.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, clientInfo.ID, clientInfo2.ID)
+
+	deactivated, err := d.DeactivateClient(ctx, clientInfo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, db.ClientDeactivated, deactivated.Status)
+
+	found, err := d.FindClientInfoByID(ctx, clientInfo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, db.ClientDeactivated, found.Status)
+
+	// the deactivated client can be activated again.
+	reactivated, err := d.ActivateClient(ctx, t.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, clientInfo.ID, reactivated.ID)
+	assert.Equal(t, db.ClientActivated, reactivated.Status)
+}
+
+func testInvalidID(t *testing.T, d db.DB) {
+	ctx := context.Background()
+	invalidID := db.ID("invalid")
+
+	_, err := d.FindClientInfoByID(ctx, invalidID)
+	assert.ErrorIs(t, err, db.ErrInvalidID)
+
+	_, err = d.DeactivateClient(ctx, invalidID)
+	assert.ErrorIs(t, err, db.ErrInvalidID)
+
+	_, err = d.FindChangeInfosBetweenServerSeqs(ctx, invalidID, 1, 10)
+	assert.ErrorIs(t, err, db.ErrInvalidID)
+
+	_, err = d.FindLastSnapshotInfo(ctx, invalidID)
+	assert.ErrorIs(t, err, db.ErrInvalidID)
+}
+
+func testAttachDetachCheckpoint(t *testing.T, d db.DB) {
+	ctx := context.Background()
+
+	clientInfo, err := d.ActivateClient(ctx, t.Name())
+	assert.NoError(t, err)
+
+	docInfo, err := d.FindDocInfoByKey(ctx, clientInfo, bsonDocKey(t), true)
+	assert.NoError(t, err)
+
+	assert.NoError(t, clientInfo.AttachDocument(docInfo.ID))
+	clientInfo.Documents[docInfo.ID].ServerSeq = 5
+	clientInfo.Documents[docInfo.ID].ClientSeq = 3
+	assert.NoError(t, d.UpdateClientInfoAfterPushPull(ctx, clientInfo, docInfo))
+
+	found, err := d.FindClientInfoByID(ctx, clientInfo.ID)
+	assert.NoError(t, err)
+	isAttached, err := found.IsAttached(docInfo.ID)
+	assert.NoError(t, err)
+	assert.True(t, isAttached)
+
+	// the checkpoint should not move backward.
+	clientInfo.Documents[docInfo.ID].ServerSeq = 1
+	clientInfo.Documents[docInfo.ID].ClientSeq = 1
+	assert.NoError(t, d.UpdateClientInfoAfterPushPull(ctx, clientInfo, docInfo))
+
+	found, err = d.FindClientInfoByID(ctx, clientInfo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), found.Checkpoint(docInfo.ID).ServerSeq)
+	assert.Equal(t, uint32(3), found.Checkpoint(docInfo.ID).ClientSeq)
+
+	// the checkpoint should be reset when the document is detached.
+	assert.NoError(t, clientInfo.DetachDocument(docInfo.ID))
+	assert.NoError(t, d.UpdateClientInfoAfterPushPull(ctx, clientInfo, docInfo))
+
+	found, err = d.FindClientInfoByID(ctx, clientInfo.ID)
+	assert.NoError(t, err)
+	isAttached, err = found.IsAttached(docInfo.ID)
+	assert.NoError(t, err)
+	assert.False(t, isAttached)
+	assert.Equal(t, uint64(0), found.Checkpoint(docInfo.ID).ServerSeq)
+	assert.Equal(t, uint32(0), found.Checkpoint(docInfo.ID).ClientSeq)
+
+	// try to update the client that does not exist.
+	unknown := &db.ClientInfo{
+		ID:     notExistsID,
+		Key:    t.Name() + "-unknown",
+		Status: db.ClientActivated,
+	}
+	assert.NoError(t, unknown.AttachDocument(docInfo.ID))
+	assert.ErrorIs(
+		t,
+		d.UpdateClientInfoAfterPushPull(ctx, unknown, docInfo),
+		db.ErrClientNotFound,
+	)
+}
+
+func testFindDocInfo(t *testing.T, d db.DB) {
+	ctx := context.Background()
+
+	clientInfo, err := d.ActivateClient(ctx, t.Name())
+	assert.NoError(t, err)
+
+	_, err = d.FindDocInfoByKey(ctx, clientInfo, bsonDocKey(t), false)
+	assert.ErrorIs(t, err, db.ErrDocumentNotFound)
+
+	docInfo, err := d.FindDocInfoByKey(ctx, clientInfo, bsonDocKey(t), true)
+	assert.NoError(t, err)
+	assert.Equal(t, bsonDocKey(t), docInfo.Key)
+	assert.Equal(t, clientInfo.ID, docInfo.Owner)
+	assert.Equal(t, uint64(0), docInfo.ServerSeq)
+
+	docInfo2, err := d.FindDocInfoByKey(ctx, clientInfo, bsonDocKey(t), false)
+	assert.NoError(t, err)
+	assert.Equal(t, docInfo.ID, docInfo2.ID)
+
+	// documents of different keys should have different IDs.
+	docInfo3, err := d.FindDocInfoByKey(ctx, clientInfo, bsonDocKey(t)+"-other", true)
+	assert.NoError(t, err)
+	assert.NotEqual(t, docInfo.ID, docInfo3.ID)
+}
+
+func testStoreChanges(t *testing.T, d db.DB) {
+	ctx := context.Background()
+
+	clientInfo, err := d.ActivateClient(ctx, t.Name())
+	assert.NoError(t, err)
+
+	docInfo, err := d.FindDocInfoByKey(ctx, clientInfo, bsonDocKey(t), true)
+	assert.NoError(t, err)
+	otherDocInfo, err := d.FindDocInfoByKey(ctx, clientInfo, bsonDocKey(t)+"-other", true)
+	assert.NoError(t, err)
+	assert.NoError(t, clientInfo.AttachDocument(docInfo.ID))
+	assert.NoError(t, clientInfo.AttachDocument(otherDocInfo.ID))
+
+	// store changes in two batches, the second one in reverse order.
+	changes := CreateChanges(t, docInfo, 4)
+	assert.NoError(t, d.StoreChangeInfos(ctx, clientInfo, docInfo, 0, changes, 0))
+	changes = CreateChanges(t, docInfo, 6)
+	reversed := make([]*change.Change, len(changes))
+	for i, c := range changes {
+		reversed[len(changes)-1-i] = c
+	}
+	assert.NoError(t, d.StoreChangeInfos(ctx, clientInfo, docInfo, 4, reversed, 0))
+	assert.NoError(t, d.StoreChangeInfos(
+		ctx,
+		clientInfo,
+		otherDocInfo,
+		0,
+		CreateChanges(t, otherDocInfo, 3),
+		0,
+	))
+
+	// changes should be returned in the order of server seq.
+	loaded, err := d.FindChangeInfosBetweenServerSeqs(ctx, docInfo.ID, 3, 7)
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 5)
+	for i, c := range loaded {
+		assert.Equal(t, uint64(i+3), c.ServerSeq())
+	}
+
+	loaded, err = d.FindChangeInfosBetweenServerSeqs(ctx, docInfo.ID, 1, 100)
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 10)
+
+	loaded, err = d.FindChangeInfosBetweenServerSeqs(ctx, docInfo.ID, 11, 20)
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 0)
+
+	loaded, err = d.FindChangeInfosBetweenServerSeqs(ctx, notExistsID, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 0)
+
+	found, err := d.FindDocInfoByKey(ctx, clientInfo, bsonDocKey(t), false)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), found.ServerSeq)
+
+	found, err = d.FindDocInfoByKey(ctx, clientInfo, bsonDocKey(t)+"-other", false)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), found.ServerSeq)
+}
+
+func testOrphanedChanges(t *testing.T, d db.DB) {
+	ctx := context.Background()
+
+	clientInfo, err := d.ActivateClient(ctx, t.Name())
+	assert.NoError(t, err)
+
+	docInfo, err := d.FindDocInfoByKey(ctx, clientInfo, bsonDocKey(t), true)
+	assert.NoError(t, err)
+	assert.NoError(t, clientInfo.AttachDocument(docInfo.ID))
+	assert.NoError(t, d.StoreChangeInfos(ctx, clientInfo, docInfo, 0, CreateChanges(t, docInfo, 3), 0))
+
+	// the changes stored with the document should not be orphaned.
+	docInfos, err := d.FindDocInfosWithOrphanedChanges(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, docInfos, 0)
+
+	deleted, err := d.DeleteOrphanedChangeInfos(ctx, docInfo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	changes, err := d.FindChangeInfosBetweenServerSeqs(ctx, docInfo.ID, 1, 3)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 3)
+
+	_, err = d.DeleteOrphanedChangeInfos(ctx, notExistsID)
+	assert.ErrorIs(t, err, db.ErrDocumentNotFound)
+	_, err = d.DeleteOrphanedChangeInfos(ctx, db.ID("invalid"))
+	assert.ErrorIs(t, err, db.ErrInvalidID)
+}
+
+func testFindChangeInfos(t *testing.T, d db.DB) {
+	ctx := context.Background()
+
+	clientInfo, err := d.ActivateClient(ctx, t.Name())
+	assert.NoError(t, err)
+
+	docInfo, err := d.FindDocInfoByKey(ctx, clientInfo, bsonDocKey(t), true)
+	assert.NoError(t, err)
+	assert.NoError(t, clientInfo.AttachDocument(docInfo.ID))
+
+	before := gotime.Now().Add(-gotime.Second)
+	changes := CreateChanges(t, docInfo, 5)
+	assert.NoError(t, d.StoreChangeInfos(ctx, clientInfo, docInfo, 0, changes, 0))
+
+	// the information of the changes should keep their messages, actors and
+	// creation times.
+	infos, err := d.FindChangeInfos(ctx, docInfo.ID, 2, 4)
+	assert.NoError(t, err)
+	assert.Len(t, infos, 3)
+	for i, info := range infos {
+		c := changes[i+1]
+		assert.Equal(t, docInfo.ID, info.DocID)
+		assert.Equal(t, c.ServerSeq(), info.ServerSeq)
+		assert.Equal(t, c.ClientSeq(), info.ClientSeq)
+		assert.Equal(t, c.ID().Actor().String(), info.Actor.String())
+		assert.Equal(t, c.Message(), info.Message)
+		assert.True(t, info.CreatedAt.After(before))
+	}
+
+	infos, err = d.FindChangeInfos(ctx, docInfo.ID, 6, 10)
+	assert.NoError(t, err)
+	assert.Len(t, infos, 0)
+}
+
+func testStoreChangesConflict(t *testing.T, d db.DB) {
+	ctx := context.Background()
+
+	clientInfo, err := d.ActivateClient(ctx, t.Name())
+	assert.NoError(t, err)
+
+	docInfo, err := d.FindDocInfoByKey(ctx, clientInfo, bsonDocKey(t), true)
+	assert.NoError(t, err)
+	assert.NoError(t, clientInfo.AttachDocument(docInfo.ID))
+
+	changes := CreateChanges(t, docInfo, 3)
+	assert.NoError(t, d.StoreChangeInfos(ctx, clientInfo, docInfo, 0, changes, 0))
+
+	// try to store changes with the stale server seq.
+	assert.ErrorIs(
+		t,
+		d.StoreChangeInfos(ctx, clientInfo, docInfo, 0, changes, 0),
+		db.ErrConflictOnUpdate,
+	)
+
+	found, err := d.FindDocInfoByKey(ctx, clientInfo, bsonDocKey(t), false)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), found.ServerSeq)
+}
+
+func testStoreChangesWithCheckpoint(t *testing.T, d db.DB) {
+	ctx := context.Background()
+
+	clientInfo, err := d.ActivateClient(ctx, t.Name())
+	assert.NoError(t, err)
+
+	docInfo, err := d.FindDocInfoByKey(ctx, clientInfo, bsonDocKey(t), true)
+	assert.NoError(t, err)
+	assert.NoError(t, clientInfo.AttachDocument(docInfo.ID))
+
+	// the checkpoint of the client should be stored with the changes.
+	changes := CreateChanges(t, docInfo, 3)
+	clientInfo.Documents[docInfo.ID].ServerSeq = 3
+	clientInfo.Documents[docInfo.ID].ClientSeq = 3
+	assert.NoError(t, d.StoreChangeInfos(ctx, clientInfo, docInfo, 0, changes, 0))
+
+	found, err := d.FindClientInfoByID(ctx, clientInfo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), found.Checkpoint(docInfo.ID).ServerSeq)
+	assert.Equal(t, uint32(3), found.Checkpoint(docInfo.ID).ClientSeq)
+
+	// the checkpoint should not be stored if the changes are in conflict.
+	clientInfo.Documents[docInfo.ID].ServerSeq = 6
+	clientInfo.Documents[docInfo.ID].ClientSeq = 6
+	assert.ErrorIs(
+		t,
+		d.StoreChangeInfos(ctx, clientInfo, docInfo, 0, CreateChanges(t, docInfo, 3), 0),
+		db.ErrConflictOnUpdate,
+	)
+
+	found, err = d.FindClientInfoByID(ctx, clientInfo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), found.Checkpoint(docInfo.ID).ServerSeq)
+	assert.Equal(t, uint32(3), found.Checkpoint(docInfo.ID).ClientSeq)
+}
+
+func testSnapshot(t *testing.T, d db.DB) {
+	ctx := context.Background()
+
+	clientInfo, err := d.ActivateClient(ctx, t.Name())
+	assert.NoError(t, err)
+
+	docInfo, err := d.FindDocInfoByKey(ctx, clientInfo, bsonDocKey(t), true)
+	assert.NoError(t, err)
+	otherDocInfo, err := d.FindDocInfoByKey(ctx, clientInfo, bsonDocKey(t)+"-other", true)
+	assert.NoError(t, err)
+
+	// an empty snapshot should be returned if there is no snapshot.
+	snapshotInfo, err := d.FindLastSnapshotInfo(ctx, docInfo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), snapshotInfo.ServerSeq)
+
+	for _, serverSeq := range []uint64{3, 10, 5} {
+		assert.NoError(t, d.CreateSnapshotInfo(ctx, docInfo.ID, createDocument(t, serverSeq)))
+	}
+	assert.NoError(t, d.CreateSnapshotInfo(ctx, otherDocInfo.ID, createDocument(t, 20)))
+
+	snapshotInfo, err = d.FindLastSnapshotInfo(ctx, docInfo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, docInfo.ID, snapshotInfo.DocID)
+	assert.Equal(t, uint64(10), snapshotInfo.ServerSeq)
+	assert.NotEmpty(t, snapshotInfo.Snapshot)
+
+	snapshotInfo, err = d.FindLastSnapshotInfo(ctx, otherDocInfo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(20), snapshotInfo.ServerSeq)
+
+	// the closest snapshot should not be past the given server seq.
+	for serverSeq, expected := range map[uint64]uint64{2: 0, 3: 3, 4: 3, 9: 5, 10: 10, 100: 10} {
+		snapshotInfo, err = d.FindClosestSnapshotInfo(ctx, docInfo.ID, serverSeq)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, snapshotInfo.ServerSeq)
+	}
+}
+
+func testMinSyncedTicket(t *testing.T, d db.DB) {
+	ctx := context.Background()
+
+	clientA, err := d.ActivateClient(ctx, t.Name()+"A")
+	assert.NoError(t, err)
+	clientB, err := d.ActivateClient(ctx, t.Name()+"B")
+	assert.NoError(t, err)
+
+	docInfo, err := d.FindDocInfoByKey(ctx, clientA, bsonDocKey(t), true)
+	assert.NoError(t, err)
+	assert.NoError(t, clientA.AttachDocument(docInfo.ID))
+	assert.NoError(t, clientB.AttachDocument(docInfo.ID))
+
+	// the initial ticket should be returned if no change is synced.
+	ticket, err := d.UpdateAndFindMinSyncedTicket(ctx, clientA, docInfo.ID, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, time.InitialTicket, ticket)
+
+	changes := CreateChanges(t, docInfo, 5)
+	assert.NoError(t, d.StoreChangeInfos(ctx, clientA, docInfo, 0, changes, 0))
+
+	ticket, err = d.UpdateAndFindMinSyncedTicket(ctx, clientA, docInfo.ID, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, changes[3].ID().Lamport(), ticket.Lamport())
+	assert.Equal(t, changes[3].ID().Actor().String(), ticket.ActorIDHex())
+
+	ticket, err = d.UpdateAndFindMinSyncedTicket(ctx, clientB, docInfo.ID, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, changes[1].ID().Lamport(), ticket.Lamport())
+
+	// the synced seq should be stored with the changes.
+	assert.NoError(t, d.StoreChangeInfos(ctx, clientB, docInfo, 5, CreateChanges(t, docInfo, 1), 3))
+	ticket, err = d.FindMinSyncedTicket(ctx, docInfo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, changes[2].ID().Lamport(), ticket.Lamport())
+
+	// the synced seq of the detached client should be removed.
+	assert.NoError(t, clientB.DetachDocument(docInfo.ID))
+	ticket, err = d.UpdateAndFindMinSyncedTicket(ctx, clientB, docInfo.ID, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, changes[3].ID().Lamport(), ticket.Lamport())
+
+	ticket, err = d.UpdateAndFindMinSyncedTicket(ctx, clientA, docInfo.ID, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, time.InitialTicket, ticket)
+}
+
+func testMinSyncedTicketConcurrently(t *testing.T, d db.DB) {
+	ctx := context.Background()
+	const clientCount = 10
+
+	owner, err := d.ActivateClient(ctx, t.Name())
+	assert.NoError(t, err)
+	docInfo, err := d.FindDocInfoByKey(ctx, owner, bsonDocKey(t), true)
+	assert.NoError(t, err)
+	assert.NoError(t, owner.AttachDocument(docInfo.ID))
+
+	changes := CreateChanges(t, docInfo, clientCount)
+	assert.NoError(t, d.StoreChangeInfos(ctx, owner, docInfo, 0, changes, clientCount))
+
+	var clients []*db.ClientInfo
+	for i := 0; i < clientCount; i++ {
+		clientInfo, err := d.ActivateClient(ctx, fmt.Sprintf("%s-%d", t.Name(), i))
+		assert.NoError(t, err)
+		assert.NoError(t, clientInfo.AttachDocument(docInfo.ID))
+		clients = append(clients, clientInfo)
+	}
+
+	// each client syncs a different server seq at the same time.
+	wg := gosync.WaitGroup{}
+	for i, clientInfo := range clients {
+		wg.Add(1)
+		go func(clientInfo *db.ClientInfo, serverSeq uint64) {
+			defer wg.Done()
+			_, err := d.UpdateAndFindMinSyncedTicket(ctx, clientInfo, docInfo.ID, serverSeq)
+			assert.NoError(t, err)
+		}(clientInfo, uint64(clientCount-i))
+	}
+	wg.Wait()
+
+	ticket, err := d.UpdateAndFindMinSyncedTicket(ctx, clients[0], docInfo.ID, clientCount)
+	assert.NoError(t, err)
+	assert.Equal(t, changes[0].ID().Lamport(), ticket.Lamport())
+
+	// the min synced ticket moves forward as the slowest clients detach.
+	for _, clientInfo := range clients[clientCount-3:] {
+		assert.NoError(t, clientInfo.DetachDocument(docInfo.ID))
+		_, err := d.UpdateAndFindMinSyncedTicket(ctx, clientInfo, docInfo.ID, 0)
+		assert.NoError(t, err)
+	}
+
+	ticket, err = d.UpdateAndFindMinSyncedTicket(ctx, clients[0], docInfo.ID, clientCount)
+	assert.NoError(t, err)
+	assert.Equal(t, changes[3].ID().Lamport(), ticket.Lamport())
+}
+
+// CreateChanges creates the given number of changes whose server seqs are
+// issued by the given docInfo.
+func CreateChanges(t *testing.T, docInfo *db.DocInfo, n int) []*change.Change {
+	// NOTE: Each batch is created by a different actor so that the IDs of the
+	// changes are not duplicated in the document.
+	actorID, err := time.ActorIDFromHex(fmt.Sprintf("%024x", docInfo.ServerSeq+1))
+	assert.NoError(t, err)
+
+	doc := document.New(collection, t.Name())
+	doc.SetActor(actorID)
+	for i := 0; i < n; i++ {
+		assert.NoError(t, doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetInteger(fmt.Sprintf("k%d", i), i)
+			return nil
+		}))
+	}
+
+	changes := doc.CreateChangePack().Changes
+	for _, c := range changes {
+		c.SetServerSeq(docInfo.IncreaseServerSeq())
+	}
+	return changes
+}
+
+// createDocument creates a document whose checkpoint has the given server seq.
+func createDocument(t *testing.T, serverSeq uint64) *document.InternalDocument {
+	doc := document.NewInternalDocument(collection, t.Name())
+	assert.NoError(t, doc.ApplyChangePack(change.NewPack(
+		doc.Key(),
+		doc.Checkpoint().NextServerSeq(serverSeq),
+		nil,
+		nil,
+	)))
+	return doc
+}
+
+func bsonDocKey(t *testing.T) string {
+	return collection + "$" + t.Name()
+}
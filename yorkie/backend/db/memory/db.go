@@ -0,0 +1,723 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"math"
+	gotime "time"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/rs/xid"
+
+	"github.com/yorkie-team/yorkie/api/converter"
+	"github.com/yorkie-team/yorkie/internal/log"
+	"github.com/yorkie-team/yorkie/pkg/document"
+	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+)
+
+// idSize is the size of ID in bytes. It is the same as the size of ObjectID
+// in MongoDB so that IDs can be used as ActorIDs of clients.
+const idSize = 12
+
+// DB is an in-memory database for testing or small single-node agents.
+type DB struct {
+	db *memdb.MemDB
+}
+
+// New returns a new in-memory database.
+func New() (*DB, error) {
+	memDB, err := memdb.NewMemDB(schema)
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	return &DB{
+		db: memDB,
+	}, nil
+}
+
+// Close closes the database.
+func (d *DB) Close() error {
+	return nil
+}
+
+// ActivateClient activates the client of the given key.
+func (d *DB) ActivateClient(
+	ctx context.Context,
+	key string,
+) (*db.ClientInfo, error) {
+	txn := d.db.Txn(true)
+	defer txn.Abort()
+
+	raw, err := txn.First(tblClients, "key", key)
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	now := gotime.Now()
+
+	var clientInfo *db.ClientInfo
+	if raw == nil {
+		clientInfo = &db.ClientInfo{
+			ID:        newID(),
+			Key:       key,
+			Status:    db.ClientActivated,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	} else {
+		clientInfo = raw.(*db.ClientInfo).DeepCopy()
+		clientInfo.Status = db.ClientActivated
+		clientInfo.UpdatedAt = now
+	}
+
+	if err := txn.Insert(tblClients, clientInfo); err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	txn.Commit()
+	return clientInfo.DeepCopy(), nil
+}
+
+// DeactivateClient deactivates the client of the given ID.
+func (d *DB) DeactivateClient(
+	ctx context.Context,
+	clientID db.ID,
+) (*db.ClientInfo, error) {
+	if err := validateID(clientID); err != nil {
+		return nil, err
+	}
+
+	txn := d.db.Txn(true)
+	defer txn.Abort()
+
+	raw, err := txn.First(tblClients, "id", clientID.String())
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("%s: %w", clientID, db.ErrClientNotFound)
+	}
+
+	clientInfo := raw.(*db.ClientInfo).DeepCopy()
+	clientInfo.Status = db.ClientDeactivated
+	clientInfo.UpdatedAt = gotime.Now()
+
+	if err := txn.Insert(tblClients, clientInfo); err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	txn.Commit()
+	return clientInfo.DeepCopy(), nil
+}
+
+// FindClientInfoByID finds the client of the given ID.
+func (d *DB) FindClientInfoByID(
+	ctx context.Context,
+	clientID db.ID,
+) (*db.ClientInfo, error) {
+	if err := validateID(clientID); err != nil {
+		return nil, err
+	}
+
+	txn := d.db.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(tblClients, "id", clientID.String())
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("%s: %w", clientID, db.ErrClientNotFound)
+	}
+
+	return raw.(*db.ClientInfo).DeepCopy(), nil
+}
+
+// UpdateClientInfoAfterPushPull updates the client from the given clientInfo
+// after handling PushPull.
+func (d *DB) UpdateClientInfoAfterPushPull(
+	ctx context.Context,
+	clientInfo *db.ClientInfo,
+	docInfo *db.DocInfo,
+) error {
+	txn := d.db.Txn(true)
+	defer txn.Abort()
+
+	if err := updateClientInfo(txn, clientInfo, docInfo); err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// FindDocInfoByKey finds the document of the given key. If the
+// createDocIfNotExist condition is true, create the document if it does not
+// exist.
+func (d *DB) FindDocInfoByKey(
+	ctx context.Context,
+	clientInfo *db.ClientInfo,
+	bsonDocKey string,
+	createDocIfNotExist bool,
+) (*db.DocInfo, error) {
+	if err := validateID(clientInfo.ID); err != nil {
+		return nil, err
+	}
+
+	txn := d.db.Txn(true)
+	defer txn.Abort()
+
+	raw, err := txn.First(tblDocuments, "key", bsonDocKey)
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+	if raw == nil && !createDocIfNotExist {
+		return nil, fmt.Errorf("%s: %w", bsonDocKey, db.ErrDocumentNotFound)
+	}
+
+	now := gotime.Now()
+
+	var docInfo *db.DocInfo
+	if raw == nil {
+		docInfo = &db.DocInfo{
+			ID:         newID(),
+			Key:        bsonDocKey,
+			ServerSeq:  0,
+			Owner:      clientInfo.ID,
+			CreatedAt:  now,
+			AccessedAt: now,
+		}
+	} else {
+		docInfo = raw.(*db.DocInfo).DeepCopy()
+		docInfo.AccessedAt = now
+	}
+
+	if err := txn.Insert(tblDocuments, docInfo); err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	txn.Commit()
+	return docInfo.DeepCopy(), nil
+}
+
+// StoreChangeInfos stores the given changes then updates the given docInfo,
+// the checkpoint of the given clientInfo and the synced server seq of the
+// client in a single transaction.
+func (d *DB) StoreChangeInfos(
+	ctx context.Context,
+	clientInfo *db.ClientInfo,
+	docInfo *db.DocInfo,
+	initialServerSeq uint64,
+	changes []*change.Change,
+	syncedServerSeq uint64,
+) error {
+	if err := validateID(docInfo.ID); err != nil {
+		return err
+	}
+
+	txn := d.db.Txn(true)
+	defer txn.Abort()
+
+	raw, err := txn.First(tblDocuments, "id", docInfo.ID.String())
+	if err != nil {
+		log.Logger.Error(err)
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("%s: %w", docInfo.ID, db.ErrDocumentNotFound)
+	}
+
+	loaded := raw.(*db.DocInfo).DeepCopy()
+	if loaded.ServerSeq != initialServerSeq {
+		return fmt.Errorf("%s: %w", docInfo.ID, db.ErrConflictOnUpdate)
+	}
+
+	now := gotime.Now()
+	for _, cn := range changes {
+		encodedOperations, err := db.EncodeOperations(cn.Operations())
+		if err != nil {
+			return err
+		}
+
+		if err := txn.Insert(tblChanges, &db.ChangeInfo{
+			DocID:      docInfo.ID,
+			ServerSeq:  cn.ServerSeq(),
+			ClientSeq:  cn.ClientSeq(),
+			Lamport:    cn.ID().Lamport(),
+			Actor:      db.ID(cn.ID().Actor().String()),
+			Message:    cn.Message(),
+			Operations: encodedOperations,
+			CreatedAt:  now,
+		}); err != nil {
+			log.Logger.Error(err)
+			return err
+		}
+	}
+
+	loaded.ServerSeq = docInfo.ServerSeq
+	loaded.UpdatedAt = now
+	if err := txn.Insert(tblDocuments, loaded); err != nil {
+		log.Logger.Error(err)
+		return err
+	}
+
+	if err := updateClientInfo(txn, clientInfo, docInfo); err != nil {
+		return err
+	}
+
+	if err := updateSyncedSeq(txn, clientInfo, docInfo.ID, syncedServerSeq); err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// CreateSnapshotInfo stores the snapshot of the given document.
+func (d *DB) CreateSnapshotInfo(
+	ctx context.Context,
+	docID db.ID,
+	doc *document.InternalDocument,
+) error {
+	if err := validateID(docID); err != nil {
+		return err
+	}
+
+	snapshot, err := converter.ObjectToBytes(doc.RootObject())
+	if err != nil {
+		return err
+	}
+
+	txn := d.db.Txn(true)
+	defer txn.Abort()
+
+	if err := txn.Insert(tblSnapshots, &db.SnapshotInfo{
+		ID:        newID(),
+		DocID:     docID,
+		ServerSeq: doc.Checkpoint().ServerSeq,
+		Snapshot:  snapshot,
+		CreatedAt: gotime.Now(),
+	}); err != nil {
+		log.Logger.Error(err)
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// FindChangeInfosBetweenServerSeqs returns the changes between two server sequences.
+func (d *DB) FindChangeInfosBetweenServerSeqs(
+	ctx context.Context,
+	docID db.ID,
+	from uint64,
+	to uint64,
+) ([]*change.Change, error) {
+	infos, err := d.FindChangeInfos(ctx, docID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*change.Change
+	for _, info := range infos {
+		c, err := info.ToChange()
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+
+	return changes, nil
+}
+
+// FindChangeInfos returns the information of the changes between two server
+// sequences, including their messages and creation times.
+func (d *DB) FindChangeInfos(
+	ctx context.Context,
+	docID db.ID,
+	from uint64,
+	to uint64,
+) ([]*db.ChangeInfo, error) {
+	if err := validateID(docID); err != nil {
+		return nil, err
+	}
+
+	txn := d.db.Txn(false)
+	defer txn.Abort()
+
+	iterator, err := txn.LowerBound(tblChanges, "id", docID.String(), from)
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	var infos []*db.ChangeInfo
+	for raw := iterator.Next(); raw != nil; raw = iterator.Next() {
+		info := raw.(*db.ChangeInfo)
+		if info.DocID != docID || info.ServerSeq > to {
+			break
+		}
+
+		copied := *info
+		infos = append(infos, &copied)
+	}
+
+	return infos, nil
+}
+
+// UpdateAndFindMinSyncedTicket updates the given serverSeq of the given client
+// and returns the min synced ticket.
+func (d *DB) UpdateAndFindMinSyncedTicket(
+	ctx context.Context,
+	clientInfo *db.ClientInfo,
+	docID db.ID,
+	serverSeq uint64,
+) (*time.Ticket, error) {
+	if err := validateID(docID); err != nil {
+		return nil, err
+	}
+	if err := validateID(clientInfo.ID); err != nil {
+		return nil, err
+	}
+
+	txn := d.db.Txn(true)
+	defer txn.Abort()
+
+	if err := updateSyncedSeq(txn, clientInfo, docID, serverSeq); err != nil {
+		return nil, err
+	}
+
+	ticket, err := findMinSyncedTicket(txn, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	txn.Commit()
+	return ticket, nil
+}
+
+// FindMinSyncedTicket returns the min synced ticket of the given document.
+func (d *DB) FindMinSyncedTicket(ctx context.Context, docID db.ID) (*time.Ticket, error) {
+	if err := validateID(docID); err != nil {
+		return nil, err
+	}
+
+	txn := d.db.Txn(false)
+	defer txn.Abort()
+
+	return findMinSyncedTicket(txn, docID)
+}
+
+// updateSyncedSeq updates the given serverSeq of the given client in the given
+// transaction. If the document is detached, the synced seq is deleted.
+func updateSyncedSeq(
+	txn *memdb.Txn,
+	clientInfo *db.ClientInfo,
+	docID db.ID,
+	serverSeq uint64,
+) error {
+	isAttached, err := clientInfo.IsAttached(docID)
+	if err != nil {
+		return err
+	}
+
+	if isAttached {
+		if err := txn.Insert(tblSyncedSeqs, &db.SyncedSeqInfo{
+			DocID:     docID,
+			ClientID:  clientInfo.ID,
+			ServerSeq: serverSeq,
+		}); err != nil {
+			log.Logger.Error(err)
+			return err
+		}
+		return nil
+	}
+
+	if _, err := txn.DeleteAll(
+		tblSyncedSeqs,
+		"id",
+		docID.String(),
+		clientInfo.ID.String(),
+	); err != nil {
+		log.Logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// findMinSyncedTicket returns the min synced ticket of the given document in
+// the given transaction.
+func findMinSyncedTicket(txn *memdb.Txn, docID db.ID) (*time.Ticket, error) {
+	iterator, err := txn.LowerBound(
+		tblSyncedSeqs,
+		"doc_id_server_seq",
+		docID.String(),
+		uint64(0),
+	)
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	raw := iterator.Next()
+	if raw == nil || raw.(*db.SyncedSeqInfo).DocID != docID {
+		return time.InitialTicket, nil
+	}
+
+	syncedSeqInfo := raw.(*db.SyncedSeqInfo)
+	if syncedSeqInfo.ServerSeq == 0 {
+		return time.InitialTicket, nil
+	}
+
+	return findTicketByServerSeq(txn, docID, syncedSeqInfo.ServerSeq)
+}
+
+// FindLastSnapshotInfo finds the last snapshot of the given document.
+func (d *DB) FindLastSnapshotInfo(
+	ctx context.Context,
+	docID db.ID,
+) (*db.SnapshotInfo, error) {
+	return d.FindClosestSnapshotInfo(ctx, docID, math.MaxUint64)
+}
+
+// FindClosestSnapshotInfo finds the last snapshot of the given document whose
+// server seq is less than or equal to the given server seq.
+func (d *DB) FindClosestSnapshotInfo(
+	ctx context.Context,
+	docID db.ID,
+	serverSeq uint64,
+) (*db.SnapshotInfo, error) {
+	if err := validateID(docID); err != nil {
+		return nil, err
+	}
+
+	txn := d.db.Txn(false)
+	defer txn.Abort()
+
+	iterator, err := txn.ReverseLowerBound(
+		tblSnapshots,
+		"doc_id_server_seq",
+		docID.String(),
+		serverSeq,
+	)
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	raw := iterator.Next()
+	if raw == nil || raw.(*db.SnapshotInfo).DocID != docID {
+		return &db.SnapshotInfo{}, nil
+	}
+
+	snapshotInfo := *raw.(*db.SnapshotInfo)
+	return &snapshotInfo, nil
+}
+
+// FindDocInfosWithOrphanedChanges finds the documents that have changes
+// stored past their server seq.
+func (d *DB) FindDocInfosWithOrphanedChanges(ctx context.Context) ([]*db.DocInfo, error) {
+	txn := d.db.Txn(false)
+	defer txn.Abort()
+
+	iterator, err := txn.Get(tblDocuments, "id")
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	var docInfos []*db.DocInfo
+	for raw := iterator.Next(); raw != nil; raw = iterator.Next() {
+		docInfo := raw.(*db.DocInfo)
+		orphans, err := findOrphanedChangeInfos(txn, docInfo)
+		if err != nil {
+			return nil, err
+		}
+		if len(orphans) > 0 {
+			docInfos = append(docInfos, docInfo.DeepCopy())
+		}
+	}
+
+	return docInfos, nil
+}
+
+// DeleteOrphanedChangeInfos deletes the changes of the given document stored
+// past the server seq of the document and returns the number of them.
+func (d *DB) DeleteOrphanedChangeInfos(ctx context.Context, docID db.ID) (int, error) {
+	if err := validateID(docID); err != nil {
+		return 0, err
+	}
+
+	txn := d.db.Txn(true)
+	defer txn.Abort()
+
+	raw, err := txn.First(tblDocuments, "id", docID.String())
+	if err != nil {
+		log.Logger.Error(err)
+		return 0, err
+	}
+	if raw == nil {
+		return 0, fmt.Errorf("%s: %w", docID, db.ErrDocumentNotFound)
+	}
+
+	orphans, err := findOrphanedChangeInfos(txn, raw.(*db.DocInfo))
+	if err != nil {
+		return 0, err
+	}
+	for _, orphan := range orphans {
+		if err := txn.Delete(tblChanges, orphan); err != nil {
+			log.Logger.Error(err)
+			return 0, err
+		}
+	}
+
+	txn.Commit()
+	return len(orphans), nil
+}
+
+// findOrphanedChangeInfos returns the changes of the given document stored
+// past the server seq of the document.
+func findOrphanedChangeInfos(txn *memdb.Txn, docInfo *db.DocInfo) ([]*db.ChangeInfo, error) {
+	iterator, err := txn.LowerBound(
+		tblChanges,
+		"id",
+		docInfo.ID.String(),
+		docInfo.ServerSeq+1,
+	)
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	var orphans []*db.ChangeInfo
+	for raw := iterator.Next(); raw != nil; raw = iterator.Next() {
+		info := raw.(*db.ChangeInfo)
+		if info.DocID != docInfo.ID {
+			break
+		}
+		orphans = append(orphans, info)
+	}
+
+	return orphans, nil
+}
+
+// updateClientInfo updates the checkpoint and the status of the given document
+// of the client in the given transaction.
+func updateClientInfo(
+	txn *memdb.Txn,
+	clientInfo *db.ClientInfo,
+	docInfo *db.DocInfo,
+) error {
+	clientDocInfo := clientInfo.Documents[docInfo.ID]
+	attached, err := clientInfo.IsAttached(docInfo.ID)
+	if err != nil {
+		return err
+	}
+
+	raw, err := txn.First(tblClients, "key", clientInfo.Key)
+	if err != nil {
+		log.Logger.Error(err)
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("%s: %w", clientInfo.Key, db.ErrClientNotFound)
+	}
+
+	loaded := raw.(*db.ClientInfo).DeepCopy()
+	if loaded.Documents == nil {
+		loaded.Documents = make(map[db.ID]*db.ClientDocInfo)
+	}
+
+	// NOTE: Like `$max` of MongoDB, the checkpoint of the attached document
+	// only moves forward so that it is not overwritten by a delayed request.
+	loadedDocInfo, ok := loaded.Documents[docInfo.ID]
+	if !attached || !ok {
+		loadedDocInfo = &db.ClientDocInfo{}
+		loaded.Documents[docInfo.ID] = loadedDocInfo
+	}
+	if attached {
+		if clientDocInfo.ServerSeq > loadedDocInfo.ServerSeq {
+			loadedDocInfo.ServerSeq = clientDocInfo.ServerSeq
+		}
+		if clientDocInfo.ClientSeq > loadedDocInfo.ClientSeq {
+			loadedDocInfo.ClientSeq = clientDocInfo.ClientSeq
+		}
+	}
+	loadedDocInfo.Status = clientDocInfo.Status
+	loaded.UpdatedAt = clientInfo.UpdatedAt
+
+	if err := txn.Insert(tblClients, loaded); err != nil {
+		log.Logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func findTicketByServerSeq(
+	txn *memdb.Txn,
+	docID db.ID,
+	serverSeq uint64,
+) (*time.Ticket, error) {
+	raw, err := txn.First(tblChanges, "id", docID.String(), serverSeq)
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("%s: %w", docID.String(), db.ErrDocumentNotFound)
+	}
+
+	changeInfo := raw.(*db.ChangeInfo)
+	actorID, err := time.ActorIDFromHex(changeInfo.Actor.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return time.NewTicket(
+		changeInfo.Lamport,
+		time.MaxDelimiter,
+		actorID,
+	), nil
+}
+
+// newID creates a new ID that has the same size as ObjectID of MongoDB.
+func newID() db.ID {
+	return db.IDFromBytes(xid.New().Bytes())
+}
+
+// validateID validates the given ID like ObjectID of MongoDB does.
+func validateID(id db.ID) error {
+	if len(id.Bytes()) != idSize {
+		return fmt.Errorf("%s: %w", id, db.ErrInvalidID)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import "github.com/hashicorp/go-memdb"
+
+// Below are names of tables that stores Yorkie data. Each table has the same
+// unique indexes as the collection of the same name in MongoDB.
+var (
+	tblClients    = "clients"
+	tblDocuments  = "documents"
+	tblChanges    = "changes"
+	tblSnapshots  = "snapshots"
+	tblSyncedSeqs = "syncedseqs"
+)
+
+var schema = &memdb.DBSchema{
+	Tables: map[string]*memdb.TableSchema{
+		tblClients: {
+			Name: tblClients,
+			Indexes: map[string]*memdb.IndexSchema{
+				"id": {
+					Name:    "id",
+					Unique:  true,
+					Indexer: &memdb.StringFieldIndex{Field: "ID"},
+				},
+				"key": {
+					Name:    "key",
+					Unique:  true,
+					Indexer: &memdb.StringFieldIndex{Field: "Key"},
+				},
+			},
+		},
+		tblDocuments: {
+			Name: tblDocuments,
+			Indexes: map[string]*memdb.IndexSchema{
+				"id": {
+					Name:    "id",
+					Unique:  true,
+					Indexer: &memdb.StringFieldIndex{Field: "ID"},
+				},
+				"key": {
+					Name:    "key",
+					Unique:  true,
+					Indexer: &memdb.StringFieldIndex{Field: "Key"},
+				},
+			},
+		},
+		tblChanges: {
+			Name: tblChanges,
+			Indexes: map[string]*memdb.IndexSchema{
+				"id": {
+					Name:   "id",
+					Unique: true,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "DocID"},
+							&memdb.UintFieldIndex{Field: "ServerSeq"},
+						},
+					},
+				},
+			},
+		},
+		tblSnapshots: {
+			Name: tblSnapshots,
+			Indexes: map[string]*memdb.IndexSchema{
+				"id": {
+					Name:    "id",
+					Unique:  true,
+					Indexer: &memdb.StringFieldIndex{Field: "ID"},
+				},
+				"doc_id_server_seq": {
+					Name:   "doc_id_server_seq",
+					Unique: true,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "DocID"},
+							&memdb.UintFieldIndex{Field: "ServerSeq"},
+						},
+					},
+				},
+			},
+		},
+		tblSyncedSeqs: {
+			Name: tblSyncedSeqs,
+			Indexes: map[string]*memdb.IndexSchema{
+				"id": {
+					Name:   "id",
+					Unique: true,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "DocID"},
+							&memdb.StringFieldIndex{Field: "ClientID"},
+						},
+					},
+				},
+				"doc_id_server_seq": {
+					Name: "doc_id_server_seq",
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "DocID"},
+							&memdb.UintFieldIndex{Field: "ServerSeq"},
+						},
+					},
+				},
+			},
+		},
+	},
+}
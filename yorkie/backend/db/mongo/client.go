@@ -18,7 +18,9 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	gotime "time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -46,6 +48,11 @@ type Config struct {
 type Client struct {
 	config *Config
 	client *mongo.Client
+
+	// supportsTransaction is whether the connected MongoDB supports
+	// multi-document transactions. Only replica sets and sharded clusters
+	// support them.
+	supportsTransaction bool
 }
 
 // Dial creates an instance of Client and dials the given MongoDB.
@@ -78,12 +85,19 @@ func Dial(conf *Config) (*Client, error) {
 		return nil, err
 	}
 
-	log.Logger.Infof("MongoDB connected, URI: %s, DB: %s", conf.ConnectionURI,
-		conf.YorkieDatabase)
+	supportsTransaction, err := checkTransactionSupport(ctx, client)
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	log.Logger.Infof("MongoDB connected, URI: %s, DB: %s, transaction: %t", conf.ConnectionURI,
+		conf.YorkieDatabase, supportsTransaction)
 
 	return &Client{
-		config: conf,
-		client: client,
+		config:              conf,
+		client:              client,
+		supportsTransaction: supportsTransaction,
 	}, nil
 }
 
@@ -295,18 +309,66 @@ func (c *Client) FindDocInfoByKey(
 	return &docInfo, nil
 }
 
-// StoreChangeInfos stores the given changes and doc info.
+// StoreChangeInfos stores the given changes then updates the given docInfo,
+// the checkpoint of the given clientInfo and the synced server seq of the
+// client. If MongoDB supports transactions, they are committed in a single
+// transaction. Otherwise, the changes left past the server seq of the document
+// by a previous failure are deleted first, and the changes sent again by the
+// client are rejected by the unique index of the changes.
 func (c *Client) StoreChangeInfos(
 	ctx context.Context,
+	clientInfo *db.ClientInfo,
+	docInfo *db.DocInfo,
+	initialServerSeq uint64,
+	changes []*change.Change,
+	syncedServerSeq uint64,
+) error {
+	if !c.supportsTransaction {
+		return c.storeChangeInfos(ctx, clientInfo, docInfo, initialServerSeq, changes, syncedServerSeq)
+	}
+
+	return c.client.UseSession(ctx, func(sessCtx mongo.SessionContext) error {
+		_, err := sessCtx.WithTransaction(sessCtx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+			return nil, c.storeChangeInfos(
+				sessCtx,
+				clientInfo,
+				docInfo,
+				initialServerSeq,
+				changes,
+				syncedServerSeq,
+			)
+		})
+		return err
+	})
+}
+
+func (c *Client) storeChangeInfos(
+	ctx context.Context,
+	clientInfo *db.ClientInfo,
 	docInfo *db.DocInfo,
 	initialServerSeq uint64,
 	changes []*change.Change,
+	syncedServerSeq uint64,
 ) error {
 	encodedDocID, err := encodeID(docInfo.ID)
 	if err != nil {
 		return err
 	}
 
+	// NOTE: Changes stored past the server seq of the document are left by a
+	// previous failure before the document was updated. They are never read,
+	// so they are deleted here while the document is locked.
+	if _, err := c.collection(ColChanges).DeleteMany(ctx, bson.M{
+		"doc_id": encodedDocID,
+		"server_seq": bson.M{
+			"$gt": initialServerSeq,
+		},
+	}); err != nil {
+		log.Logger.Error(err)
+		return err
+	}
+
+	now := gotime.Now()
 	var models []mongo.WriteModel
 	for _, cn := range changes {
 		encodedOperations, err := db.EncodeOperations(cn.Operations())
@@ -323,16 +385,18 @@ func (c *Client) StoreChangeInfos(
 			"lamport":    cn.ID().Lamport(),
 			"message":    cn.Message(),
 			"operations": encodedOperations,
+			"created_at": now,
 		}}).SetUpsert(true))
 	}
 
-	// TODO(hackerwins): We need to handle the updates for the two collections
-	// below atomically.
 	if _, err = c.collection(ColChanges).BulkWrite(
 		ctx,
 		models,
 		options.BulkWrite().SetOrdered(true),
 	); err != nil {
+		if isDuplicateKeyError(err) {
+			return c.syncPushedClientSeq(ctx, clientInfo, docInfo, initialServerSeq, changes)
+		}
 		log.Logger.Error(err)
 		return err
 	}
@@ -343,7 +407,7 @@ func (c *Client) StoreChangeInfos(
 	}, bson.M{
 		"$set": bson.M{
 			"server_seq": docInfo.ServerSeq,
-			"updated_at": gotime.Now(),
+			"updated_at": now,
 		},
 	})
 	if err != nil {
@@ -354,7 +418,68 @@ func (c *Client) StoreChangeInfos(
 		return fmt.Errorf("%s: %w", docInfo.ID, db.ErrConflictOnUpdate)
 	}
 
-	return nil
+	if err := c.UpdateClientInfoAfterPushPull(ctx, clientInfo, docInfo); err != nil {
+		return err
+	}
+
+	return c.updateSyncedSeq(ctx, clientInfo, docInfo.ID, syncedServerSeq)
+}
+
+// syncPushedClientSeq is called when some of the given changes were already
+// stored, but the checkpoint of the client was not updated due to a previous
+// failure. It moves the client seq of the checkpoint to the last stored change
+// so that the changes are not pushed again when the client retries.
+func (c *Client) syncPushedClientSeq(
+	ctx context.Context,
+	clientInfo *db.ClientInfo,
+	docInfo *db.DocInfo,
+	initialServerSeq uint64,
+	changes []*change.Change,
+) error {
+	encodedDocID, err := encodeID(docInfo.ID)
+	if err != nil {
+		return err
+	}
+
+	changeInfo := db.ChangeInfo{}
+	result := c.collection(ColChanges).FindOne(ctx, bson.M{
+		"doc_id": encodedDocID,
+		"actor":  encodeActorID(changes[0].ID().Actor()),
+		"client_seq": bson.M{
+			"$gte": changes[0].ClientSeq(),
+			"$lte": changes[len(changes)-1].ClientSeq(),
+		},
+		"server_seq": bson.M{
+			"$lte": initialServerSeq,
+		},
+	}, options.FindOne().SetSort(bson.M{
+		"client_seq": -1,
+	}))
+	if result.Err() != nil {
+		log.Logger.Error(result.Err())
+		return result.Err()
+	}
+	if err := decodeChangeInfo(result, &changeInfo); err != nil {
+		return err
+	}
+
+	cp := clientInfo.Checkpoint(docInfo.ID)
+	if err := clientInfo.UpdateCheckpoint(
+		docInfo.ID,
+		cp.SyncClientSeq(changeInfo.ClientSeq),
+	); err != nil {
+		return err
+	}
+	if err := c.UpdateClientInfoAfterPushPull(ctx, clientInfo, docInfo); err != nil {
+		return err
+	}
+
+	return fmt.Errorf(
+		"%s: client seq %d already stored: %w",
+		docInfo.ID,
+		changeInfo.ClientSeq,
+		db.ErrConflictOnUpdate,
+	)
 }
 
 // CreateSnapshotInfo stores the snapshot of the given document.
@@ -392,19 +517,45 @@ func (c *Client) FindChangeInfosBetweenServerSeqs(
 	from uint64,
 	to uint64,
 ) ([]*change.Change, error) {
-	encodedDocID, err := encodeID(docID)
+	infos, err := c.FindChangeInfos(ctx, docID, from, to)
 	if err != nil {
 		return nil, err
 	}
 
 	var changes []*change.Change
+	for _, info := range infos {
+		c, err := info.ToChange()
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+
+	return changes, nil
+}
+
+// FindChangeInfos returns the information of the changes between two server
+// sequences, including their messages and creation times.
+func (c *Client) FindChangeInfos(
+	ctx context.Context,
+	docID db.ID,
+	from uint64,
+	to uint64,
+) ([]*db.ChangeInfo, error) {
+	encodedDocID, err := encodeID(docID)
+	if err != nil {
+		return nil, err
+	}
+
 	cursor, err := c.collection(ColChanges).Find(ctx, bson.M{
 		"doc_id": encodedDocID,
 		"server_seq": bson.M{
 			"$gte": from,
 			"$lte": to,
 		},
-	}, options.Find())
+	}, options.Find().SetSort(bson.M{
+		"server_seq": 1,
+	}))
 	if err != nil {
 		log.Logger.Error(err)
 		return nil, err
@@ -416,16 +567,13 @@ func (c *Client) FindChangeInfosBetweenServerSeqs(
 		}
 	}()
 
+	var infos []*db.ChangeInfo
 	for cursor.Next(ctx) {
-		var changeInfo db.ChangeInfo
-		if err := decodeChangeInfo(cursor, &changeInfo); err != nil {
-			return nil, err
-		}
-		c, err := changeInfo.ToChange()
-		if err != nil {
+		changeInfo := &db.ChangeInfo{}
+		if err := decodeChangeInfo(cursor, changeInfo); err != nil {
 			return nil, err
 		}
-		changes = append(changes, c)
+		infos = append(infos, changeInfo)
 	}
 
 	if cursor.Err() != nil {
@@ -433,7 +581,7 @@ func (c *Client) FindChangeInfosBetweenServerSeqs(
 		return nil, cursor.Err()
 	}
 
-	return changes, nil
+	return infos, nil
 }
 
 // UpdateAndFindMinSyncedTicket updates the given serverSeq of the given client
@@ -444,44 +592,21 @@ func (c *Client) UpdateAndFindMinSyncedTicket(
 	docID db.ID,
 	serverSeq uint64,
 ) (*time.Ticket, error) {
-	encodedDocID, err := encodeID(docID)
-	if err != nil {
-		return nil, err
-	}
-	encodedClientID, err := encodeID(clientInfo.ID)
-	if err != nil {
+	if err := c.updateSyncedSeq(ctx, clientInfo, docID, serverSeq); err != nil {
 		return nil, err
 	}
 
-	// 01. update synced seq of the given client.
-	isAttached, err := clientInfo.IsAttached(docID)
+	return c.FindMinSyncedTicket(ctx, docID)
+}
+
+// FindMinSyncedTicket returns the min synced ticket of the given document.
+func (c *Client) FindMinSyncedTicket(ctx context.Context, docID db.ID) (*time.Ticket, error) {
+	encodedDocID, err := encodeID(docID)
 	if err != nil {
 		return nil, err
 	}
 
-	if isAttached {
-		if _, err = c.collection(ColSyncedSeqs).UpdateOne(ctx, bson.M{
-			"doc_id":    encodedDocID,
-			"client_id": encodedClientID,
-		}, bson.M{
-			"$set": bson.M{
-				"server_seq": serverSeq,
-			},
-		}, options.Update().SetUpsert(true)); err != nil {
-			log.Logger.Error(err)
-			return nil, err
-		}
-	} else {
-		if _, err = c.collection(ColSyncedSeqs).DeleteOne(ctx, bson.M{
-			"doc_id":    encodedDocID,
-			"client_id": encodedClientID,
-		}, options.Delete()); err != nil {
-			log.Logger.Error(err)
-			return nil, err
-		}
-	}
-
-	// 02. find min synced seq of the given document.
+	// 01. find min synced seq of the given document.
 	syncedSeqInfo := db.SyncedSeqInfo{}
 	result := c.collection(ColSyncedSeqs).FindOne(ctx, bson.M{
 		"doc_id": encodedDocID,
@@ -503,7 +628,7 @@ func (c *Client) UpdateAndFindMinSyncedTicket(
 		return time.InitialTicket, nil
 	}
 
-	// 03. find ticket by seq.
+	// 02. find ticket by seq.
 	// TODO: We need to find a way to not access `changes` collection.
 	ticket, err := c.findTicketByServerSeq(ctx, docID, syncedSeqInfo.ServerSeq)
 	if err != nil {
@@ -517,6 +642,18 @@ func (c *Client) UpdateAndFindMinSyncedTicket(
 func (c *Client) FindLastSnapshotInfo(
 	ctx context.Context,
 	docID db.ID,
+) (*db.SnapshotInfo, error) {
+	// NOTE: MongoDB stores integers as signed 64-bit integers, so
+	// MaxInt64 is used as the largest server seq.
+	return c.FindClosestSnapshotInfo(ctx, docID, math.MaxInt64)
+}
+
+// FindClosestSnapshotInfo finds the last snapshot of the given document whose
+// server seq is less than or equal to the given server seq.
+func (c *Client) FindClosestSnapshotInfo(
+	ctx context.Context,
+	docID db.ID,
+	serverSeq uint64,
 ) (*db.SnapshotInfo, error) {
 	encodedDocID, err := encodeID(docID)
 	if err != nil {
@@ -526,6 +663,9 @@ func (c *Client) FindLastSnapshotInfo(
 	snapshotInfo := &db.SnapshotInfo{}
 	result := c.collection(ColSnapshots).FindOne(ctx, bson.M{
 		"doc_id": encodedDocID,
+		"server_seq": bson.M{
+			"$lte": serverSeq,
+		},
 	}, options.FindOne().SetSort(bson.M{
 		"server_seq": -1,
 	}))
@@ -546,6 +686,142 @@ func (c *Client) FindLastSnapshotInfo(
 	return snapshotInfo, nil
 }
 
+// FindDocInfosWithOrphanedChanges finds the documents that have changes
+// stored past their server seq.
+func (c *Client) FindDocInfosWithOrphanedChanges(ctx context.Context) ([]*db.DocInfo, error) {
+	cursor, err := c.collection(ColDocuments).Find(ctx, bson.M{})
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Logger.Error(err)
+		}
+	}()
+
+	var docInfos []*db.DocInfo
+	for cursor.Next(ctx) {
+		docInfo := &db.DocInfo{}
+		if err := decodeDocInfo(cursor, docInfo); err != nil {
+			return nil, err
+		}
+
+		encodedDocID, err := encodeID(docInfo.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		count, err := c.collection(ColChanges).CountDocuments(ctx, bson.M{
+			"doc_id": encodedDocID,
+			"server_seq": bson.M{
+				"$gt": docInfo.ServerSeq,
+			},
+		}, options.Count().SetLimit(1))
+		if err != nil {
+			log.Logger.Error(err)
+			return nil, err
+		}
+
+		if count > 0 {
+			docInfos = append(docInfos, docInfo)
+		}
+	}
+
+	if cursor.Err() != nil {
+		log.Logger.Error(cursor.Err())
+		return nil, cursor.Err()
+	}
+
+	return docInfos, nil
+}
+
+// DeleteOrphanedChangeInfos deletes the changes of the given document stored
+// past the server seq of the document and returns the number of them.
+func (c *Client) DeleteOrphanedChangeInfos(ctx context.Context, docID db.ID) (int, error) {
+	encodedDocID, err := encodeID(docID)
+	if err != nil {
+		return 0, err
+	}
+
+	docInfo := &db.DocInfo{}
+	result := c.collection(ColDocuments).FindOne(ctx, bson.M{
+		"_id": encodedDocID,
+	})
+	if result.Err() == mongo.ErrNoDocuments {
+		return 0, fmt.Errorf("%s: %w", docID, db.ErrDocumentNotFound)
+	}
+	if result.Err() != nil {
+		log.Logger.Error(result.Err())
+		return 0, result.Err()
+	}
+	if err := decodeDocInfo(result, docInfo); err != nil {
+		return 0, err
+	}
+
+	res, err := c.collection(ColChanges).DeleteMany(ctx, bson.M{
+		"doc_id": encodedDocID,
+		"server_seq": bson.M{
+			"$gt": docInfo.ServerSeq,
+		},
+	})
+	if err != nil {
+		log.Logger.Error(err)
+		return 0, err
+	}
+
+	return int(res.DeletedCount), nil
+}
+
+// updateSyncedSeq updates the given serverSeq of the given client. If the
+// document is detached, the synced seq is deleted.
+func (c *Client) updateSyncedSeq(
+	ctx context.Context,
+	clientInfo *db.ClientInfo,
+	docID db.ID,
+	serverSeq uint64,
+) error {
+	encodedDocID, err := encodeID(docID)
+	if err != nil {
+		return err
+	}
+	encodedClientID, err := encodeID(clientInfo.ID)
+	if err != nil {
+		return err
+	}
+
+	isAttached, err := clientInfo.IsAttached(docID)
+	if err != nil {
+		return err
+	}
+
+	if !isAttached {
+		if _, err = c.collection(ColSyncedSeqs).DeleteOne(ctx, bson.M{
+			"doc_id":    encodedDocID,
+			"client_id": encodedClientID,
+		}, options.Delete()); err != nil {
+			log.Logger.Error(err)
+			return err
+		}
+		return nil
+	}
+
+	if _, err = c.collection(ColSyncedSeqs).UpdateOne(ctx, bson.M{
+		"doc_id":    encodedDocID,
+		"client_id": encodedClientID,
+	}, bson.M{
+		"$set": bson.M{
+			"server_seq": serverSeq,
+		},
+	}, options.Update().SetUpsert(true)); err != nil {
+		log.Logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
 func (c *Client) findTicketByServerSeq(
 	ctx context.Context,
 	docID db.ID,
@@ -594,3 +870,36 @@ func (c *Client) collection(
 		Database(c.config.YorkieDatabase).
 		Collection(name, opts...)
 }
+
+// isDuplicateKeyError returns whether the given error is caused by a
+// violation of a unique index.
+func isDuplicateKeyError(err error) bool {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) {
+		return false
+	}
+
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Code == duplicateKeyErrorCode {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTransactionSupport returns whether the connected MongoDB supports
+// multi-document transactions.
+func checkTransactionSupport(ctx context.Context, client *mongo.Client) (bool, error) {
+	reply := struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}{}
+	if err := client.Database("admin").RunCommand(
+		ctx,
+		bson.D{{Key: "isMaster", Value: 1}},
+	).Decode(&reply); err != nil {
+		return false, err
+	}
+
+	return reply.SetName != "" || reply.Msg == "isdbgrid", nil
+}
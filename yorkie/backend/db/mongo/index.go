@@ -18,7 +18,10 @@ package mongo
 
 import (
 	"context"
+	"errors"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/x/bsonx"
@@ -26,6 +29,10 @@ import (
 	"github.com/yorkie-team/yorkie/internal/log"
 )
 
+// duplicateKeyErrorCode is the error code of MongoDB returned when a write
+// violates a unique index.
+const duplicateKeyErrorCode = 11000
+
 // Below are names and indexes information of collections that stores Yorkie data.
 var (
 	ColClients     = "clients"
@@ -47,6 +54,18 @@ var (
 			{Key: "server_seq", Value: bsonx.Int32(1)},
 		},
 		Options: options.Index().SetUnique(true),
+	}, {
+		// NOTE: A change sent again by the client violates this index. The
+		// lamport is included because a new Document attached by the same
+		// client starts its client seq again, and changes with the same ID
+		// would already create elements with the same tickets.
+		Keys: bsonx.Doc{
+			{Key: "doc_id", Value: bsonx.Int32(1)},
+			{Key: "actor", Value: bsonx.Int32(1)},
+			{Key: "client_seq", Value: bsonx.Int32(1)},
+			{Key: "lamport", Value: bsonx.Int32(1)},
+		},
+		Options: options.Index().SetUnique(true),
 	}}
 
 	ColSnapshots = "snapshots"
@@ -90,11 +109,7 @@ func ensureIndexes(ctx context.Context, db *mongo.Database) error {
 		return err
 	}
 
-	if _, err := db.Collection(ColChanges).Indexes().CreateMany(
-		ctx,
-		idxChanges,
-	); err != nil {
-		log.Logger.Error(err)
+	if err := ensureChangeIndexes(ctx, db); err != nil {
 		return err
 	}
 
@@ -116,3 +131,94 @@ func ensureIndexes(ctx context.Context, db *mongo.Database) error {
 
 	return nil
 }
+
+// ensureChangeIndexes creates the indexes of the changes. Earlier versions
+// could store a change sent again by the client twice, which violates the
+// unique index of the change ID. In that case, the duplicates are removed
+// before creating the indexes again.
+func ensureChangeIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(ColChanges).Indexes().CreateMany(ctx, idxChanges)
+	if err == nil {
+		return nil
+	}
+
+	var cmdErr mongo.CommandError
+	if !errors.As(err, &cmdErr) || cmdErr.Code != duplicateKeyErrorCode {
+		log.Logger.Error(err)
+		return err
+	}
+
+	if err := removeDuplicatedChanges(ctx, db); err != nil {
+		return err
+	}
+
+	if _, err := db.Collection(ColChanges).Indexes().CreateMany(ctx, idxChanges); err != nil {
+		log.Logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// removeDuplicatedChanges deletes the changes stored more than once with the
+// same ID. The change with the lowest server seq is kept, because the later
+// ones were stored by retries of the client.
+func removeDuplicatedChanges(ctx context.Context, db *mongo.Database) error {
+	cursor, err := db.Collection(ColChanges).Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{
+			{Key: "doc_id", Value: 1},
+			{Key: "server_seq", Value: 1},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "doc_id", Value: "$doc_id"},
+				{Key: "actor", Value: "$actor"},
+				{Key: "client_seq", Value: "$client_seq"},
+				{Key: "lamport", Value: "$lamport"},
+			}},
+			{Key: "ids", Value: bson.D{{Key: "$push", Value: "$_id"}}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		{{Key: "$match", Value: bson.D{
+			{Key: "count", Value: bson.D{{Key: "$gt", Value: 1}}},
+		}}},
+	}, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		log.Logger.Error(err)
+		return err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			log.Logger.Error(err)
+		}
+	}()
+
+	deleted := int64(0)
+	for cursor.Next(ctx) {
+		var duplicated struct {
+			IDs []primitive.ObjectID `bson:"ids"`
+		}
+		if err := cursor.Decode(&duplicated); err != nil {
+			log.Logger.Error(err)
+			return err
+		}
+
+		res, err := db.Collection(ColChanges).DeleteMany(ctx, bson.M{
+			"_id": bson.M{"$in": duplicated.IDs[1:]},
+		})
+		if err != nil {
+			log.Logger.Error(err)
+			return err
+		}
+		deleted += res.DeletedCount
+	}
+
+	if cursor.Err() != nil {
+		log.Logger.Error(cursor.Err())
+		return cursor.Err()
+	}
+
+	log.Logger.Infof("MIGRATION: deleted %d duplicated changes", deleted)
+	return nil
+}
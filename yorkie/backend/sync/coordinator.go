@@ -21,30 +21,56 @@ import (
 	"errors"
 	gotime "time"
 
+	"github.com/yorkie-team/yorkie/api"
+	"github.com/yorkie-team/yorkie/pkg/document/key"
 	"github.com/yorkie-team/yorkie/pkg/document/time"
 )
 
 var (
 	// ErrEmptyTopics is returned when the given topic is empty.
 	ErrEmptyTopics = errors.New("empty topics")
+
+	// ErrUnauthenticatedAgent is returned when a request to the Cluster
+	// service is not sent by an agent of the cluster.
+	ErrUnauthenticatedAgent = errors.New("unauthenticated agent")
 )
 
+// ClusterSecretKey is the key of the metadata that carries the secret shared
+// by the agents of the cluster.
+const ClusterSecretKey = "x-yorkie-cluster-secret"
+
 // AgentInfo represents the information of the Agent.
 type AgentInfo struct {
-	ID        string      `json:"id"`
-	Hostname  string      `json:"hostname"`
-	RPCAddr   string      `json:"rpc_addr"`
-	UpdatedAt gotime.Time `json:"updated_at"`
+	ID          string      `json:"id"`
+	Hostname    string      `json:"hostname"`
+	ClusterAddr string      `json:"cluster_addr"`
+	UpdatedAt   gotime.Time `json:"updated_at"`
 }
 
-// Coordinator provides synchronization functions such as locks and event Pub/Sub.
+// Coordinator provides synchronization functions such as locks, event Pub/Sub
+// and rate limits.
 type Coordinator interface {
 	LockerMap
 	PubSub
+	RateLimiter
 
 	// Members returns the members of this cluster.
 	Members() map[string]*AgentInfo
 
+	// Owner returns the member that owns the given document key and whether
+	// the member is this agent. Requests that modify the document should be
+	// processed by the owner so that the lock of the document is not
+	// contended across agents.
+	Owner(docKey *key.Key) (*AgentInfo, bool)
+
+	// ClusterClient returns the client of the Cluster service of the given
+	// member.
+	ClusterClient(member *AgentInfo) (api.ClusterClient, error)
+
+	// AuthenticateAgent verifies that the request of the given context is
+	// sent to the Cluster service by another agent of this cluster.
+	AuthenticateAgent(ctx context.Context) error
+
 	// PublishToLocal publishes the given event.
 	PublishToLocal(ctx context.Context, publisherID *time.ActorID, event DocEvent)
 
@@ -18,6 +18,7 @@ package etcd
 
 import (
 	"context"
+	"fmt"
 	gosync "sync"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/yorkie-team/yorkie/internal/log"
 	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
 	"github.com/yorkie-team/yorkie/yorkie/backend/sync/memory"
+	"github.com/yorkie-team/yorkie/yorkie/metrics"
 )
 
 const (
@@ -36,6 +38,25 @@ const (
 
 	// DefaultLockLeaseTimeSec is the default lease time of lock.
 	DefaultLockLeaseTimeSec = 30
+
+	// DefaultBroadcastTimeoutSec is the default deadline of a BroadcastEvent
+	// call to a member.
+	DefaultBroadcastTimeoutSec = 3
+
+	// DefaultBroadcastMaxRetries is the default number of retries of a
+	// BroadcastEvent call to a member.
+	DefaultBroadcastMaxRetries = 3
+)
+
+const (
+	// BroadcastPropagation propagates document events by calling
+	// BroadcastEvent of each member. It is used by default.
+	BroadcastPropagation = "broadcast"
+
+	// WatchPropagation propagates document events by putting them under the
+	// events prefix of etcd with a short lease, and each agent watches the
+	// prefix to receive them in order.
+	WatchPropagation = "watch"
 )
 
 // Config is the configuration for creating a Client instance.
@@ -46,12 +67,56 @@ type Config struct {
 	Password       string        `json:"Password"`
 
 	LockLeaseTimeSec int `json:"LockLeaseTimeSec"`
+
+	// EventPropagation is the mode of propagating document events to other
+	// agents: "broadcast" or "watch". If it is empty, "broadcast" is used.
+	EventPropagation string `json:"EventPropagation"`
+
+	// BroadcastTimeoutSec is the deadline of a BroadcastEvent call to a member.
+	BroadcastTimeoutSec time.Duration `json:"BroadcastTimeoutSec"`
+
+	// BroadcastMaxRetries is the number of retries of a BroadcastEvent call
+	// to a member before the event is given up.
+	BroadcastMaxRetries int `json:"BroadcastMaxRetries"`
+
+	// ClusterSecret is the secret shared by the agents of the cluster. It is
+	// sent with the calls to the Cluster service of other agents, and the
+	// calls without it are rejected. If it is empty, the agents are not
+	// authenticated, so the cluster port should not be exposed.
+	ClusterSecret string `json:"ClusterSecret"`
+}
+
+// Validate validates this config.
+func (c *Config) Validate() error {
+	if c.EventPropagation != "" &&
+		c.EventPropagation != BroadcastPropagation &&
+		c.EventPropagation != WatchPropagation {
+		return fmt.Errorf("not supported event propagation: %s", c.EventPropagation)
+	}
+
+	return nil
 }
 
-// clusterClientInfo represents a cluster client and its connection.
+// clusterClientInfo represents a cluster client and its connection. Events
+// to the member are queued and sent in order by a goroutine of the member.
 type clusterClientInfo struct {
-	client api.ClusterClient
-	conn   *grpc.ClientConn
+	memberID string
+	client   api.ClusterClient
+	conn     *grpc.ClientConn
+
+	events  chan *api.BroadcastEventRequest
+	closing chan struct{}
+
+	// evicted is whether the member had been evicted before this client was
+	// created. The eviction is cleared when an event is sent successfully.
+	evicted bool
+}
+
+// evictedMember represents a member evicted after consecutive failures. Events
+// are not sent to the member until the deadline passes.
+type evictedMember struct {
+	until   time.Time
+	backoff time.Duration
 }
 
 // Client is a client that connects to ETCD.
@@ -61,25 +126,50 @@ type Client struct {
 
 	client *clientv3.Client
 
-	pubSub *memory.PubSub
+	pubSub  *memory.PubSub
+	metrics metrics.Metrics
 
 	memberMapMu        *gosync.RWMutex
 	memberMap          map[string]*sync.AgentInfo
+	hashRing           *sync.HashRing
 	clusterClientMapMu *gosync.RWMutex
 	clusterClientMap   map[string]*clusterClientInfo
+	evictedMembers     map[string]*evictedMember
+
+	eventLeaseMu      *gosync.Mutex
+	eventLeaseID      clientv3.LeaseID
+	eventLeaseRenewAt time.Time
+
+	rateLimitLeaseMu      *gosync.Mutex
+	rateLimitLeaseID      clientv3.LeaseID
+	rateLimitLeaseRenewAt time.Time
 
 	ctx        context.Context
 	cancelFunc context.CancelFunc
 }
 
 // newClient creates a new instance of Client.
-func newClient(conf *Config, agentInfo *sync.AgentInfo) *Client {
+func newClient(
+	conf *Config,
+	agentInfo *sync.AgentInfo,
+	pubSubConf *sync.PubSubConfig,
+	met metrics.Metrics,
+) *Client {
 	if conf.DialTimeoutSec == 0 {
 		conf.DialTimeoutSec = DefaultDialTimeoutSec
 	}
 	if conf.LockLeaseTimeSec == 0 {
 		conf.LockLeaseTimeSec = DefaultLockLeaseTimeSec
 	}
+	if conf.EventPropagation == "" {
+		conf.EventPropagation = BroadcastPropagation
+	}
+	if conf.BroadcastTimeoutSec == 0 {
+		conf.BroadcastTimeoutSec = DefaultBroadcastTimeoutSec
+	}
+	if conf.BroadcastMaxRetries == 0 {
+		conf.BroadcastMaxRetries = DefaultBroadcastMaxRetries
+	}
 
 	ctx, cancelFunc := context.WithCancel(context.Background())
 
@@ -87,12 +177,18 @@ func newClient(conf *Config, agentInfo *sync.AgentInfo) *Client {
 		config:    conf,
 		agentInfo: agentInfo,
 
-		pubSub: memory.NewPubSub(),
+		pubSub:  memory.NewPubSub(pubSubConf, met),
+		metrics: met,
 
 		memberMapMu:        &gosync.RWMutex{},
 		memberMap:          make(map[string]*sync.AgentInfo),
+		hashRing:           sync.NewHashRing(sync.DefaultHashRingReplicas, nil),
 		clusterClientMapMu: &gosync.RWMutex{},
 		clusterClientMap:   make(map[string]*clusterClientInfo),
+		evictedMembers:     make(map[string]*evictedMember),
+
+		eventLeaseMu:     &gosync.Mutex{},
+		rateLimitLeaseMu: &gosync.Mutex{},
 
 		ctx:        ctx,
 		cancelFunc: cancelFunc,
@@ -100,8 +196,13 @@ func newClient(conf *Config, agentInfo *sync.AgentInfo) *Client {
 }
 
 // Dial creates a new instance of Client and dials the given ETCD.
-func Dial(conf *Config, agentInfo *sync.AgentInfo) (*Client, error) {
-	c := newClient(conf, agentInfo)
+func Dial(
+	conf *Config,
+	agentInfo *sync.AgentInfo,
+	pubSubConf *sync.PubSubConfig,
+	met metrics.Metrics,
+) (*Client, error) {
+	c := newClient(conf, agentInfo, pubSubConf, met)
 
 	if err := c.Dial(); err != nil {
 		return nil, err
@@ -18,11 +18,13 @@ package etcd_test
 
 import (
 	"context"
-	"sync"
+	gosync "sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
 
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
 	"github.com/yorkie-team/yorkie/yorkie/backend/sync/etcd"
 )
 
@@ -30,17 +32,45 @@ func TestClient(t *testing.T) {
 	t.Run("dial timeout test", func(t *testing.T) {
 		var err error
 
-		wg := sync.WaitGroup{}
+		wg := gosync.WaitGroup{}
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			_, err = etcd.Dial(&etcd.Config{
 				Endpoints:      []string{"invalid-endpoint:2379"},
 				DialTimeoutSec: 1,
-			}, nil)
+			}, nil, nil, nil)
 		}()
 		wg.Wait()
 
 		assert.ErrorIs(t, context.DeadlineExceeded, err)
 	})
+
+	t.Run("config validation test", func(t *testing.T) {
+		conf := &etcd.Config{EventPropagation: "InvalidPropagation"}
+		assert.Error(t, conf.Validate())
+
+		conf2 := &etcd.Config{EventPropagation: etcd.WatchPropagation}
+		assert.NoError(t, conf2.Validate())
+
+		conf3 := &etcd.Config{}
+		assert.NoError(t, conf3.Validate())
+	})
+	t.Run("authenticate agent test", func(t *testing.T) {
+		cli := etcd.NewClientWithoutDial(&etcd.Config{ClusterSecret: "secret"}, nil)
+
+		assert.ErrorIs(t, cli.AuthenticateAgent(context.Background()), sync.ErrUnauthenticatedAgent)
+		assert.ErrorIs(t, cli.AuthenticateAgent(metadata.NewIncomingContext(
+			context.Background(),
+			metadata.Pairs(sync.ClusterSecretKey, "invalid"),
+		)), sync.ErrUnauthenticatedAgent)
+		assert.NoError(t, cli.AuthenticateAgent(metadata.NewIncomingContext(
+			context.Background(),
+			metadata.Pairs(sync.ClusterSecretKey, "secret"),
+		)))
+
+		// every request is allowed if the secret is not configured.
+		cli = etcd.NewClientWithoutDial(&etcd.Config{}, nil)
+		assert.NoError(t, cli.AuthenticateAgent(context.Background()))
+	})
 }
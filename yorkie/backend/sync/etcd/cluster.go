@@ -0,0 +1,70 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+)
+
+// clusterCredentials attaches the cluster secret to the calls to the Cluster
+// service of other agents.
+type clusterCredentials struct {
+	secret string
+}
+
+// GetRequestMetadata returns the metadata that carries the cluster secret.
+func (c *clusterCredentials) GetRequestMetadata(
+	ctx context.Context,
+	uri ...string,
+) (map[string]string, error) {
+	return map[string]string{sync.ClusterSecretKey: c.secret}, nil
+}
+
+// RequireTransportSecurity returns false, since the agents are connected
+// without TLS in the internal network.
+func (c *clusterCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// AuthenticateAgent verifies that the request of the given context carries
+// the cluster secret. If the secret is not configured, every request is
+// allowed.
+func (c *Client) AuthenticateAgent(ctx context.Context) error {
+	if c.config.ClusterSecret == "" {
+		return nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return sync.ErrUnauthenticatedAgent
+	}
+
+	values := md.Get(sync.ClusterSecretKey)
+	if len(values) != 1 || subtle.ConstantTimeCompare(
+		[]byte(values[0]),
+		[]byte(c.config.ClusterSecret),
+	) != 1 {
+		return sync.ErrUnauthenticatedAgent
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+	"go.etcd.io/etcd/clientv3"
+	"go.etcd.io/etcd/mvcc/mvccpb"
+
+	"github.com/yorkie-team/yorkie/api"
+	"github.com/yorkie-team/yorkie/api/converter"
+	"github.com/yorkie-team/yorkie/internal/log"
+	pkgtime "github.com/yorkie-team/yorkie/pkg/document/time"
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+)
+
+const (
+	eventsPath    = "/events"
+	eventValueTTL = 10 * time.Second
+
+	watchRetryInterval = time.Second
+)
+
+// putEvent puts the given event under the events prefix so that the other
+// agents watching the prefix receive it. The event is encoded in the same
+// format as the request of BroadcastEvent.
+func (c *Client) putEvent(
+	ctx context.Context,
+	publisherID *pkgtime.ActorID,
+	event sync.DocEvent,
+) error {
+	request, err := toBroadcastEventRequest(publisherID, event)
+	if err != nil {
+		return err
+	}
+
+	bytes, err := request.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshal event of %s: %w", publisherID.String(), err)
+	}
+
+	leaseID, err := c.eventLease(ctx)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("%s/%s/%s", eventsPath, c.agentInfo.ID, xid.New().String())
+	if _, err := c.client.Put(ctx, key, string(bytes), clientv3.WithLease(leaseID)); err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// eventLease returns the lease for the events of this agent. The lease is
+// shared by the events put in half of its TTL, so that each event lives at
+// least half of the TTL without granting a lease for every event.
+func (c *Client) eventLease(ctx context.Context) (clientv3.LeaseID, error) {
+	c.eventLeaseMu.Lock()
+	defer c.eventLeaseMu.Unlock()
+
+	now := time.Now()
+	if c.eventLeaseID != clientv3.NoLease && now.Before(c.eventLeaseRenewAt) {
+		return c.eventLeaseID, nil
+	}
+
+	grantResponse, err := c.client.Grant(ctx, int64(eventValueTTL.Seconds()))
+	if err != nil {
+		return clientv3.NoLease, fmt.Errorf("grant events of %s: %w", c.agentInfo.ID, err)
+	}
+
+	c.eventLeaseID = grantResponse.ID
+	c.eventLeaseRenewAt = now.Add(eventValueTTL / 2)
+	return c.eventLeaseID, nil
+}
+
+// watchEvents watches the events put by other agents and publishes them to
+// the local subscribers in the order of their revisions.
+func (c *Client) watchEvents() {
+	myPrefix := fmt.Sprintf("%s/%s/", eventsPath, c.agentInfo.ID)
+
+	var rev int64
+	for {
+		opts := []clientv3.OpOption{clientv3.WithPrefix()}
+		if rev > 0 {
+			opts = append(opts, clientv3.WithRev(rev))
+		}
+
+		for watchResponse := range c.client.Watch(c.ctx, eventsPath+"/", opts...) {
+			if watchResponse.CompactRevision > 0 {
+				log.Logger.Warnf("events compacted at revision %d", watchResponse.CompactRevision)
+				rev = watchResponse.CompactRevision
+			}
+			if err := watchResponse.Err(); err != nil {
+				log.Logger.Error(err)
+				continue
+			}
+
+			for _, event := range watchResponse.Events {
+				rev = event.Kv.ModRevision + 1
+				if event.Type != mvccpb.PUT || strings.HasPrefix(string(event.Kv.Key), myPrefix) {
+					continue
+				}
+
+				if err := c.publishEventToLocal(event.Kv.Value); err != nil {
+					log.Logger.Error(err)
+				}
+			}
+		}
+
+		// NOTE: If the watch channel is closed unexpectedly, we
+		// watch again from the next revision not to miss events.
+		select {
+		case <-time.After(watchRetryInterval):
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// publishEventToLocal decodes the given value of an event and publishes it to
+// the local subscribers.
+func (c *Client) publishEventToLocal(value []byte) error {
+	request := &api.BroadcastEventRequest{}
+	if err := request.Unmarshal(value); err != nil {
+		return fmt.Errorf("unmarshal event: %w", err)
+	}
+
+	publisherID, err := pkgtime.ActorIDFromBytes(request.PublisherId)
+	if err != nil {
+		return err
+	}
+
+	docEvent, err := converter.FromDocEvent(request.Event)
+	if err != nil {
+		return err
+	}
+
+	c.PublishToLocal(c.ctx, publisherID, *docEvent)
+	return nil
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+)
+
+// NewClientWithoutDial creates an instance of Client that is not connected to
+// etcd for testing.
+func NewClientWithoutDial(conf *Config, agentInfo *sync.AgentInfo) *Client {
+	return newClient(conf, agentInfo, nil, nil)
+}
+
+// AddMember adds the given member to the member map for testing.
+func (c *Client) AddMember(member sync.AgentInfo) {
+	c.setAgentInfo(agentsPath+"/"+member.ID, member)
+}
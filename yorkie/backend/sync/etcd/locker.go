@@ -18,58 +18,161 @@ package etcd
 
 import (
 	"context"
+	"fmt"
+	"math"
 
+	"go.etcd.io/etcd/clientv3"
 	"go.etcd.io/etcd/clientv3/concurrency"
 
 	"github.com/yorkie-team/yorkie/internal/log"
 	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
 )
 
-// NewLocker creates locker of the given key.
+// NewLocker creates locker of the given key. The lock is bound to a lease
+// whose TTL is LockLeaseTimeSec unless it is given by the options. The lease
+// is kept alive while this agent is alive, so the lock is released
+// automatically only when the holder crashes.
 func (c *Client) NewLocker(
 	ctx context.Context,
 	key sync.Key,
+	opts ...sync.LockerOption,
 ) (sync.Locker, error) {
-	session, err := concurrency.NewSession(
-		c.client,
-		concurrency.WithContext(ctx),
-		concurrency.WithTTL(c.config.LockLeaseTimeSec),
-	)
-	if err != nil {
-		log.Logger.Error(err)
-		return nil, err
+	ttl := c.config.LockLeaseTimeSec
+	if options := sync.NewLockerOptions(opts...); options.TTL > 0 {
+		ttl = int(math.Ceil(options.TTL.Seconds()))
 	}
 
 	return &internalLocker{
-		session,
-		concurrency.NewMutex(session, key.String()),
+		client: c.client,
+		key:    key.String(),
+		ttl:    ttl,
 	}, nil
 }
 
+// internalLocker is a locker based on concurrency.Mutex. A session is created
+// whenever it tries to acquire the lock and closed when the lock is released
+// or not acquired, so that the lease is not leaked.
 type internalLocker struct {
+	client *clientv3.Client
+	key    string
+	ttl    int
+
 	session *concurrency.Session
 	mu      *concurrency.Mutex
+	myKey   string
+}
+
+// newSession creates a session for acquiring the lock.
+func (il *internalLocker) newSession(ctx context.Context) error {
+	session, err := concurrency.NewSession(
+		il.client,
+		concurrency.WithContext(ctx),
+		concurrency.WithTTL(il.ttl),
+	)
+	if err != nil {
+		log.Logger.Error(err)
+		return err
+	}
+
+	il.session = session
+	il.mu = concurrency.NewMutex(session, il.key)
+	return nil
+}
+
+// closeSession closes the session of this locker.
+func (il *internalLocker) closeSession() error {
+	session := il.session
+	il.session = nil
+	il.mu = nil
+
+	if err := session.Close(); err != nil {
+		log.Logger.Error(err)
+		return err
+	}
+
+	return nil
 }
 
 // Lock locks a mutex.
 func (il *internalLocker) Lock(ctx context.Context) error {
+	if err := il.newSession(ctx); err != nil {
+		return err
+	}
+
 	if err := il.mu.Lock(ctx); err != nil {
 		log.Logger.Error(err)
+		_ = il.closeSession()
 		return err
 	}
+	il.myKey = il.mu.Key()
 
 	return nil
 }
 
+// TryLock tries to lock the mutex without blocking.
+// NOTE: concurrency.Mutex of the etcd version we use does not
+// provide TryLock, so it puts the key of this session like Mutex.Lock does,
+// and deletes it instead of waiting if another session holds the lock.
+func (il *internalLocker) TryLock(ctx context.Context) (bool, error) {
+	if err := il.newSession(ctx); err != nil {
+		return false, err
+	}
+
+	prefix := il.key + "/"
+	myKey := fmt.Sprintf("%s%x", prefix, il.session.Lease())
+
+	cmp := clientv3.Compare(clientv3.CreateRevision(myKey), "=", 0)
+	put := clientv3.OpPut(myKey, "", clientv3.WithLease(il.session.Lease()))
+	get := clientv3.OpGet(myKey)
+	getOwner := clientv3.OpGet(prefix, clientv3.WithFirstCreate()...)
+	resp, err := il.client.Txn(ctx).If(cmp).Then(put, getOwner).Else(get, getOwner).Commit()
+	if err != nil {
+		log.Logger.Error(err)
+		_ = il.closeSession()
+		return false, err
+	}
+
+	myRev := resp.Header.Revision
+	if !resp.Succeeded {
+		myRev = resp.Responses[0].GetResponseRange().Kvs[0].CreateRevision
+	}
+	ownerKey := resp.Responses[1].GetResponseRange().Kvs
+	if len(ownerKey) == 0 || ownerKey[0].CreateRevision == myRev {
+		il.myKey = myKey
+		return true, nil
+	}
+
+	// NOTE: Closing the session revokes its lease, and the key
+	// put above is deleted with it.
+	if err := il.closeSession(); err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
 // Unlock unlocks the mutex.
 func (il *internalLocker) Unlock(ctx context.Context) error {
-	if err := il.mu.Unlock(ctx); err != nil {
-		log.Logger.Error(err)
-		return err
+	if il.session == nil {
+		log.Logger.Error(sync.ErrLockNotHeld)
+		return sync.ErrLockNotHeld
+	}
+
+	select {
+	case <-il.session.Done():
+		// NOTE: The lease has expired and the key has already
+		// been deleted with it, so the lock might be held by another.
+		il.session = nil
+		il.mu = nil
+		log.Logger.Error(sync.ErrLockExpired)
+		return sync.ErrLockExpired
+	default:
 	}
-	if err := il.session.Close(); err != nil {
+
+	if _, err := il.client.Delete(ctx, il.myKey); err != nil {
+		log.Logger.Error(err)
 		return err
 	}
 
-	return nil
+	return il.closeSession()
 }
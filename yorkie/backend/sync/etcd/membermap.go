@@ -20,12 +20,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"go.etcd.io/etcd/clientv3"
 	"go.etcd.io/etcd/mvcc/mvccpb"
 
 	"github.com/yorkie-team/yorkie/internal/log"
+	"github.com/yorkie-team/yorkie/pkg/document/key"
 	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
 )
 
@@ -47,6 +49,9 @@ func (c *Client) Initialize() error {
 
 	go c.syncAgents()
 	go c.putAgentPeriodically()
+	if c.config.EventPropagation == WatchPropagation {
+		go c.watchEvents()
+	}
 
 	return nil
 }
@@ -59,10 +64,10 @@ func (c *Client) Members() map[string]*sync.AgentInfo {
 	memberMap := make(map[string]*sync.AgentInfo)
 	for _, member := range c.memberMap {
 		memberMap[member.ID] = &sync.AgentInfo{
-			ID:        member.ID,
-			Hostname:  member.Hostname,
-			RPCAddr:   member.RPCAddr,
-			UpdatedAt: member.UpdatedAt,
+			ID:          member.ID,
+			Hostname:    member.Hostname,
+			ClusterAddr: member.ClusterAddr,
+			UpdatedAt:   member.UpdatedAt,
 		}
 	}
 
@@ -152,8 +157,11 @@ func (c *Client) syncAgents() {
 					}
 					c.setAgentInfo(string(event.Kv.Key), info)
 				case mvccpb.DELETE:
-					c.removeAgentInfo(string(event.Kv.Key))
-					c.removeClusterClient(string(event.Kv.Key))
+					key := string(event.Kv.Key)
+					id := strings.TrimPrefix(key, agentsPath+"/")
+					c.removeAgentInfo(key)
+					c.removeClusterClient(id)
+					c.clearEviction(id)
 				}
 			}
 		case <-c.ctx.Done():
@@ -162,12 +170,33 @@ func (c *Client) syncAgents() {
 	}
 }
 
+// Owner returns the member that owns the given document key in the hash
+// ring of the members and whether the member is this agent.
+func (c *Client) Owner(docKey *key.Key) (*sync.AgentInfo, bool) {
+	c.memberMapMu.RLock()
+	defer c.memberMapMu.RUnlock()
+
+	owner := c.hashRing.Owner(docKey.BSONKey())
+	if owner == nil || owner.ID == c.agentInfo.ID {
+		return c.agentInfo, true
+	}
+
+	return owner, false
+}
+
 // setAgentInfo sets the given agentInfo to the local member map.
 func (c *Client) setAgentInfo(key string, value sync.AgentInfo) {
 	c.memberMapMu.Lock()
 	defer c.memberMapMu.Unlock()
 
+	_, exists := c.memberMap[key]
 	c.memberMap[key] = &value
+
+	// NOTE: The agent is put periodically, so the hash ring is
+	// rebuilt only when a new member joins.
+	if !exists {
+		c.rebuildHashRing()
+	}
 }
 
 // removeAgentInfo removes the given agentInfo from the local member map.
@@ -175,5 +204,18 @@ func (c *Client) removeAgentInfo(id string) {
 	c.memberMapMu.Lock()
 	defer c.memberMapMu.Unlock()
 
+	if _, ok := c.memberMap[id]; !ok {
+		return
+	}
+
 	delete(c.memberMap, id)
+	c.rebuildHashRing()
+}
+
+// rebuildHashRing rebuilds the hash ring with the members so that the
+// ownership of documents is rebalanced. It should be called with the mutex
+// of the member map held.
+func (c *Client) rebuildHashRing() {
+	c.hashRing = sync.NewHashRing(sync.DefaultHashRingReplicas, c.memberMap)
+	log.Logger.Infof("hash ring rebuilt with %d members", len(c.memberMap))
 }
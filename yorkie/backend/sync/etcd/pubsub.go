@@ -18,6 +18,7 @@ package etcd
 
 import (
 	"context"
+	gotime "time"
 
 	"google.golang.org/grpc"
 
@@ -30,6 +31,24 @@ import (
 	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
 )
 
+const (
+	// memberEventQueueSize is the size of the event queue of each member.
+	memberEventQueueSize = 128
+
+	// maxMemberFailures is the number of consecutive failed events after
+	// which the member is evicted.
+	maxMemberFailures = 3
+
+	broadcastInitialBackoff = 100 * gotime.Millisecond
+	broadcastMaxBackoff     = 2 * gotime.Second
+
+	// evictionInitialBackoff and evictionMaxBackoff are the bounds of the
+	// duration for which events are not sent to an evicted member. The
+	// duration is doubled whenever the member is evicted again.
+	evictionInitialBackoff = 5 * gotime.Second
+	evictionMaxBackoff     = gotime.Minute
+)
+
 // Subscribe subscribes to the given topics.
 func (c *Client) Subscribe(
 	subscriber types.Client,
@@ -52,24 +71,43 @@ func (c *Client) Publish(
 ) {
 	c.PublishToLocal(ctx, publisherID, event)
 
+	if c.config.EventPropagation == WatchPropagation {
+		if err := c.putEvent(ctx, publisherID, event); err != nil {
+			log.Logger.Error(err)
+		}
+		return
+	}
+
+	request, err := toBroadcastEventRequest(publisherID, event)
+	if err != nil {
+		log.Logger.Error(err)
+		return
+	}
+
 	for _, member := range c.Members() {
-		memberAddr := member.RPCAddr
-		if memberAddr == c.agentInfo.RPCAddr {
+		memberAddr := member.ClusterAddr
+		if memberAddr == c.agentInfo.ClusterAddr {
+			continue
+		}
+
+		if c.isEvicted(member.ID) {
+			c.recordBroadcastFailure()
 			continue
 		}
 
 		clientInfo, err := c.ensureClusterClient(member)
 		if err != nil {
+			c.recordBroadcastFailure()
 			continue
 		}
 
-		if err := c.publishToMember(
-			ctx,
-			clientInfo,
-			publisherID,
-			event,
-		); err != nil {
-			continue
+		// NOTE: Events are queued not to block the publisher by
+		// slow members. They are sent by sendEventsToMember with retries.
+		select {
+		case clientInfo.events <- request:
+		default:
+			log.Logger.Warnf("event queue of %s is full; dropping event", member.ID)
+			c.recordBroadcastFailure()
 		}
 	}
 }
@@ -89,27 +127,51 @@ func (c *Client) ensureClusterClient(member *sync.AgentInfo) (*clusterClientInfo
 	defer c.clusterClientMapMu.Unlock()
 
 	if _, ok := c.clusterClientMap[member.ID]; !ok {
-		conn, err := grpc.Dial(member.RPCAddr, grpc.WithInsecure())
+		opts := []grpc.DialOption{grpc.WithInsecure()}
+		if c.config.ClusterSecret != "" {
+			opts = append(opts, grpc.WithPerRPCCredentials(&clusterCredentials{
+				secret: c.config.ClusterSecret,
+			}))
+		}
+
+		conn, err := grpc.Dial(member.ClusterAddr, opts...)
 		if err != nil {
 			log.Logger.Error(err)
 			return nil, err
 		}
 
-		c.clusterClientMap[member.ID] = &clusterClientInfo{
-			client: api.NewClusterClient(conn),
-			conn:   conn,
+		clientInfo := &clusterClientInfo{
+			memberID: member.ID,
+			client:   api.NewClusterClient(conn),
+			conn:     conn,
+			events:   make(chan *api.BroadcastEventRequest, memberEventQueueSize),
+			closing:  make(chan struct{}),
+			evicted:  c.evictedMembers[member.ID] != nil,
 		}
+		c.clusterClientMap[member.ID] = clientInfo
+		go c.sendEventsToMember(clientInfo)
 	}
 
 	return c.clusterClientMap[member.ID], nil
 }
 
+// ClusterClient returns the client of the Cluster service of the given member.
+func (c *Client) ClusterClient(member *sync.AgentInfo) (api.ClusterClient, error) {
+	clientInfo, err := c.ensureClusterClient(member)
+	if err != nil {
+		return nil, err
+	}
+
+	return clientInfo.client, nil
+}
+
 // removeClusterClient removes the cluster client of the given ID.
 func (c *Client) removeClusterClient(id string) {
 	c.clusterClientMapMu.Lock()
 	defer c.clusterClientMapMu.Unlock()
 
 	if info, ok := c.clusterClientMap[id]; ok {
+		close(info.closing)
 		if err := info.conn.Close(); err != nil {
 			log.Logger.Error(err)
 		}
@@ -118,26 +180,144 @@ func (c *Client) removeClusterClient(id string) {
 	}
 }
 
-// publishToMember publishes events to other agents.
+// evictMember removes the cluster client of the given ID and skips the member
+// until the backoff deadline passes.
+func (c *Client) evictMember(id string) {
+	c.removeClusterClient(id)
+
+	c.clusterClientMapMu.Lock()
+	defer c.clusterClientMapMu.Unlock()
+
+	backoff := evictionInitialBackoff
+	if evicted, ok := c.evictedMembers[id]; ok {
+		backoff = evicted.backoff * 2
+		if backoff > evictionMaxBackoff {
+			backoff = evictionMaxBackoff
+		}
+	}
+
+	c.evictedMembers[id] = &evictedMember{
+		until:   gotime.Now().Add(backoff),
+		backoff: backoff,
+	}
+}
+
+// isEvicted returns whether the member of the given ID is evicted and its
+// backoff deadline has not passed yet.
+func (c *Client) isEvicted(id string) bool {
+	c.clusterClientMapMu.RLock()
+	defer c.clusterClientMapMu.RUnlock()
+
+	evicted, ok := c.evictedMembers[id]
+	return ok && gotime.Now().Before(evicted.until)
+}
+
+// clearEviction clears the eviction of the member of the given ID.
+func (c *Client) clearEviction(id string) {
+	c.clusterClientMapMu.Lock()
+	defer c.clusterClientMapMu.Unlock()
+
+	delete(c.evictedMembers, id)
+}
+
+// sendEventsToMember sends the queued events to the member of the given
+// client in order. If the calls to the member keep failing, the member is
+// evicted: its client is removed and it is skipped until the backoff deadline
+// passes.
+func (c *Client) sendEventsToMember(clientInfo *clusterClientInfo) {
+	failures := 0
+	for {
+		select {
+		case request := <-clientInfo.events:
+			if err := c.publishToMember(clientInfo, request); err != nil {
+				c.recordBroadcastFailure()
+				failures++
+			} else {
+				failures = 0
+				if clientInfo.evicted {
+					clientInfo.evicted = false
+					c.clearEviction(clientInfo.memberID)
+				}
+			}
+
+			if failures >= maxMemberFailures {
+				log.Logger.Warnf("evicting member %s after %d failures", clientInfo.memberID, failures)
+				c.evictMember(clientInfo.memberID)
+				if c.metrics != nil {
+					c.metrics.IncClusterMemberEvictions()
+				}
+				return
+			}
+		case <-clientInfo.closing:
+			return
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// publishToMember publishes events to other agents. The call is retried with
+// exponential backoff, and each call has its own deadline.
 func (c *Client) publishToMember(
-	ctx context.Context,
 	clientInfo *clusterClientInfo,
+	request *api.BroadcastEventRequest,
+) error {
+	var err error
+	backoff := broadcastInitialBackoff
+	for attempt := 0; attempt <= c.config.BroadcastMaxRetries; attempt++ {
+		if attempt > 0 {
+			if c.metrics != nil {
+				c.metrics.IncBroadcastEventRetries()
+			}
+
+			select {
+			case <-gotime.After(backoff):
+			case <-clientInfo.closing:
+				return err
+			case <-c.ctx.Done():
+				return err
+			}
+
+			backoff *= 2
+			if backoff > broadcastMaxBackoff {
+				backoff = broadcastMaxBackoff
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(c.ctx, c.config.BroadcastTimeoutSec*gotime.Second)
+		_, err = clientInfo.client.BroadcastEvent(ctx, request)
+		cancel()
+		if err == nil {
+			return nil
+		}
+
+		log.Logger.Error(err)
+	}
+
+	return err
+}
+
+// recordBroadcastFailure records the failure of delivering an event to a
+// member.
+func (c *Client) recordBroadcastFailure() {
+	if c.metrics != nil {
+		c.metrics.IncBroadcastEventFailures()
+	}
+}
+
+// toBroadcastEventRequest converts the given event to the request of
+// BroadcastEvent.
+func toBroadcastEventRequest(
 	publisherID *time.ActorID,
 	event sync.DocEvent,
-) error {
+) (*api.BroadcastEventRequest, error) {
 	docEvent, err := converter.ToDocEvent(event)
 	if err != nil {
-		log.Logger.Error(err)
-		return err
+		return nil, err
 	}
 
-	if _, err := clientInfo.client.BroadcastEvent(ctx, &api.BroadcastEventRequest{
+	return &api.BroadcastEventRequest{
 		PublisherId: publisherID.Bytes(),
 		Event:       docEvent,
-	}); err != nil {
-		log.Logger.Error(err)
-		return err
-	}
-
-	return nil
+	}, nil
 }
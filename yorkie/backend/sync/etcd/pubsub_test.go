@@ -0,0 +1,106 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	gosync "sync"
+	"sync/atomic"
+	"testing"
+	gotime "time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/yorkie-team/yorkie/api"
+	"github.com/yorkie-team/yorkie/pkg/document/key"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+	"github.com/yorkie-team/yorkie/pkg/types"
+	"github.com/yorkie-team/yorkie/test/helper"
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync/etcd"
+)
+
+// failingClusterServer is a Cluster server whose BroadcastEvent always fails.
+type failingClusterServer struct {
+	api.UnimplementedClusterServer
+	calls int32
+}
+
+func (s *failingClusterServer) BroadcastEvent(
+	ctx context.Context,
+	req *api.BroadcastEventRequest,
+) (*api.BroadcastEventResponse, error) {
+	atomic.AddInt32(&s.calls, 1)
+	return nil, errors.New("unavailable")
+}
+
+func TestPubSub(t *testing.T) {
+	t.Run("evicted member test", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "localhost:0")
+		assert.NoError(t, err)
+		server := &failingClusterServer{}
+		grpcServer := grpc.NewServer()
+		api.RegisterClusterServer(grpcServer, server)
+
+		wg := gosync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = grpcServer.Serve(listener)
+		}()
+		defer func() {
+			grpcServer.Stop()
+			wg.Wait()
+		}()
+
+		cli := etcd.NewClientWithoutDial(&etcd.Config{
+			BroadcastTimeoutSec: 1,
+			BroadcastMaxRetries: 1,
+		}, &sync.AgentInfo{ID: "agent-a", ClusterAddr: "localhost:0"})
+		cli.AddMember(sync.AgentInfo{ID: "agent-b", ClusterAddr: listener.Addr().String()})
+
+		publisher := types.Client{ID: &time.ActorID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}}
+		publish := func() {
+			cli.Publish(context.Background(), publisher.ID, sync.DocEvent{
+				Type:      types.DocumentsChangedEvent,
+				Publisher: publisher,
+				DocumentKeys: []*key.Key{{
+					Collection: helper.Collection,
+					Document:   t.Name(),
+				}},
+			})
+		}
+
+		// the member is evicted after 3 events are failed with a retry each.
+		for i := 0; i < 3; i++ {
+			publish()
+		}
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt32(&server.calls) == 6
+		}, 5*gotime.Second, 10*gotime.Millisecond)
+
+		// no events should be sent to the evicted member.
+		for i := 0; i < 3; i++ {
+			publish()
+		}
+		gotime.Sleep(500 * gotime.Millisecond)
+		assert.Equal(t, int32(6), atomic.LoadInt32(&server.calls))
+	})
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.etcd.io/etcd/clientv3"
+
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+)
+
+const (
+	rateLimitsPath    = "/rate-limits"
+	rateLimitValueTTL = time.Minute
+
+	// maxRateLimitTxnRetries is the number of retries of the transaction that
+	// takes a token when the bucket is updated by other agents concurrently.
+	maxRateLimitTxnRetries = 5
+)
+
+// ErrRateLimitConflict is returned when the bucket of a key kept being updated
+// by other agents while taking a token. The caller can limit the request with
+// a bucket of its own instead.
+var ErrRateLimitConflict = errors.New("rate limit conflict")
+
+// Allow takes a token from the bucket of the given key. The bucket is stored
+// in etcd so that it is shared by all agents in the cluster. The token is
+// taken with a transaction that compares the revision of the bucket, and the
+// bucket is removed when it is not updated during the lease TTL.
+//
+// NOTE: If it takes longer than half of the TTL to refill a bucket,
+// the bucket can be removed before it is full, and then more requests than
+// the limit are allowed.
+//
+// NOTE: A token takes two round trips: reading the bucket and the transaction
+// that writes it back. The refill depends on the time the bucket was updated,
+// so the bucket cannot be updated without reading it. The lease is granted
+// only once in half of its TTL, and the transaction returns the latest bucket
+// when it fails, so a retry takes only one more round trip.
+func (c *Client) Allow(
+	ctx context.Context,
+	key string,
+	limit sync.RateLimit,
+) (time.Duration, error) {
+	k := fmt.Sprintf("%s/%s", rateLimitsPath, key)
+
+	getResponse, err := c.client.Get(ctx, k)
+	if err != nil {
+		return 0, fmt.Errorf("get %s: %w", k, err)
+	}
+	kvs := getResponse.Kvs
+
+	for i := 0; i < maxRateLimitTxnRetries; i++ {
+		now := time.Now()
+		bucket := sync.NewTokenBucket(limit, now)
+		var rev int64
+		if len(kvs) > 0 {
+			rev = kvs[0].ModRevision
+			if err := json.Unmarshal(kvs[0].Value, bucket); err != nil {
+				return 0, fmt.Errorf("unmarshal %s: %w", k, err)
+			}
+		}
+
+		if wait := bucket.Take(limit, now); wait > 0 {
+			return wait, nil
+		}
+
+		bytes, err := json.Marshal(bucket)
+		if err != nil {
+			return 0, fmt.Errorf("marshal %s: %w", k, err)
+		}
+
+		leaseID, err := c.rateLimitLease(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		txnResponse, err := c.client.Txn(ctx).If(
+			clientv3.Compare(clientv3.ModRevision(k), "=", rev),
+		).Then(
+			clientv3.OpPut(k, string(bytes), clientv3.WithLease(leaseID)),
+		).Else(
+			clientv3.OpGet(k),
+		).Commit()
+		if err != nil {
+			return 0, fmt.Errorf("put %s: %w", k, err)
+		}
+		if txnResponse.Succeeded {
+			return 0, nil
+		}
+		kvs = txnResponse.Responses[0].GetResponseRange().Kvs
+	}
+
+	return 0, fmt.Errorf("%s: %w", k, ErrRateLimitConflict)
+}
+
+// rateLimitLease returns the lease for the buckets of this agent. Like the
+// lease for the events, it is shared by the buckets put in half of its TTL.
+func (c *Client) rateLimitLease(ctx context.Context) (clientv3.LeaseID, error) {
+	c.rateLimitLeaseMu.Lock()
+	defer c.rateLimitLeaseMu.Unlock()
+
+	now := time.Now()
+	if c.rateLimitLeaseID != clientv3.NoLease && now.Before(c.rateLimitLeaseRenewAt) {
+		return c.rateLimitLeaseID, nil
+	}
+
+	grantResponse, err := c.client.Grant(ctx, int64(rateLimitValueTTL.Seconds()))
+	if err != nil {
+		return clientv3.NoLease, fmt.Errorf("grant rate limits of %s: %w", c.agentInfo.ID, err)
+	}
+
+	c.rateLimitLeaseID = grantResponse.ID
+	c.rateLimitLeaseRenewAt = now.Add(rateLimitValueTTL / 2)
+	return c.rateLimitLeaseID, nil
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sync
+
+import (
+	"hash/crc32"
+	"sort"
+	"strconv"
+)
+
+// DefaultHashRingReplicas is the default number of virtual nodes of each
+// member in HashRing.
+const DefaultHashRingReplicas = 64
+
+// HashRing is a consistent hash ring of the members of the cluster. It is used
+// to decide the member that owns a document, so that the requests of the
+// document are processed by the same agent. When a member joins or leaves,
+// only the keys of the member are moved to the others.
+type HashRing struct {
+	hashes  []uint32
+	members map[uint32]*AgentInfo
+}
+
+// NewHashRing creates an instance of HashRing of the given members. Each
+// member is placed on the ring with the given number of virtual nodes.
+func NewHashRing(replicas int, members map[string]*AgentInfo) *HashRing {
+	ring := &HashRing{
+		members: make(map[uint32]*AgentInfo),
+	}
+
+	for _, member := range members {
+		for i := 0; i < replicas; i++ {
+			hash := crc32.ChecksumIEEE([]byte(member.ID + "#" + strconv.Itoa(i)))
+			// NOTE: On a hash collision, the member with the
+			// smaller ID takes the node so that every agent builds the same ring.
+			owner, ok := ring.members[hash]
+			if !ok {
+				ring.hashes = append(ring.hashes, hash)
+			} else if owner.ID < member.ID {
+				continue
+			}
+			ring.members[hash] = member
+		}
+	}
+	sort.Slice(ring.hashes, func(i, j int) bool {
+		return ring.hashes[i] < ring.hashes[j]
+	})
+
+	return ring
+}
+
+// Owner returns the member that owns the given key. It returns nil if the
+// ring has no members.
+func (r *HashRing) Owner(key string) *AgentInfo {
+	if len(r.hashes) == 0 {
+		return nil
+	}
+
+	hash := crc32.ChecksumIEEE([]byte(key))
+	idx := sort.Search(len(r.hashes), func(i int) bool {
+		return r.hashes[i] >= hash
+	})
+	if idx == len(r.hashes) {
+		idx = 0
+	}
+
+	return r.members[r.hashes[idx]]
+}
+
+// Len returns the number of virtual nodes of this ring.
+func (r *HashRing) Len() int {
+	return len(r.hashes)
+}
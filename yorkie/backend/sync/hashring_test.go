@@ -0,0 +1,73 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sync_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+)
+
+func TestHashRing(t *testing.T) {
+	members := map[string]*sync.AgentInfo{
+		"agent-a": {ID: "agent-a"},
+		"agent-b": {ID: "agent-b"},
+		"agent-c": {ID: "agent-c"},
+	}
+
+	t.Run("empty ring test", func(t *testing.T) {
+		ring := sync.NewHashRing(sync.DefaultHashRingReplicas, nil)
+		assert.Nil(t, ring.Owner("collection$document"))
+		assert.Equal(t, 0, ring.Len())
+	})
+
+	t.Run("owner test", func(t *testing.T) {
+		ring := sync.NewHashRing(sync.DefaultHashRingReplicas, members)
+		ring2 := sync.NewHashRing(sync.DefaultHashRingReplicas, members)
+
+		counts := make(map[string]int)
+		for i := 0; i < 300; i++ {
+			docKey := fmt.Sprintf("collection$document-%d", i)
+			owner := ring.Owner(docKey)
+			assert.Equal(t, owner.ID, ring2.Owner(docKey).ID)
+			counts[owner.ID]++
+		}
+
+		// every member owns some of the documents.
+		assert.Len(t, counts, len(members))
+	})
+
+	t.Run("rebalance test", func(t *testing.T) {
+		ring := sync.NewHashRing(sync.DefaultHashRingReplicas, members)
+		reduced := map[string]*sync.AgentInfo{
+			"agent-a": members["agent-a"],
+			"agent-b": members["agent-b"],
+		}
+		ring2 := sync.NewHashRing(sync.DefaultHashRingReplicas, reduced)
+
+		// only the documents of the removed member are moved.
+		for i := 0; i < 300; i++ {
+			docKey := fmt.Sprintf("collection$document-%d", i)
+			if owner := ring.Owner(docKey); owner.ID != "agent-c" {
+				assert.Equal(t, owner.ID, ring2.Owner(docKey).ID)
+			}
+		}
+	})
+}
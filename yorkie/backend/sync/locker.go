@@ -18,8 +18,17 @@ package sync
 
 import (
 	"context"
+	"errors"
+	gotime "time"
 )
 
+// ErrLockExpired is returned when the lock is unlocked after its TTL has
+// expired and it has been released automatically.
+var ErrLockExpired = errors.New("lock expired")
+
+// ErrLockNotHeld is returned when the lock is unlocked without being held.
+var ErrLockNotHeld = errors.New("lock not held")
+
 // Key represents key of Locker.
 type Key string
 
@@ -35,12 +44,49 @@ func (k Key) String() string {
 
 // A Locker represents an object that can be locked and unlocked.
 type Locker interface {
+	// Lock locks the mutex. It blocks until the lock is acquired or the given
+	// context is done.
 	Lock(ctx context.Context) error
+
+	// TryLock tries to lock the mutex without blocking. It returns false if
+	// the lock is already held by another locker.
+	TryLock(ctx context.Context) (bool, error)
+
+	// Unlock unlocks the mutex. It returns ErrLockNotHeld if the mutex is not
+	// locked by this locker.
 	Unlock(ctx context.Context) error
 }
 
+// LockerOptions is the options for creating a Locker.
+type LockerOptions struct {
+	// TTL is the lease time of the lock. If the holder does not unlock the
+	// lock within the TTL, the lock is released automatically so that a
+	// crashed holder cannot block the key forever. Zero means that the
+	// default of the implementation is used.
+	TTL gotime.Duration
+}
+
+// LockerOption configures LockerOptions.
+type LockerOption func(*LockerOptions)
+
+// WithTTL sets the lease time of the lock.
+func WithTTL(ttl gotime.Duration) LockerOption {
+	return func(opts *LockerOptions) {
+		opts.TTL = ttl
+	}
+}
+
+// NewLockerOptions creates an instance of LockerOptions from the given options.
+func NewLockerOptions(opts ...LockerOption) *LockerOptions {
+	options := &LockerOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
 // LockerMap is a module that manages Locker for the given keys.
 type LockerMap interface {
 	// NewLocker creates a sync.Locker.
-	NewLocker(ctx context.Context, key Key) (Locker, error)
+	NewLocker(ctx context.Context, key Key, opts ...LockerOption) (Locker, error)
 }
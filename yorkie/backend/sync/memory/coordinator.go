@@ -18,40 +18,55 @@ package memory
 
 import (
 	"context"
+	"errors"
+	gotime "time"
 
-	"github.com/moby/locker"
-
+	"github.com/yorkie-team/yorkie/api"
 	"github.com/yorkie-team/yorkie/pkg/document/key"
 	"github.com/yorkie-team/yorkie/pkg/document/time"
 	"github.com/yorkie-team/yorkie/pkg/types"
 	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+	"github.com/yorkie-team/yorkie/yorkie/metrics"
 )
 
+// ErrNoClusterClient is returned when the cluster client is requested from
+// the memory coordinator that has no other member.
+var ErrNoClusterClient = errors.New("no cluster client in memory coordinator")
+
 // Coordinator is a memory-based implementation of sync.Coordinator.
 type Coordinator struct {
 	agentInfo *sync.AgentInfo
 
-	locks  *locker.Locker
-	pubSub *PubSub
+	locks       *lockerMap
+	pubSub      *PubSub
+	rateLimiter *RateLimiter
 }
 
 // NewCoordinator creates an instance of Coordinator.
-func NewCoordinator(agentInfo *sync.AgentInfo) *Coordinator {
+func NewCoordinator(
+	agentInfo *sync.AgentInfo,
+	pubSubConf *sync.PubSubConfig,
+	met metrics.Metrics,
+) *Coordinator {
 	return &Coordinator{
-		agentInfo: agentInfo,
-		locks:     locker.New(),
-		pubSub:    NewPubSub(),
+		agentInfo:   agentInfo,
+		locks:       newLockerMap(),
+		pubSub:      NewPubSub(pubSubConf, met),
+		rateLimiter: NewRateLimiter(),
 	}
 }
 
-// NewLocker creates locker of the given key.
+// NewLocker creates locker of the given key. If the TTL is not given, the
+// lock is held until it is unlocked.
 func (m *Coordinator) NewLocker(
 	ctx context.Context,
 	key sync.Key,
+	opts ...sync.LockerOption,
 ) (sync.Locker, error) {
 	return &internalLocker{
-		key.String(),
-		m.locks,
+		key:   key.String(),
+		locks: m.locks,
+		ttl:   sync.NewLockerOptions(opts...).TTL,
 	}, nil
 }
 
@@ -86,6 +101,16 @@ func (m *Coordinator) PublishToLocal(
 	m.pubSub.Publish(ctx, publisherID, event)
 }
 
+// Allow takes a token from the bucket of the given key. Since there is no
+// other member, the bucket is kept in memory.
+func (m *Coordinator) Allow(
+	ctx context.Context,
+	key string,
+	limit sync.RateLimit,
+) (gotime.Duration, error) {
+	return m.rateLimiter.Allow(ctx, key, limit)
+}
+
 // Members returns the members of this cluster.
 func (m *Coordinator) Members() map[string]*sync.AgentInfo {
 	members := make(map[string]*sync.AgentInfo)
@@ -93,6 +118,21 @@ func (m *Coordinator) Members() map[string]*sync.AgentInfo {
 	return members
 }
 
+// Owner returns this agent, since there is no other member.
+func (m *Coordinator) Owner(docKey *key.Key) (*sync.AgentInfo, bool) {
+	return m.agentInfo, true
+}
+
+// ClusterClient returns an error, since there is no other member.
+func (m *Coordinator) ClusterClient(member *sync.AgentInfo) (api.ClusterClient, error) {
+	return nil, ErrNoClusterClient
+}
+
+// AuthenticateAgent returns an error, since there is no other member.
+func (m *Coordinator) AuthenticateAgent(ctx context.Context) error {
+	return sync.ErrUnauthenticatedAgent
+}
+
 // Close closes all resources of this Coordinator.
 func (m *Coordinator) Close() error {
 	return nil
@@ -18,30 +18,165 @@ package memory
 
 import (
 	"context"
-
-	"github.com/moby/locker"
+	gosync "sync"
+	gotime "time"
 
 	"github.com/yorkie-team/yorkie/internal/log"
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
 )
 
+// keyLock is a mutex of a key. The lock is held while the channel is full.
+type keyLock struct {
+	ch chan struct{}
+
+	// waiters is the number of lockers waiting for or holding this lock.
+	waiters int
+
+	// generation is increased whenever the lock is acquired or released, so
+	// that a stale holder cannot release the lock held by another.
+	generation uint64
+}
+
+// lockerMap is a map of keyLock that removes unused locks.
+type lockerMap struct {
+	mu    gosync.Mutex
+	locks map[string]*keyLock
+}
+
+// newLockerMap creates an instance of lockerMap.
+func newLockerMap() *lockerMap {
+	return &lockerMap{
+		locks: make(map[string]*keyLock),
+	}
+}
+
+// ref returns the lock of the given key and increases its waiters.
+func (m *lockerMap) ref(key string) *keyLock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l, ok := m.locks[key]
+	if !ok {
+		l = &keyLock{ch: make(chan struct{}, 1)}
+		m.locks[key] = l
+	}
+	l.waiters++
+	return l
+}
+
+// unref decreases the waiters of the given lock and removes the lock if it
+// is no longer used. It should be called with the mutex held.
+func (m *lockerMap) unref(key string, l *keyLock) {
+	l.waiters--
+	if l.waiters == 0 {
+		delete(m.locks, key)
+	}
+}
+
+// acquired marks the given lock as acquired and returns its generation.
+func (m *lockerMap) acquired(l *keyLock) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l.generation++
+	return l.generation
+}
+
+// cancel gives up waiting for the lock of the given key.
+func (m *lockerMap) cancel(key string, l *keyLock) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.unref(key, l)
+}
+
+// release releases the lock of the given key if it is still held in the
+// given generation. It returns false if the lock has already been released.
+func (m *lockerMap) release(key string, l *keyLock, generation uint64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if l.generation != generation {
+		return false
+	}
+
+	l.generation++
+	<-l.ch
+	m.unref(key, l)
+	return true
+}
+
 type internalLocker struct {
 	key   string
-	locks *locker.Locker
+	locks *lockerMap
+	ttl   gotime.Duration
+
+	lock       *keyLock
+	generation uint64
+	timer      *gotime.Timer
 }
 
 // Lock locks the mutex.
 func (il *internalLocker) Lock(ctx context.Context) error {
-	il.locks.Lock(il.key)
+	l := il.locks.ref(il.key)
 
-	return nil
+	select {
+	case l.ch <- struct{}{}:
+		il.hold(l)
+		return nil
+	case <-ctx.Done():
+		il.locks.cancel(il.key, l)
+		return ctx.Err()
+	}
+}
+
+// TryLock tries to lock the mutex without blocking.
+func (il *internalLocker) TryLock(ctx context.Context) (bool, error) {
+	l := il.locks.ref(il.key)
+
+	select {
+	case l.ch <- struct{}{}:
+		il.hold(l)
+		return true, nil
+	default:
+		il.locks.cancel(il.key, l)
+		return false, nil
+	}
 }
 
 // Unlock unlocks the mutex.
 func (il *internalLocker) Unlock(ctx context.Context) error {
-	if err := il.locks.Unlock(il.key); err != nil {
-		log.Logger.Error(err)
-		return err
+	if il.lock == nil {
+		log.Logger.Error(sync.ErrLockNotHeld)
+		return sync.ErrLockNotHeld
+	}
+
+	if il.timer != nil {
+		il.timer.Stop()
+		il.timer = nil
+	}
+
+	l := il.lock
+	il.lock = nil
+	if !il.locks.release(il.key, l, il.generation) {
+		log.Logger.Error(sync.ErrLockExpired)
+		return sync.ErrLockExpired
 	}
 
 	return nil
 }
+
+// hold records the acquired lock and starts the lease timer if the TTL is set.
+func (il *internalLocker) hold(l *keyLock) {
+	il.lock = l
+	il.generation = il.locks.acquired(l)
+
+	if il.ttl > 0 {
+		generation := il.generation
+		il.timer = gotime.AfterFunc(il.ttl, func() {
+			if il.locks.release(il.key, l, generation) {
+				log.Logger.Warnf("lock of %s expired after %s", il.key, il.ttl)
+			}
+		})
+	}
+}
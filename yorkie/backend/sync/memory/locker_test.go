@@ -0,0 +1,136 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory_test
+
+import (
+	"context"
+	"testing"
+	gotime "time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync/memory"
+)
+
+func TestLocker(t *testing.T) {
+	agentInfo := &sync.AgentInfo{ID: "agent"}
+	lockKey := sync.NewKey("lock-key")
+
+	t.Run("lock and unlock test", func(t *testing.T) {
+		ctx := context.Background()
+		coordinator := memory.NewCoordinator(agentInfo, nil, nil)
+
+		lockerA, err := coordinator.NewLocker(ctx, lockKey)
+		assert.NoError(t, err)
+		lockerB, err := coordinator.NewLocker(ctx, lockKey)
+		assert.NoError(t, err)
+
+		assert.NoError(t, lockerA.Lock(ctx))
+
+		done := make(chan struct{})
+		go func() {
+			assert.NoError(t, lockerB.Lock(ctx))
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			assert.Fail(t, "lock should be blocked")
+		case <-gotime.After(50 * gotime.Millisecond):
+		}
+
+		assert.NoError(t, lockerA.Unlock(ctx))
+		<-done
+		assert.NoError(t, lockerB.Unlock(ctx))
+	})
+
+	t.Run("try lock test", func(t *testing.T) {
+		ctx := context.Background()
+		coordinator := memory.NewCoordinator(agentInfo, nil, nil)
+
+		lockerA, err := coordinator.NewLocker(ctx, lockKey)
+		assert.NoError(t, err)
+		lockerB, err := coordinator.NewLocker(ctx, lockKey)
+		assert.NoError(t, err)
+
+		locked, err := lockerA.TryLock(ctx)
+		assert.NoError(t, err)
+		assert.True(t, locked)
+
+		locked, err = lockerB.TryLock(ctx)
+		assert.NoError(t, err)
+		assert.False(t, locked)
+
+		assert.NoError(t, lockerA.Unlock(ctx))
+
+		locked, err = lockerB.TryLock(ctx)
+		assert.NoError(t, err)
+		assert.True(t, locked)
+		assert.NoError(t, lockerB.Unlock(ctx))
+	})
+
+	t.Run("lock with canceled context test", func(t *testing.T) {
+		ctx := context.Background()
+		coordinator := memory.NewCoordinator(agentInfo, nil, nil)
+
+		lockerA, err := coordinator.NewLocker(ctx, lockKey)
+		assert.NoError(t, err)
+		lockerB, err := coordinator.NewLocker(ctx, lockKey)
+		assert.NoError(t, err)
+
+		assert.NoError(t, lockerA.Lock(ctx))
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 50*gotime.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, lockerB.Lock(timeoutCtx), context.DeadlineExceeded)
+
+		assert.NoError(t, lockerA.Unlock(ctx))
+		assert.NoError(t, lockerB.Lock(ctx))
+		assert.NoError(t, lockerB.Unlock(ctx))
+	})
+
+	t.Run("lock with ttl test", func(t *testing.T) {
+		ctx := context.Background()
+		coordinator := memory.NewCoordinator(agentInfo, nil, nil)
+
+		lockerA, err := coordinator.NewLocker(ctx, lockKey, sync.WithTTL(50*gotime.Millisecond))
+		assert.NoError(t, err)
+		lockerB, err := coordinator.NewLocker(ctx, lockKey)
+		assert.NoError(t, err)
+
+		assert.NoError(t, lockerA.Lock(ctx))
+
+		// the lock of A is released automatically after its TTL.
+		assert.NoError(t, lockerB.Lock(ctx))
+		assert.ErrorIs(t, lockerA.Unlock(ctx), sync.ErrLockExpired)
+		assert.NoError(t, lockerB.Unlock(ctx))
+	})
+
+	t.Run("unlock without lock test", func(t *testing.T) {
+		ctx := context.Background()
+		coordinator := memory.NewCoordinator(agentInfo, nil, nil)
+
+		locker, err := coordinator.NewLocker(ctx, lockKey)
+		assert.NoError(t, err)
+		assert.ErrorIs(t, locker.Unlock(ctx), sync.ErrLockNotHeld)
+
+		assert.NoError(t, locker.Lock(ctx))
+		assert.NoError(t, locker.Unlock(ctx))
+		assert.ErrorIs(t, locker.Unlock(ctx), sync.ErrLockNotHeld)
+	})
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/yorkie-team/yorkie/pkg/document/time"
 	"github.com/yorkie-team/yorkie/pkg/types"
 	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+	"github.com/yorkie-team/yorkie/yorkie/metrics"
 )
 
 // subscriptions is a collection of subscriptions that subscribe to a specific
@@ -65,13 +66,23 @@ func (s *subscriptions) Len() int {
 // PubSub is the memory implementation of PubSub, used for single agent or
 // tests.
 type PubSub struct {
+	config  *sync.PubSubConfig
+	metrics metrics.Metrics
+
 	subscriptionsMapMu      *gosync.RWMutex
 	subscriptionsMapByTopic map[string]*subscriptions
 }
 
-// NewPubSub creates an instance of PubSub.
-func NewPubSub() *PubSub {
+// NewPubSub creates an instance of PubSub. If the given config is nil, the
+// default config is used. The given metrics can be nil for tests.
+func NewPubSub(conf *sync.PubSubConfig, met metrics.Metrics) *PubSub {
+	if conf == nil {
+		conf = sync.NewPubSubConfig(0, "")
+	}
+
 	return &PubSub{
+		config:                  conf,
+		metrics:                 met,
 		subscriptionsMapMu:      &gosync.RWMutex{},
 		subscriptionsMapByTopic: make(map[string]*subscriptions),
 	}
@@ -95,7 +106,7 @@ func (m *PubSub) Subscribe(
 		subscriber.ID.String(),
 	)
 
-	sub := sync.NewSubscription(subscriber)
+	sub := sync.NewSubscription(subscriber, m.config)
 	peersMap := make(map[string][]types.Client)
 
 	for _, topic := range topics {
@@ -150,15 +161,16 @@ func (m *PubSub) Unsubscribe(topics []*key.Key, sub *sync.Subscription) {
 	)
 }
 
-// Publish publishes the given event.
+// Publish publishes the given event. It does not block on slow consumers,
+// since events are queued in each subscription.
 func (m *PubSub) Publish(
 	_ context.Context,
 	publisherID *time.ActorID,
 	event sync.DocEvent,
 ) {
-	m.subscriptionsMapMu.RLock()
-	defer m.subscriptionsMapMu.RUnlock()
+	var droppedSubs []*sync.Subscription
 
+	m.subscriptionsMapMu.RLock()
 	for _, docKey := range event.DocumentKeys {
 		topic := docKey.BSONKey()
 
@@ -176,9 +188,57 @@ func (m *PubSub) Publish(
 					publisherID.String(),
 					sub.SubscriberID(),
 				)
-				sub.Events() <- event
+				result := sub.Enqueue(event)
+				m.recordEnqueueResult(sub, result)
+				if sub.Closed() {
+					droppedSubs = append(droppedSubs, sub)
+				}
 			}
 		}
 		log.Logger.Debugf(`Publish(%s,%s) End`, topic, publisherID.String())
 	}
+	m.subscriptionsMapMu.RUnlock()
+
+	if len(droppedSubs) > 0 {
+		m.removeSubscriptions(droppedSubs)
+	}
+}
+
+// recordEnqueueResult records the metrics of the given enqueue result.
+func (m *PubSub) recordEnqueueResult(sub *sync.Subscription, result sync.EnqueueResult) {
+	if result.Dropped > 0 {
+		log.Logger.Warnf(
+			"slow consumer %s: %d events dropped by %s policy",
+			sub.SubscriberID(),
+			result.Dropped,
+			m.config.SlowConsumerPolicy,
+		)
+	}
+
+	if m.metrics == nil {
+		return
+	}
+	if result.Coalesced {
+		m.metrics.IncPubSubCoalescedEvents()
+	}
+	if result.Dropped > 0 {
+		m.metrics.AddPubSubDroppedEvents(result.Dropped)
+		m.metrics.IncPubSubSlowConsumers(m.config.SlowConsumerPolicy)
+	}
+}
+
+// removeSubscriptions removes the given subscriptions dropped due to slow
+// consumers from all topics.
+func (m *PubSub) removeSubscriptions(subs []*sync.Subscription) {
+	m.subscriptionsMapMu.Lock()
+	defer m.subscriptionsMapMu.Unlock()
+
+	for topic, topicSubs := range m.subscriptionsMapByTopic {
+		for _, sub := range subs {
+			topicSubs.Delete(sub.ID())
+		}
+		if topicSubs.Len() == 0 {
+			delete(m.subscriptionsMapByTopic, topic)
+		}
+	}
 }
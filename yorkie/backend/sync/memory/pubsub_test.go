@@ -20,6 +20,7 @@ import (
 	"context"
 	gosync "sync"
 	"testing"
+	gotime "time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -36,7 +37,7 @@ func TestPubSub(t *testing.T) {
 	actorB := types.Client{ID: &time.ActorID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}}
 
 	t.Run("publish subscribe test", func(t *testing.T) {
-		pubSub := memory.NewPubSub()
+		pubSub := memory.NewPubSub(nil, nil)
 		docKeys := []*key.Key{
 			{
 				Collection: helper.Collection,
@@ -70,7 +71,7 @@ func TestPubSub(t *testing.T) {
 	})
 
 	t.Run("subscriptions map test", func(t *testing.T) {
-		pubSub := memory.NewPubSub()
+		pubSub := memory.NewPubSub(nil, nil)
 		docKeys := []*key.Key{
 			{
 				Collection: helper.Collection,
@@ -84,4 +85,150 @@ func TestPubSub(t *testing.T) {
 			assert.Len(t, subs[docKeys[0].BSONKey()], i+1)
 		}
 	})
+
+	t.Run("coalesce changed events test", func(t *testing.T) {
+		pubSub := memory.NewPubSub(nil, nil)
+		docKeys := []*key.Key{
+			{
+				Collection: helper.Collection,
+				Document:   t.Name(),
+			},
+		}
+		event := sync.DocEvent{
+			Type:         types.DocumentsChangedEvent,
+			Publisher:    actorB,
+			DocumentKeys: docKeys,
+		}
+
+		subA, _, err := pubSub.Subscribe(actorA, docKeys)
+		assert.NoError(t, err)
+		defer func() {
+			pubSub.Unsubscribe(docKeys, subA)
+		}()
+
+		// publish does not block even if the subscriber does not receive.
+		for i := 0; i < 10; i++ {
+			pubSub.Publish(context.Background(), actorB.ID, event)
+		}
+
+		assert.Equal(t, event, <-subA.Events())
+		select {
+		case e := <-subA.Events():
+			assert.Fail(t, "changed events should be coalesced", e)
+		case <-gotime.After(50 * gotime.Millisecond):
+		}
+	})
+
+	t.Run("slow consumer resync test", func(t *testing.T) {
+		pubSub := memory.NewPubSub(sync.NewPubSubConfig(2, sync.SlowConsumerResync), nil)
+		docKeys := []*key.Key{
+			{
+				Collection: helper.Collection,
+				Document:   t.Name(),
+			},
+		}
+
+		subA, _, err := pubSub.Subscribe(actorA, docKeys)
+		assert.NoError(t, err)
+		defer func() {
+			pubSub.Unsubscribe(docKeys, subA)
+		}()
+
+		// NOTE: one event can be in flight besides the queued events.
+		for _, eventType := range []types.DocEventType{
+			types.DocumentsWatchedEvent,
+			types.DocumentsChangedEvent,
+			types.DocumentsUnwatchedEvent,
+			types.DocumentsChangedEvent,
+			types.DocumentsWatchedEvent,
+		} {
+			pubSub.Publish(context.Background(), actorB.ID, sync.DocEvent{
+				Type:         eventType,
+				Publisher:    actorB,
+				DocumentKeys: docKeys,
+			})
+		}
+
+		// the changed events are coalesced and only the last watch/unwatch
+		// event of the peer is kept.
+		var events []sync.DocEvent
+		for len(events) < 5 {
+			select {
+			case e := <-subA.Events():
+				events = append(events, e)
+				continue
+			case <-gotime.After(50 * gotime.Millisecond):
+			}
+			break
+		}
+		assert.Less(t, len(events), 5)
+		assert.Contains(t, events, sync.DocEvent{
+			Type:         types.DocumentsChangedEvent,
+			Publisher:    actorB,
+			DocumentKeys: docKeys,
+		})
+		assert.Equal(t, sync.DocEvent{
+			Type:         types.DocumentsWatchedEvent,
+			Publisher:    actorB,
+			DocumentKeys: docKeys,
+		}, events[len(events)-1])
+		assert.False(t, subA.Closed())
+	})
+
+	t.Run("slow consumer resync overflow test", func(t *testing.T) {
+		pubSub := memory.NewPubSub(sync.NewPubSubConfig(2, sync.SlowConsumerResync), nil)
+		docKeys := []*key.Key{
+			{
+				Collection: helper.Collection,
+				Document:   t.Name(),
+			},
+		}
+
+		subA, _, err := pubSub.Subscribe(actorA, docKeys)
+		assert.NoError(t, err)
+
+		// the watch events of different peers cannot be compacted, so the
+		// subscription is dropped instead of exceeding the queue size.
+		for i := 0; i < 4; i++ {
+			peer := types.Client{ID: &time.ActorID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, byte(i)}}
+			pubSub.Publish(context.Background(), peer.ID, sync.DocEvent{
+				Type:         types.DocumentsWatchedEvent,
+				Publisher:    peer,
+				DocumentKeys: docKeys,
+			})
+		}
+
+		assert.True(t, subA.Closed())
+		for range subA.Events() {
+		}
+	})
+
+	t.Run("slow consumer drop test", func(t *testing.T) {
+		pubSub := memory.NewPubSub(sync.NewPubSubConfig(2, sync.SlowConsumerDrop), nil)
+		docKeys := []*key.Key{
+			{
+				Collection: helper.Collection,
+				Document:   t.Name(),
+			},
+		}
+
+		subA, _, err := pubSub.Subscribe(actorA, docKeys)
+		assert.NoError(t, err)
+
+		for i := 0; i < 4; i++ {
+			pubSub.Publish(context.Background(), actorB.ID, sync.DocEvent{
+				Type:         types.DocumentsWatchedEvent,
+				Publisher:    actorB,
+				DocumentKeys: docKeys,
+			})
+		}
+
+		assert.True(t, subA.Closed())
+		for range subA.Events() {
+		}
+
+		_, subs, err := pubSub.Subscribe(actorB, docKeys)
+		assert.NoError(t, err)
+		assert.Len(t, subs[docKeys[0].BSONKey()], 1)
+	})
 }
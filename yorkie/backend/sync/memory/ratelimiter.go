@@ -0,0 +1,95 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	gosync "sync"
+	gotime "time"
+
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+)
+
+// rateLimiterSweepInterval is the interval of removing the full buckets.
+const rateLimiterSweepInterval = gotime.Minute
+
+// rateLimitBucket is the token bucket of a key and the limit of the bucket.
+type rateLimitBucket struct {
+	bucket *sync.TokenBucket
+	limit  sync.RateLimit
+}
+
+// RateLimiter is a memory-based implementation of sync.RateLimiter. The
+// buckets are only shared by the requests to this agent.
+type RateLimiter struct {
+	mu          *gosync.Mutex
+	buckets     map[string]*rateLimitBucket
+	nextSweepAt gotime.Time
+}
+
+// NewRateLimiter creates an instance of RateLimiter.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		mu:          &gosync.Mutex{},
+		buckets:     make(map[string]*rateLimitBucket),
+		nextSweepAt: gotime.Now().Add(rateLimiterSweepInterval),
+	}
+}
+
+// Allow takes a token from the bucket of the given key. It returns zero if the
+// request is allowed, otherwise the duration to wait for the next token.
+func (r *RateLimiter) Allow(
+	ctx context.Context,
+	key string,
+	limit sync.RateLimit,
+) (gotime.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := gotime.Now()
+	if now.After(r.nextSweepAt) {
+		r.sweep(now)
+	}
+
+	b, ok := r.buckets[key]
+	if !ok {
+		b = &rateLimitBucket{bucket: sync.NewTokenBucket(limit, now)}
+		r.buckets[key] = b
+	}
+	b.limit = limit
+
+	return b.bucket.Take(limit, now), nil
+}
+
+// Len returns the number of the buckets.
+func (r *RateLimiter) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.buckets)
+}
+
+// sweep removes the full buckets, so that the buckets of the keys that are no
+// longer requested are not kept.
+func (r *RateLimiter) sweep(now gotime.Time) {
+	for key, b := range r.buckets {
+		if b.bucket.IsFull(b.limit, now) {
+			delete(r.buckets, key)
+		}
+	}
+	r.nextSweepAt = now.Add(rateLimiterSweepInterval)
+}
@@ -18,6 +18,9 @@ package sync
 
 import (
 	"context"
+	"errors"
+	"strings"
+	gosync "sync"
 
 	"github.com/rs/xid"
 
@@ -26,22 +29,102 @@ import (
 	"github.com/yorkie-team/yorkie/pkg/types"
 )
 
+const (
+	// SlowConsumerDrop drops the subscription of a slow consumer when its
+	// queue is full. The consumer should subscribe again to receive events.
+	SlowConsumerDrop = "drop"
+
+	// SlowConsumerResync compacts the queued events of a slow consumer when
+	// its queue is full. DocumentsChanged events are replaced with one for
+	// each document, so that the consumer resyncs the documents, and only the
+	// last watch/unwatch event of each peer is kept. If the queue is still
+	// full, the subscription is dropped.
+	SlowConsumerResync = "resync"
+
+	// DefaultSubscriptionQueueSize is the default size of the event queue of
+	// a subscription.
+	DefaultSubscriptionQueueSize = 64
+)
+
+// ErrSlowConsumer is returned when the subscription is dropped because its
+// consumer could not keep up with the events.
+var ErrSlowConsumer = errors.New("subscription dropped due to slow consumer")
+
+// ErrTooManyWatchers is returned when a document already has as many watchers
+// as the limit.
+var ErrTooManyWatchers = errors.New("too many watchers")
+
+// PubSubConfig is the configuration of PubSub.
+type PubSubConfig struct {
+	// SubscriptionQueueSize is the maximum number of events that can be queued
+	// for a subscription. DocumentsChanged events of the same documents are
+	// coalesced while they are queued.
+	SubscriptionQueueSize int
+
+	// SlowConsumerPolicy is the policy applied when the queue of a
+	// subscription is full: SlowConsumerDrop or SlowConsumerResync.
+	SlowConsumerPolicy string
+}
+
+// NewPubSubConfig creates an instance of PubSubConfig with default values
+// for the missing fields.
+func NewPubSubConfig(queueSize int, policy string) *PubSubConfig {
+	if queueSize <= 0 {
+		queueSize = DefaultSubscriptionQueueSize
+	}
+	if policy == "" {
+		policy = SlowConsumerResync
+	}
+
+	return &PubSubConfig{
+		SubscriptionQueueSize: queueSize,
+		SlowConsumerPolicy:    policy,
+	}
+}
+
+// EnqueueResult is the result of enqueueing an event to a subscription.
+type EnqueueResult struct {
+	// Coalesced is whether the event is coalesced into a queued event.
+	Coalesced bool
+
+	// Dropped is the number of events dropped because the queue is full.
+	Dropped int
+}
+
 // Subscription represents the subscription of a subscriber. It is used across
 // several topics.
 type Subscription struct {
 	id         string
 	subscriber types.Client
-	closed     bool
-	events     chan DocEvent
+	config     *PubSubConfig
+
+	mu        gosync.Mutex
+	closed    bool
+	queue     []DocEvent
+	coalesced map[string]bool
+	notify    chan struct{}
+	closing   chan struct{}
+	events    chan DocEvent
 }
 
 // NewSubscription creates a new instance of Subscription.
-func NewSubscription(subscriber types.Client) *Subscription {
-	return &Subscription{
+func NewSubscription(subscriber types.Client, conf *PubSubConfig) *Subscription {
+	if conf == nil {
+		conf = NewPubSubConfig(0, "")
+	}
+
+	s := &Subscription{
 		id:         xid.New().String(),
 		subscriber: subscriber,
-		events:     make(chan DocEvent, 1),
+		config:     conf,
+		coalesced:  make(map[string]bool),
+		notify:     make(chan struct{}, 1),
+		closing:    make(chan struct{}),
+		events:     make(chan DocEvent),
 	}
+	go s.deliver()
+
+	return s
 }
 
 // ID returns the id of this subscription.
@@ -56,7 +139,38 @@ type DocEvent struct {
 	DocumentKeys []*key.Key
 }
 
-// Events returns the DocEvent channel of this subscription.
+// coalesceKey returns the key to coalesce the event with queued events. Only
+// DocumentsChanged events are coalesced, since the consumer only needs to know
+// whether the documents have changed.
+func (e DocEvent) coalesceKey() (string, bool) {
+	if e.Type != types.DocumentsChangedEvent {
+		return "", false
+	}
+
+	var keys []string
+	for _, docKey := range e.DocumentKeys {
+		keys = append(keys, docKey.BSONKey())
+	}
+	return strings.Join(keys, ","), true
+}
+
+// peerKey returns the key of the peer and the documents of the event. Only
+// watch/unwatch events have it, and the last one of the same key represents
+// whether the peer watches the documents.
+func (e DocEvent) peerKey() (string, bool) {
+	if e.Type != types.DocumentsWatchedEvent && e.Type != types.DocumentsUnwatchedEvent {
+		return "", false
+	}
+
+	var keys []string
+	for _, docKey := range e.DocumentKeys {
+		keys = append(keys, docKey.BSONKey())
+	}
+	return e.Publisher.ID.String() + "/" + strings.Join(keys, ","), true
+}
+
+// Events returns the DocEvent channel of this subscription. It is closed when
+// the subscription is closed.
 func (s *Subscription) Events() chan DocEvent {
 	return s.events
 }
@@ -71,14 +185,170 @@ func (s *Subscription) SubscriberID() string {
 	return s.subscriber.ID.String()
 }
 
+// Enqueue adds the given event to the queue of this subscription without
+// blocking. If the queue is full, the slow consumer policy is applied.
+func (s *Subscription) Enqueue(event DocEvent) EnqueueResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return EnqueueResult{}
+	}
+
+	coalesceKey, ok := event.coalesceKey()
+	if ok && s.coalesced[coalesceKey] {
+		return EnqueueResult{Coalesced: true}
+	}
+
+	result := EnqueueResult{}
+	if len(s.queue) >= s.config.SubscriptionQueueSize {
+		if s.config.SlowConsumerPolicy == SlowConsumerDrop {
+			result.Dropped = len(s.queue) + 1
+			s.close()
+			return result
+		}
+
+		queued := len(s.queue)
+		s.resync(event)
+		result.Dropped = queued - len(s.queue)
+		if ok && s.coalesced[coalesceKey] {
+			result.Coalesced = true
+			return result
+		}
+
+		if len(s.queue) >= s.config.SubscriptionQueueSize {
+			result.Dropped = len(s.queue) + 1
+			s.close()
+			return result
+		}
+	}
+
+	s.queue = append(s.queue, event)
+	if ok {
+		s.coalesced[coalesceKey] = true
+	}
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+
+	return result
+}
+
+// resync replaces the queued DocumentsChanged events with one for each
+// document of them, and keeps only the last watch/unwatch event of each peer
+// including the given incoming event. It should be called with the mutex held.
+func (s *Subscription) resync(incoming DocEvent) {
+	lastPeerEvents := make(map[string]int)
+	for i, event := range s.queue {
+		if peerKey, ok := event.peerKey(); ok {
+			lastPeerEvents[peerKey] = i
+		}
+	}
+	if peerKey, ok := incoming.peerKey(); ok {
+		lastPeerEvents[peerKey] = len(s.queue)
+	}
+
+	var events []DocEvent
+	coalesced := make(map[string]bool)
+	for i, event := range s.queue {
+		if peerKey, ok := event.peerKey(); ok {
+			if lastPeerEvents[peerKey] == i {
+				events = append(events, event)
+			}
+			continue
+		}
+
+		for _, docKey := range event.DocumentKeys {
+			if coalesced[docKey.BSONKey()] {
+				continue
+			}
+			coalesced[docKey.BSONKey()] = true
+			events = append(events, DocEvent{
+				Type:         types.DocumentsChangedEvent,
+				Publisher:    event.Publisher,
+				DocumentKeys: []*key.Key{docKey},
+			})
+		}
+	}
+
+	s.queue = events
+	s.coalesced = coalesced
+}
+
+// deliver delivers the queued events to the events channel until this
+// subscription is closed.
+func (s *Subscription) deliver() {
+	defer close(s.events)
+
+	for {
+		event, ok := s.dequeue()
+		if !ok {
+			return
+		}
+
+		select {
+		case s.events <- event:
+		case <-s.closing:
+			return
+		}
+	}
+}
+
+// dequeue waits for an event in the queue and removes it from the queue. It
+// returns false if this subscription is closed.
+func (s *Subscription) dequeue() (DocEvent, bool) {
+	for {
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			return DocEvent{}, false
+		}
+		if len(s.queue) > 0 {
+			event := s.queue[0]
+			s.queue = s.queue[1:]
+			if coalesceKey, ok := event.coalesceKey(); ok {
+				delete(s.coalesced, coalesceKey)
+			}
+			s.mu.Unlock()
+			return event, true
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-s.notify:
+		case <-s.closing:
+			return DocEvent{}, false
+		}
+	}
+}
+
+// Closed returns whether this subscription is closed.
+func (s *Subscription) Closed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.closed
+}
+
 // Close closes all resources of this Subscription.
 func (s *Subscription) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.close()
+}
+
+// close closes this subscription. It should be called with the mutex held.
+func (s *Subscription) close() {
 	if s.closed {
 		return
 	}
 
 	s.closed = true
-	close(s.events)
+	s.queue = nil
+	close(s.closing)
 }
 
 // PubSub is a structure to support event publishing/subscription.
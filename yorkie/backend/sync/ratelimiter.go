@@ -0,0 +1,117 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	gotime "time"
+)
+
+// ErrInvalidRateLimit is returned when the given rate limit is invalid.
+var ErrInvalidRateLimit = errors.New("invalid rate limit")
+
+// RateLimit is the limit of a token bucket. The tokens are refilled at
+// RatePerSec up to Burst, and each request takes a token.
+type RateLimit struct {
+	RatePerSec float64
+	Burst      int
+}
+
+// ParseRateLimit parses the given rate limit in "rate:burst" format such as
+// "10:20". If the burst is omitted, the rate rounded up is used as the burst.
+func ParseRateLimit(value string) (RateLimit, error) {
+	rateValue, burstValue, hasBurst := value, "", false
+	if i := strings.Index(value, ":"); i >= 0 {
+		rateValue, burstValue, hasBurst = value[:i], value[i+1:], true
+	}
+
+	rate, err := strconv.ParseFloat(rateValue, 64)
+	if err != nil || rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+		return RateLimit{}, fmt.Errorf("%s: %w", value, ErrInvalidRateLimit)
+	}
+
+	burst := int(math.Ceil(rate))
+	if hasBurst {
+		burst, err = strconv.Atoi(burstValue)
+		if err != nil || burst < 1 {
+			return RateLimit{}, fmt.Errorf("%s: %w", value, ErrInvalidRateLimit)
+		}
+	}
+
+	return RateLimit{
+		RatePerSec: rate,
+		Burst:      burst,
+	}, nil
+}
+
+// TokenBucket is the state of the token bucket of a key.
+type TokenBucket struct {
+	Tokens    float64     `json:"tokens"`
+	UpdatedAt gotime.Time `json:"updated_at"`
+}
+
+// NewTokenBucket creates a full token bucket of the given limit.
+func NewTokenBucket(limit RateLimit, now gotime.Time) *TokenBucket {
+	return &TokenBucket{
+		Tokens:    float64(limit.Burst),
+		UpdatedAt: now,
+	}
+}
+
+// Take refills the tokens of this bucket until the given time and takes a
+// token. It returns zero if a token is taken, otherwise the duration to wait
+// for the next token.
+func (b *TokenBucket) Take(limit RateLimit, now gotime.Time) gotime.Duration {
+	// NOTE: The clock of the agent that updated the bucket can be
+	// ahead of ours. In that case, the tokens are not refilled.
+	if elapsed := now.Sub(b.UpdatedAt); elapsed > 0 {
+		b.Tokens = math.Min(float64(limit.Burst), b.Tokens+elapsed.Seconds()*limit.RatePerSec)
+		b.UpdatedAt = now
+	}
+
+	if b.Tokens >= 1 {
+		b.Tokens--
+		return 0
+	}
+
+	wait := gotime.Duration((1 - b.Tokens) / limit.RatePerSec * float64(gotime.Second))
+	if wait <= 0 {
+		wait = 1
+	}
+	return wait
+}
+
+// IsFull returns whether this bucket is refilled up to the burst of the given
+// limit at the given time. A full bucket is the same as a bucket that does not
+// exist, so it can be removed.
+func (b *TokenBucket) IsFull(limit RateLimit, now gotime.Time) bool {
+	refill := now.Sub(b.UpdatedAt).Seconds() * limit.RatePerSec
+	return b.Tokens+refill >= float64(limit.Burst)
+}
+
+// RateLimiter limits the rate of requests by key with token buckets.
+type RateLimiter interface {
+	// Allow takes a token from the bucket of the given key. It returns zero
+	// if the request is allowed, otherwise the duration to wait for the next
+	// token.
+	Allow(ctx context.Context, key string, limit RateLimit) (gotime.Duration, error)
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sync_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	gotime "time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync/memory"
+)
+
+func TestRateLimit(t *testing.T) {
+	t.Run("parse rate limit test", func(t *testing.T) {
+		limit, err := sync.ParseRateLimit("10:20")
+		assert.NoError(t, err)
+		assert.Equal(t, sync.RateLimit{RatePerSec: 10, Burst: 20}, limit)
+
+		limit, err = sync.ParseRateLimit("0.5")
+		assert.NoError(t, err)
+		assert.Equal(t, sync.RateLimit{RatePerSec: 0.5, Burst: 1}, limit)
+
+		for _, value := range []string{"", "a", "0", "-1:2", "1:0", "1:a", "1:"} {
+			_, err = sync.ParseRateLimit(value)
+			assert.True(t, errors.Is(err, sync.ErrInvalidRateLimit), value)
+		}
+	})
+
+	t.Run("token bucket test", func(t *testing.T) {
+		limit := sync.RateLimit{RatePerSec: 2, Burst: 2}
+		now := gotime.Now()
+		bucket := sync.NewTokenBucket(limit, now)
+
+		assert.Equal(t, gotime.Duration(0), bucket.Take(limit, now))
+		assert.Equal(t, gotime.Duration(0), bucket.Take(limit, now))
+		assert.Equal(t, 500*gotime.Millisecond, bucket.Take(limit, now))
+		assert.False(t, bucket.IsFull(limit, now))
+
+		// refilled at the rate up to the burst.
+		now = now.Add(250 * gotime.Millisecond)
+		assert.Equal(t, 250*gotime.Millisecond, bucket.Take(limit, now))
+		now = now.Add(250 * gotime.Millisecond)
+		assert.Equal(t, gotime.Duration(0), bucket.Take(limit, now))
+		assert.True(t, bucket.IsFull(limit, now.Add(gotime.Second)))
+
+		now = now.Add(10 * gotime.Second)
+		assert.Equal(t, gotime.Duration(0), bucket.Take(limit, now))
+		assert.Equal(t, gotime.Duration(0), bucket.Take(limit, now))
+		assert.NotEqual(t, gotime.Duration(0), bucket.Take(limit, now))
+
+		// not refilled if the bucket is updated in the future.
+		assert.NotEqual(t, gotime.Duration(0), bucket.Take(limit, now.Add(-gotime.Second)))
+	})
+
+	t.Run("memory rate limiter test", func(t *testing.T) {
+		ctx := context.Background()
+		limiter := memory.NewRateLimiter()
+		limit := sync.RateLimit{RatePerSec: 1, Burst: 1}
+
+		wait, err := limiter.Allow(ctx, "PushPull/a", limit)
+		assert.NoError(t, err)
+		assert.Equal(t, gotime.Duration(0), wait)
+
+		wait, err = limiter.Allow(ctx, "PushPull/a", limit)
+		assert.NoError(t, err)
+		assert.NotEqual(t, gotime.Duration(0), wait)
+
+		// the bucket of each key is independent.
+		wait, err = limiter.Allow(ctx, "PushPull/b", limit)
+		assert.NoError(t, err)
+		assert.Equal(t, gotime.Duration(0), wait)
+		assert.Equal(t, 2, limiter.Len())
+	})
+}
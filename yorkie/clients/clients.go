@@ -19,10 +19,12 @@ package clients
 import (
 	"context"
 	"errors"
+	"fmt"
 
-	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/key"
 	"github.com/yorkie-team/yorkie/yorkie/backend"
 	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+	"github.com/yorkie-team/yorkie/yorkie/documents"
 )
 
 var (
@@ -31,6 +33,14 @@ var (
 
 	// ErrInvalidClientID is returned when the given Key is not valid ClientID.
 	ErrInvalidClientID = errors.New("invalid client id")
+
+	// ErrTooManyAttachedDocuments is returned when the client has already
+	// attached as many documents as the limit.
+	ErrTooManyAttachedDocuments = errors.New("too many attached documents")
+
+	// ErrReservedClientKey is returned when the given key is reserved for the
+	// clients of the agent itself.
+	ErrReservedClientKey = errors.New("reserved client key")
 )
 
 // Activate activates the given client.
@@ -39,6 +49,10 @@ func Activate(
 	be *backend.Backend,
 	clientKey string,
 ) (*db.ClientInfo, error) {
+	if clientKey == documents.SystemClientKey {
+		return nil, fmt.Errorf("%s: %w", clientKey, ErrReservedClientKey)
+	}
+
 	return be.DB.ActivateClient(ctx, clientKey)
 }
 
@@ -51,12 +65,35 @@ func Deactivate(
 	return be.DB.DeactivateClient(ctx, db.IDFromBytes(clientID))
 }
 
+// AttachDocument attaches the given document to the given client if the
+// client has attached fewer documents than the limit.
+func AttachDocument(
+	be *backend.Backend,
+	clientInfo *db.ClientInfo,
+	docID db.ID,
+) error {
+	limit := be.Config.MaxAttachedDocumentsPerClient
+	if attached, _ := clientInfo.IsAttached(docID); !attached && limit > 0 &&
+		clientInfo.AttachedDocumentsLen() >= limit {
+		be.Metrics.IncLimitRejections(backend.LimitAttachedDocumentsPerClient)
+		return fmt.Errorf(
+			"%d documents of %s(limit %d): %w",
+			clientInfo.AttachedDocumentsLen(),
+			clientInfo.ID,
+			limit,
+			ErrTooManyAttachedDocuments,
+		)
+	}
+
+	return clientInfo.AttachDocument(docID)
+}
+
 // FindClientAndDocument finds the client and the document.
 func FindClientAndDocument(
 	ctx context.Context,
 	be *backend.Backend,
 	clientID []byte,
-	pack *change.Pack,
+	docKey *key.Key,
 	createDocIfNotExist bool,
 ) (*db.ClientInfo, *db.DocInfo, error) {
 	clientInfo, err := be.DB.FindClientInfoByID(ctx, db.IDFromBytes(clientID))
@@ -67,7 +104,7 @@ func FindClientAndDocument(
 	docInfo, err := be.DB.FindDocInfoByKey(
 		ctx,
 		clientInfo,
-		pack.DocumentKey.BSONKey(),
+		docKey.BSONKey(),
 		createDocIfNotExist,
 	)
 	if err != nil {
@@ -24,7 +24,9 @@ import (
 
 	"github.com/yorkie-team/yorkie/internal/log"
 	"github.com/yorkie-team/yorkie/yorkie/backend"
+	"github.com/yorkie-team/yorkie/yorkie/backend/db/bolt"
 	"github.com/yorkie-team/yorkie/yorkie/backend/db/mongo"
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
 	"github.com/yorkie-team/yorkie/yorkie/backend/sync/etcd"
 	"github.com/yorkie-team/yorkie/yorkie/metrics/prometheus"
 	"github.com/yorkie-team/yorkie/yorkie/rpc"
@@ -32,16 +34,25 @@ import (
 
 // Below are the values of the default values of Yorkie config.
 const (
-	DefaultRPCPort     = 11101
-	DefaultMetricsPort = 11102
+	DefaultRPCPort        = 11101
+	DefaultMetricsPort    = 11102
+	DefaultRPCClusterPort = 11103
 
 	DefaultMongoConnectionURI        = "mongodb://localhost:27017"
 	DefaultMongoConnectionTimeoutSec = 5
 	DefaultMongoPingTimeoutSec       = 5
 	DefaultMongoYorkieDatabase       = "yorkie-meta"
 
+	DefaultBoltPath           = "yorkie.db"
+	DefaultBoltOpenTimeoutSec = 5
+
 	DefaultSnapshotThreshold = 500
 	DefaultSnapshotInterval  = 100
+
+	DefaultSubscriptionQueueSize = sync.DefaultSubscriptionQueueSize
+	DefaultSlowConsumerPolicy    = sync.SlowConsumerResync
+
+	DefaultDocCacheSize = backend.DefaultDocCacheSize
 )
 
 // Config is the configuration for creating a Yorkie instance.
@@ -49,6 +60,7 @@ type Config struct {
 	RPC     *rpc.Config        `json:"RPC"`
 	Metrics *prometheus.Config `json:"Metrics"`
 	Mongo   *mongo.Config      `json:"Mongo"`
+	Bolt    *bolt.Config       `json:"Bolt"`
 	ETCD    *etcd.Config       `json:"ETCD"`
 	Backend *backend.Config    `json:"Backend"`
 }
@@ -58,10 +70,16 @@ func (c *Config) RPCAddr() string {
 	return fmt.Sprintf("localhost:%d", c.RPC.Port)
 }
 
+// ClusterAddr returns the address of the Cluster service that other agents
+// connect to.
+func (c *Config) ClusterAddr() string {
+	return fmt.Sprintf("localhost:%d", c.RPC.ClusterPort)
+}
+
 // NewConfig returns a Config struct that contains reasonable defaults
 // for most of the configurations.
 func NewConfig() *Config {
-	return newConfig(DefaultRPCPort, DefaultMetricsPort, DefaultMongoYorkieDatabase)
+	return newConfig(DefaultRPCPort, DefaultRPCClusterPort, DefaultMetricsPort, DefaultMongoYorkieDatabase)
 }
 
 // NewConfigFromFile returns a Config struct for the given conf file.
@@ -81,10 +99,11 @@ func NewConfigFromFile(path string) (*Config, error) {
 	return conf, nil
 }
 
-func newConfig(port int, metricsPort int, dbName string) *Config {
+func newConfig(port int, clusterPort int, metricsPort int, dbName string) *Config {
 	return &Config{
 		RPC: &rpc.Config{
-			Port: port,
+			Port:        port,
+			ClusterPort: clusterPort,
 		},
 		Metrics: &prometheus.Config{
 			Port: metricsPort,
@@ -92,6 +111,11 @@ func newConfig(port int, metricsPort int, dbName string) *Config {
 		Backend: &backend.Config{
 			SnapshotThreshold: DefaultSnapshotThreshold,
 			SnapshotInterval:  DefaultSnapshotInterval,
+
+			SubscriptionQueueSize: DefaultSubscriptionQueueSize,
+			SlowConsumerPolicy:    DefaultSlowConsumerPolicy,
+
+			DocCacheSize: DefaultDocCacheSize,
 		},
 		Mongo: &mongo.Config{
 			ConnectionURI:        DefaultMongoConnectionURI,
@@ -99,5 +123,9 @@ func newConfig(port int, metricsPort int, dbName string) *Config {
 			PingTimeoutSec:       DefaultMongoPingTimeoutSec,
 			YorkieDatabase:       dbName,
 		},
+		Bolt: &bolt.Config{
+			Path:           DefaultBoltPath,
+			OpenTimeoutSec: DefaultBoltOpenTimeoutSec,
+		},
 	}
 }
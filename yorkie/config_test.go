@@ -38,7 +38,12 @@ func TestNewConfigFromFile(t *testing.T) {
 	assert.Equal(t, conf.Mongo.ConnectionURI, yorkie.DefaultMongoConnectionURI)
 	assert.Equal(t, conf.Mongo.YorkieDatabase, yorkie.DefaultMongoYorkieDatabase)
 	assert.Equal(t, conf.Mongo.PingTimeoutSec, time.Duration(yorkie.DefaultMongoPingTimeoutSec))
+	assert.Equal(t, conf.Bolt.Path, yorkie.DefaultBoltPath)
+	assert.Equal(t, conf.Bolt.OpenTimeoutSec, time.Duration(yorkie.DefaultBoltOpenTimeoutSec))
 	assert.Equal(t, conf.Backend.SnapshotThreshold, uint64(yorkie.DefaultSnapshotThreshold))
+	assert.Equal(t, conf.Backend.SubscriptionQueueSize, yorkie.DefaultSubscriptionQueueSize)
+	assert.Equal(t, conf.Backend.SlowConsumerPolicy, yorkie.DefaultSlowConsumerPolicy)
+	assert.Equal(t, conf.Backend.DocCacheSize, yorkie.DefaultDocCacheSize)
 
 	filePath := "config.sample.json"
 	conf, err = yorkie.NewConfigFromFile(filePath)
@@ -51,5 +56,10 @@ func TestNewConfigFromFile(t *testing.T) {
 	assert.Equal(t, conf.Mongo.ConnectionURI, yorkie.DefaultMongoConnectionURI)
 	assert.Equal(t, conf.Mongo.YorkieDatabase, yorkie.DefaultMongoYorkieDatabase)
 	assert.Equal(t, conf.Mongo.PingTimeoutSec, time.Duration(yorkie.DefaultMongoPingTimeoutSec))
+	assert.Equal(t, conf.Bolt.Path, yorkie.DefaultBoltPath)
+	assert.Equal(t, conf.Bolt.OpenTimeoutSec, time.Duration(yorkie.DefaultBoltOpenTimeoutSec))
 	assert.Equal(t, conf.Backend.SnapshotThreshold, uint64(yorkie.DefaultSnapshotThreshold))
+	assert.Equal(t, conf.Backend.SubscriptionQueueSize, yorkie.DefaultSubscriptionQueueSize)
+	assert.Equal(t, conf.Backend.SlowConsumerPolicy, yorkie.DefaultSlowConsumerPolicy)
+	assert.Equal(t, conf.Backend.DocCacheSize, yorkie.DefaultDocCacheSize)
 }
@@ -0,0 +1,261 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package documents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/yorkie-team/yorkie/pkg/document"
+	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/checkpoint"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+	"github.com/yorkie-team/yorkie/pkg/types"
+	"github.com/yorkie-team/yorkie/yorkie/backend"
+	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+	"github.com/yorkie-team/yorkie/yorkie/packs"
+)
+
+const (
+	// DefaultPageSize is the number of changes in a page of the history when
+	// the page size is not given.
+	DefaultPageSize = 50
+
+	// MaxPageSize is the maximum number of changes in a page of the history.
+	MaxPageSize = 1000
+
+	// SystemClientKey is the key of the client that authors the changes made
+	// by the agent itself such as reverting a document.
+	SystemClientKey = "yorkie-system"
+)
+
+var (
+	// ErrServerSeqOutOfRange is returned when the given server seq is greater
+	// than the server seq of the document.
+	ErrServerSeqOutOfRange = errors.New("server seq out of range")
+)
+
+// FindChangeSummaries returns the summaries of the changes of the given
+// document starting from the given server seq, and the server seq of the next
+// page. The server seq of the next page is zero if there are no more changes.
+func FindChangeSummaries(
+	ctx context.Context,
+	be *backend.Backend,
+	docInfo *db.DocInfo,
+	from uint64,
+	pageSize uint32,
+) ([]*types.ChangeSummary, uint64, error) {
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	} else if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	if from == 0 {
+		from = 1
+	}
+	if from > docInfo.ServerSeq {
+		return nil, 0, nil
+	}
+
+	// NOTE: Changes stored past the server seq of the document are
+	// orphaned changes left by a failed push, so they are excluded.
+	to := from + uint64(pageSize) - 1
+	if to > docInfo.ServerSeq {
+		to = docInfo.ServerSeq
+	}
+
+	infos, err := be.DB.FindChangeInfos(ctx, docInfo.ID, from, to)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var summaries []*types.ChangeSummary
+	for _, info := range infos {
+		actorID, err := time.ActorIDFromHex(info.Actor.String())
+		if err != nil {
+			return nil, 0, err
+		}
+
+		summaries = append(summaries, &types.ChangeSummary{
+			ServerSeq: info.ServerSeq,
+			Actor:     actorID,
+			Message:   info.Message,
+			CreatedAt: info.CreatedAt,
+		})
+	}
+
+	var next uint64
+	if to < docInfo.ServerSeq {
+		next = to + 1
+	}
+
+	return summaries, next, nil
+}
+
+// BuildDocumentAt builds the given document as it was at the given server seq
+// from the closest snapshot and the changes after it.
+func BuildDocumentAt(
+	ctx context.Context,
+	be *backend.Backend,
+	docInfo *db.DocInfo,
+	serverSeq uint64,
+) (*document.InternalDocument, error) {
+	if serverSeq > docInfo.ServerSeq {
+		return nil, fmt.Errorf(
+			"server seq(document %d, requested %d): %w",
+			docInfo.ServerSeq,
+			serverSeq,
+			ErrServerSeqOutOfRange,
+		)
+	}
+
+	docKey, err := docInfo.GetKey()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotInfo, err := be.DB.FindClosestSnapshotInfo(ctx, docInfo.ID, serverSeq)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := document.NewInternalDocumentFromSnapshot(
+		docKey.Collection,
+		docKey.Document,
+		snapshotInfo.ServerSeq,
+		snapshotInfo.Snapshot,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshotInfo.ServerSeq == serverSeq {
+		return doc, nil
+	}
+
+	changes, err := be.DB.FindChangeInfosBetweenServerSeqs(
+		ctx,
+		docInfo.ID,
+		snapshotInfo.ServerSeq+1,
+		serverSeq,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := doc.ApplyChangePack(change.NewPack(
+		docKey,
+		checkpoint.Initial.NextServerSeq(serverSeq),
+		changes,
+		nil,
+	)); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// Revert reverts the given document to the state at the given server seq. It
+// pushes the difference between the current state and the state at the given
+// server seq as a change of the system client, so the clients receive it like
+// any other change. The change is validated like the changes of clients, so
+// the reverted document should conform to the schema of its collection and be
+// within the size limit. It returns the server seq of the document after
+// reverting.
+//
+// NOTE: The caller should hold the lock of the document to push
+// the change.
+func Revert(
+	ctx context.Context,
+	be *backend.Backend,
+	docInfo *db.DocInfo,
+	serverSeq uint64,
+) (uint64, error) {
+	current, err := BuildDocumentAt(ctx, be, docInfo, docInfo.ServerSeq)
+	if err != nil {
+		return 0, err
+	}
+
+	past, err := BuildDocumentAt(ctx, be, docInfo, serverSeq)
+	if err != nil {
+		return 0, err
+	}
+
+	clientInfo, err := be.DB.ActivateClient(ctx, SystemClientKey)
+	if err != nil {
+		return 0, err
+	}
+	actorID, err := time.ActorIDFromHex(clientInfo.ID.String())
+	if err != nil {
+		return 0, err
+	}
+
+	// NOTE: The system client stays attached to the documents it has
+	// reverted, so that its checkpoint keeps the client seq between reverts.
+	isAttached, err := clientInfo.IsAttached(docInfo.ID)
+	if err != nil && !errors.Is(err, db.ErrDocumentNeverAttached) {
+		return 0, err
+	}
+	if !isAttached {
+		if err := clientInfo.AttachDocument(docInfo.ID); err != nil {
+			return 0, err
+		}
+	}
+
+	// NOTE: The change should win over every change of the
+	// document, so its lamport should be greater than theirs. The current
+	// document is built from the last snapshot and the changes after it, so
+	// it knows the lamports without loading the whole history.
+	lamport := current.MaxLamport()
+	clientSeq := clientInfo.Checkpoint(docInfo.ID).ClientSeq + 1
+
+	c := current.CreateDiffChange(
+		change.NewID(clientSeq, lamport+1, actorID),
+		fmt.Sprintf("revert to %d", serverSeq),
+		past.RootObject(),
+	)
+	if c == nil {
+		return docInfo.ServerSeq, nil
+	}
+
+	docKey, err := docInfo.GetKey()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := packs.PushPull(ctx, be, clientInfo, docInfo, change.NewPack(
+		docKey,
+		checkpoint.New(docInfo.ServerSeq, c.ClientSeq()),
+		[]*change.Change{c},
+		nil,
+	)); err != nil {
+		return 0, err
+	}
+
+	// NOTE: The system client does not read the document after
+	// reverting, so its synced seq is removed to not hold garbage collection.
+	detached := clientInfo.DeepCopy()
+	if err := detached.DetachDocument(docInfo.ID); err != nil {
+		return 0, err
+	}
+	if _, err := be.DB.UpdateAndFindMinSyncedTicket(ctx, detached, docInfo.ID, 0); err != nil {
+		return 0, err
+	}
+
+	return docInfo.ServerSeq, nil
+}
@@ -35,4 +35,36 @@ type Metrics interface {
 
 	// SetPushPullSnapshotBytes sets the snapshot byte size.
 	SetPushPullSnapshotBytes(bytes int)
+
+	// IncPubSubCoalescedEvents increases the number of events coalesced into
+	// events already queued for a subscription.
+	IncPubSubCoalescedEvents()
+
+	// AddPubSubDroppedEvents adds the number of events dropped because the
+	// queue of a subscription is full.
+	AddPubSubDroppedEvents(count int)
+
+	// IncPubSubSlowConsumers increases the number of slow consumers handled
+	// by the given policy.
+	IncPubSubSlowConsumers(policy string)
+
+	// IncBroadcastEventFailures increases the number of events that could
+	// not be delivered to other agents.
+	IncBroadcastEventFailures()
+
+	// IncBroadcastEventRetries increases the number of retried BroadcastEvent
+	// calls to other agents.
+	IncBroadcastEventRetries()
+
+	// IncClusterMemberEvictions increases the number of members evicted
+	// because the calls to them kept failing.
+	IncClusterMemberEvictions()
+
+	// IncLimitRejections increases the number of requests rejected because
+	// they exceed the given limit.
+	IncLimitRejections(limit string)
+
+	// IncRateLimitRejections increases the number of requests of the given
+	// RPC method rejected by the rate limit.
+	IncRateLimitRejections(method string)
 }
@@ -38,6 +38,17 @@ type Metrics struct {
 	pushPullSentChanges             prometheus.Gauge
 	pushPullSnapshotDurationSeconds prometheus.Histogram
 	pushPullSnapshotBytes           prometheus.Gauge
+
+	pubSubCoalescedEvents prometheus.Counter
+	pubSubDroppedEvents   prometheus.Counter
+	pubSubSlowConsumers   *prometheus.CounterVec
+
+	broadcastEventFailures prometheus.Counter
+	broadcastEventRetries  prometheus.Counter
+	clusterMemberEvictions prometheus.Counter
+
+	limitRejections     *prometheus.CounterVec
+	rateLimitRejections *prometheus.CounterVec
 }
 
 // NewMetrics creates a new instance of Metrics.
@@ -80,6 +91,54 @@ func NewMetrics() *Metrics {
 			Name:      "pushpull_snapshot_bytes",
 			Help:      "The number of bytes of Snapshot.",
 		}),
+		pubSubCoalescedEvents: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "pubsub",
+			Name:      "coalesced_events_total",
+			Help:      "The total number of events coalesced into queued events of subscriptions.",
+		}),
+		pubSubDroppedEvents: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "pubsub",
+			Name:      "dropped_events_total",
+			Help:      "The total number of events dropped because the queue of a subscription is full.",
+		}),
+		pubSubSlowConsumers: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "pubsub",
+			Name:      "slow_consumers_total",
+			Help:      "The total number of slow consumers handled by the slow consumer policy.",
+		}, []string{"policy"}),
+		broadcastEventFailures: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "cluster",
+			Name:      "broadcast_event_failures_total",
+			Help:      "The total number of events that could not be delivered to other agents.",
+		}),
+		broadcastEventRetries: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "cluster",
+			Name:      "broadcast_event_retries_total",
+			Help:      "The total number of retried BroadcastEvent calls to other agents.",
+		}),
+		clusterMemberEvictions: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "cluster",
+			Name:      "member_evictions_total",
+			Help:      "The total number of members evicted because the calls to them kept failing.",
+		}),
+		limitRejections: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "limits",
+			Name:      "rejections_total",
+			Help:      "The total number of requests rejected because they exceed the limit.",
+		}, []string{"limit"}),
+		rateLimitRejections: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "limits",
+			Name:      "rate_limit_rejections_total",
+			Help:      "The total number of requests rejected by the rate limit of the RPC method.",
+		}, []string{"method"}),
 	}
 
 	metrics.agentVersion.With(prometheus.Labels{
@@ -117,6 +176,60 @@ func (m *Metrics) SetPushPullSnapshotBytes(bytes int) {
 	m.pushPullSnapshotBytes.Set(float64(bytes))
 }
 
+// IncPubSubCoalescedEvents increases the number of events coalesced into
+// events already queued for a subscription.
+func (m *Metrics) IncPubSubCoalescedEvents() {
+	m.pubSubCoalescedEvents.Inc()
+}
+
+// AddPubSubDroppedEvents adds the number of events dropped because the
+// queue of a subscription is full.
+func (m *Metrics) AddPubSubDroppedEvents(count int) {
+	m.pubSubDroppedEvents.Add(float64(count))
+}
+
+// IncPubSubSlowConsumers increases the number of slow consumers handled
+// by the given policy.
+func (m *Metrics) IncPubSubSlowConsumers(policy string) {
+	m.pubSubSlowConsumers.With(prometheus.Labels{
+		"policy": policy,
+	}).Inc()
+}
+
+// IncBroadcastEventFailures increases the number of events that could
+// not be delivered to other agents.
+func (m *Metrics) IncBroadcastEventFailures() {
+	m.broadcastEventFailures.Inc()
+}
+
+// IncBroadcastEventRetries increases the number of retried BroadcastEvent
+// calls to other agents.
+func (m *Metrics) IncBroadcastEventRetries() {
+	m.broadcastEventRetries.Inc()
+}
+
+// IncClusterMemberEvictions increases the number of members evicted
+// because the calls to them kept failing.
+func (m *Metrics) IncClusterMemberEvictions() {
+	m.clusterMemberEvictions.Inc()
+}
+
+// IncLimitRejections increases the number of requests rejected because
+// they exceed the given limit.
+func (m *Metrics) IncLimitRejections(limit string) {
+	m.limitRejections.With(prometheus.Labels{
+		"limit": limit,
+	}).Inc()
+}
+
+// IncRateLimitRejections increases the number of requests of the given RPC
+// method rejected by the rate limit.
+func (m *Metrics) IncRateLimitRejections(method string) {
+	m.rateLimitRejections.With(prometheus.Labels{
+		"method": method,
+	}).Inc()
+}
+
 // Registry returns the registry of this metrics.
 func (m *Metrics) Registry() *prometheus.Registry {
 	return m.registry
@@ -27,7 +27,9 @@ import (
 	"github.com/yorkie-team/yorkie/pkg/document"
 	"github.com/yorkie-team/yorkie/pkg/document/change"
 	"github.com/yorkie-team/yorkie/pkg/document/checkpoint"
+	"github.com/yorkie-team/yorkie/pkg/document/json"
 	"github.com/yorkie-team/yorkie/pkg/document/key"
+	"github.com/yorkie-team/yorkie/pkg/document/operation"
 	"github.com/yorkie-team/yorkie/pkg/document/time"
 	"github.com/yorkie-team/yorkie/pkg/types"
 	"github.com/yorkie-team/yorkie/yorkie/backend"
@@ -39,6 +41,30 @@ var (
 	// ErrInvalidServerSeq is returned when the given server seq greater than
 	// the initial server seq.
 	ErrInvalidServerSeq = errors.New("invalid server seq")
+
+	// ErrClientSeqGap is returned when some changes are missing between the
+	// checkpoint of the client and the changes of the given pack.
+	ErrClientSeqGap = errors.New("client seq gap")
+
+	// ErrChangesOutOfOrder is returned when the changes of the given pack are
+	// not sorted by client seq.
+	ErrChangesOutOfOrder = errors.New("changes out of order")
+
+	// ErrInvalidChange is returned when a change of the given pack is not
+	// created by the client or cannot be applied to the document.
+	ErrInvalidChange = errors.New("invalid change")
+
+	// ErrTooManyChanges is returned when the given pack has more changes than
+	// the limit.
+	ErrTooManyChanges = errors.New("too many changes")
+
+	// ErrPackTooLarge is returned when the given pack is larger than the
+	// limit.
+	ErrPackTooLarge = errors.New("pack too large")
+
+	// ErrDocumentTooLarge is returned when the document after applying the
+	// changes of the given pack is larger than the limit.
+	ErrDocumentTooLarge = errors.New("document too large")
 )
 
 // NewPushPullKey creates a new sync.Key of PushPull for the given document.
@@ -60,9 +86,23 @@ func PushPull(
 	docInfo *db.DocInfo,
 	reqPack *change.Pack,
 ) (*change.Pack, error) {
-	// TODO: Changes may be reordered or missing during communication on the network.
-	// We should check the change.pack with checkpoint to make sure the changes are in the correct order.
+	if limit := be.Config.MaxChangesPerPack; limit > 0 && len(reqPack.Changes) > limit {
+		be.Metrics.IncLimitRejections(backend.LimitChangesPerPack)
+		return nil, fmt.Errorf("%d changes(limit %d): %w", len(reqPack.Changes), limit, ErrTooManyChanges)
+	}
+
+	// NOTE: Changes may be reordered or missing during communication on the
+	// network, so the pack is checked with the checkpoints before pushing.
 	initialServerSeq := docInfo.ServerSeq
+	if err := validatePack(clientInfo.Checkpoint(docInfo.ID), reqPack, initialServerSeq); err != nil {
+		return nil, err
+	}
+
+	// NOTE: The changes are executed on the cached document before they are
+	// stored, so that an invalid change does not break other replicas.
+	if err := validateChanges(ctx, be, clientInfo, docInfo, reqPack, initialServerSeq); err != nil {
+		return nil, err
+	}
 
 	// 01. push changes.
 	pushedCP, pushedChanges, err := pushChanges(clientInfo, docInfo, reqPack, initialServerSeq)
@@ -80,31 +120,43 @@ func PushPull(
 		return nil, err
 	}
 
-	// 03. store pushed changes, document info and checkpoint of the client to DB.
+	// 03. store pushed changes, document info and checkpoint of the client to
+	//     DB, then find min synced ticket for garbage collection.
+	// NOTE Since the client could not receive the PushPull response,
+	//      the requested seq(reqPack) is stored instead of the response seq(resPack).
 	if len(pushedChanges) > 0 {
-		if err := be.DB.StoreChangeInfos(ctx, docInfo, initialServerSeq, pushedChanges); err != nil {
+		if err := be.DB.StoreChangeInfos(
+			ctx,
+			clientInfo,
+			docInfo,
+			initialServerSeq,
+			pushedChanges,
+			reqPack.Checkpoint.ServerSeq,
+		); err != nil {
 			return nil, err
 		}
-	}
 
-	if err := be.DB.UpdateClientInfoAfterPushPull(ctx, clientInfo, docInfo); err != nil {
-		return nil, err
-	}
+		respPack.MinSyncedTicket, err = be.DB.FindMinSyncedTicket(ctx, docInfo.ID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if err := be.DB.UpdateClientInfoAfterPushPull(ctx, clientInfo, docInfo); err != nil {
+			return nil, err
+		}
 
-	// 04. update and find min synced ticket for garbage collection.
-	// NOTE Since the client could not receive the PushPull response,
-	//      the requested seq(reqPack) is stored instead of the response seq(resPack).
-	respPack.MinSyncedTicket, err = be.DB.UpdateAndFindMinSyncedTicket(
-		ctx,
-		clientInfo,
-		docInfo.ID,
-		reqPack.Checkpoint.ServerSeq,
-	)
-	if err != nil {
-		return nil, err
+		respPack.MinSyncedTicket, err = be.DB.UpdateAndFindMinSyncedTicket(
+			ctx,
+			clientInfo,
+			docInfo.ID,
+			reqPack.Checkpoint.ServerSeq,
+		)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// 05. publish document change event then store snapshot asynchronously.
+	// 04. publish document change event then store snapshot asynchronously.
 	if reqPack.HasChanges() {
 		be.AttachGoroutine(func() {
 			publisherID, err := time.ActorIDFromHex(clientInfo.ID.String())
@@ -114,7 +166,16 @@ func PushPull(
 			}
 
 			ctx := context.Background()
-			// TODO(hackerwins): We need to replace Lock with TryLock.
+			be.Coordinator.Publish(
+				ctx,
+				publisherID,
+				sync.DocEvent{
+					Type:         types.DocumentsChangedEvent,
+					Publisher:    types.Client{ID: publisherID},
+					DocumentKeys: []*key.Key{reqPack.DocumentKey},
+				},
+			)
+
 			// If the snapshot is already being created by another routine, it
 			// is not necessary to recreate it, so we can skip it.
 			locker, err := be.Coordinator.NewLocker(
@@ -125,10 +186,14 @@ func PushPull(
 				log.Logger.Error(err)
 				return
 			}
-			if err := locker.Lock(ctx); err != nil {
+			locked, err := locker.TryLock(ctx)
+			if err != nil {
 				log.Logger.Error(err)
 				return
 			}
+			if !locked {
+				return
+			}
 
 			defer func() {
 				if err := locker.Unlock(ctx); err != nil {
@@ -137,16 +202,6 @@ func PushPull(
 				}
 			}()
 
-			be.Coordinator.Publish(
-				ctx,
-				publisherID,
-				sync.DocEvent{
-					Type:         types.DocumentsChangedEvent,
-					Publisher:    types.Client{ID: publisherID},
-					DocumentKeys: []*key.Key{reqPack.DocumentKey},
-				},
-			)
-
 			if err := storeSnapshot(
 				ctx,
 				be,
@@ -160,6 +215,356 @@ func PushPull(
 	return respPack, nil
 }
 
+// AttachCheckpoint returns the checkpoint of the client for the document
+// attached with the given pack. The checkpoint of the client is reset when the
+// document is detached, but the document can be attached again with the
+// changes that follow the changes pushed before. So the client seq before the
+// changes of the pack is taken as the checkpoint, and the changes pushed
+// after attaching are checked with it.
+func AttachCheckpoint(pack *change.Pack) *checkpoint.Checkpoint {
+	clientSeq := pack.Checkpoint.ClientSeq
+	if pack.HasChanges() && pack.Changes[0].ClientSeq() > 0 {
+		clientSeq = pack.Changes[0].ClientSeq() - 1
+	}
+
+	return checkpoint.New(0, clientSeq)
+}
+
+// validatePack checks whether the changes of the given pack follow the given
+// checkpoint of the client without reordering or missing changes, and whether
+// the checkpoint of the pack is not ahead of the document.
+func validatePack(
+	cp *checkpoint.Checkpoint,
+	pack *change.Pack,
+	initialServerSeq uint64,
+) error {
+	if initialServerSeq < pack.Checkpoint.ServerSeq {
+		return fmt.Errorf(
+			"server seq(initial %d, request pack %d): %w",
+			initialServerSeq,
+			pack.Checkpoint.ServerSeq,
+			ErrInvalidServerSeq,
+		)
+	}
+
+	if !pack.HasChanges() {
+		return nil
+	}
+
+	for i := 1; i < len(pack.Changes); i++ {
+		prev, curr := pack.Changes[i-1].ClientSeq(), pack.Changes[i].ClientSeq()
+		if curr <= prev {
+			return fmt.Errorf("client seq(%d after %d): %w", curr, prev, ErrChangesOutOfOrder)
+		}
+		if curr != prev+1 {
+			return fmt.Errorf("client seq(%d after %d): %w", curr, prev, ErrClientSeqGap)
+		}
+	}
+
+	last := pack.Changes[len(pack.Changes)-1].ClientSeq()
+	if last != pack.Checkpoint.ClientSeq {
+		return fmt.Errorf(
+			"client seq(last change %d, request pack %d): %w",
+			last,
+			pack.Checkpoint.ClientSeq,
+			ErrClientSeqGap,
+		)
+	}
+
+	first := pack.Changes[0].ClientSeq()
+	if first > cp.ClientSeq+1 {
+		return fmt.Errorf(
+			"client seq(first change %d, checkpoint %d): %w",
+			first,
+			cp.ClientSeq,
+			ErrClientSeqGap,
+		)
+	}
+
+	return nil
+}
+
+// validateChanges checks whether the changes of the given pack not yet pushed
+// are created by the client and can be applied to the document, and whether
+// the document after applying them conforms to the schema of its collection
+// and is within the size limit.
+func validateChanges(
+	ctx context.Context,
+	be *backend.Backend,
+	clientInfo *db.ClientInfo,
+	docInfo *db.DocInfo,
+	pack *change.Pack,
+	initialServerSeq uint64,
+) error {
+	cp := clientInfo.Checkpoint(docInfo.ID)
+
+	var changes []*change.Change
+	for _, c := range pack.Changes {
+		if c.ClientSeq() > cp.ClientSeq {
+			changes = append(changes, c)
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	actorID, err := time.ActorIDFromHex(clientInfo.ID.String())
+	if err != nil {
+		return err
+	}
+
+	for _, c := range changes {
+		if c.ID().Actor().Compare(actorID) != 0 {
+			return fmt.Errorf(
+				"change(clientSeq %d) of actor %s: %w",
+				c.ClientSeq(),
+				c.ID().Actor().String(),
+				ErrInvalidChange,
+			)
+		}
+
+		for i, op := range c.Operations() {
+			for _, ticket := range createdTicketsOf(op) {
+				if ticket == nil {
+					return fmt.Errorf(
+						"change(clientSeq %d) operation %d without ticket: %w",
+						c.ClientSeq(),
+						i,
+						ErrInvalidChange,
+					)
+				}
+				if ticket.ActorID().Compare(actorID) != 0 {
+					return fmt.Errorf(
+						"change(clientSeq %d) operation %d of actor %s: %w",
+						c.ClientSeq(),
+						i,
+						ticket.ActorIDHex(),
+						ErrInvalidChange,
+					)
+				}
+			}
+		}
+	}
+
+	cached, err := loadDocument(ctx, be, docInfo, initialServerSeq)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: The changes are applied to the cached document without copying
+	// it. The document is removed from the cache if the changes are rejected,
+	// and it is built again by the next push. If the changes are accepted but
+	// not stored, the document is ahead of the server seq of the next push,
+	// so it is also built again.
+	if err := validateDocument(be, cached, pack, changes, initialServerSeq); err != nil {
+		be.DocCache.Remove(docInfo.ID.String())
+		return err
+	}
+
+	return nil
+}
+
+// validateDocument applies the given changes to the given cached document,
+// and checks whether the document conforms to the schema of its collection
+// and is within the size limit.
+func validateDocument(
+	be *backend.Backend,
+	cached *cachedDocument,
+	pack *change.Pack,
+	changes []*change.Change,
+	initialServerSeq uint64,
+) error {
+	serverSeq := initialServerSeq + uint64(len(changes))
+	if err := cached.doc.ApplyPushedChanges(changes, serverSeq); err != nil {
+		return fmt.Errorf("%s: %w", err.Error(), ErrInvalidChange)
+	}
+
+	root := cached.doc.RootObject()
+	if err := be.Schemas.Validate(pack.DocumentKey.Collection, root); err != nil {
+		return err
+	}
+
+	if err := cached.addBytes(changes); err != nil {
+		return err
+	}
+
+	limit := be.Config.MaxDocumentBytes
+	if limit <= 0 || cached.bytes <= limit {
+		return nil
+	}
+
+	// NOTE: The estimated size exceeds the limit, so the document is
+	// measured exactly. It happens only with the documents close to the limit.
+	snapshot, err := converter.ObjectToBytes(root)
+	if err != nil {
+		return err
+	}
+	cached.bytes = len(snapshot)
+	if cached.bytes > limit {
+		be.Metrics.IncLimitRejections(backend.LimitDocumentBytes)
+		return fmt.Errorf("%d bytes(limit %d): %w", cached.bytes, limit, ErrDocumentTooLarge)
+	}
+
+	return nil
+}
+
+// createdTicketsOf returns the tickets that the given operation is executed
+// or creates elements with. The elements given with the operation come from
+// the client, so their tickets are checked as well as the execution time. A
+// missing ticket or element is returned as a nil ticket.
+func createdTicketsOf(op operation.Operation) []*time.Ticket {
+	tickets := []*time.Ticket{op.ExecutedAt()}
+
+	appendElement := func(elem json.Element) {
+		if elem == nil {
+			tickets = append(tickets, nil)
+			return
+		}
+
+		tickets = append(tickets, elem.CreatedAt())
+		if container, ok := elem.(json.Container); ok {
+			container.Descendants(func(elem json.Element, parent json.Container) bool {
+				tickets = append(tickets, elem.CreatedAt())
+				return false
+			})
+		}
+	}
+
+	switch op := op.(type) {
+	case *operation.Set:
+		appendElement(op.Value())
+	case *operation.Add:
+		appendElement(op.Value())
+	case *operation.TreeInsert:
+		var appendNode func(node *json.TreeNode)
+		appendNode = func(node *json.TreeNode) {
+			if node == nil {
+				tickets = append(tickets, nil)
+				return
+			}
+
+			tickets = append(tickets, node.ID())
+			for _, child := range node.AllChildren() {
+				appendNode(child)
+			}
+		}
+		appendNode(op.Node())
+	}
+
+	return tickets
+}
+
+// CheckPackBytes checks whether the given size of a pack pushed by a client
+// is within the limit.
+func CheckPackBytes(be *backend.Backend, bytes int) error {
+	if limit := be.Config.MaxPackBytes; limit > 0 && bytes > limit {
+		be.Metrics.IncLimitRejections(backend.LimitPackBytes)
+		return fmt.Errorf("%d bytes(limit %d): %w", bytes, limit, ErrPackTooLarge)
+	}
+
+	return nil
+}
+
+// cachedDocument is a document cached to validate the changes pushed by
+// clients.
+type cachedDocument struct {
+	doc *document.InternalDocument
+
+	// bytes is the estimated size of the snapshot of the document. It starts
+	// from the size of the snapshot that the document is built from, and the
+	// size of the operations applied after that is added as the growth of
+	// the snapshot, so that the document is not serialized on every push.
+	bytes int
+}
+
+// addBytes adds the size of the operations of the given changes to the
+// estimated size of the document.
+func (c *cachedDocument) addBytes(changes []*change.Change) error {
+	for _, ch := range changes {
+		pbOps, err := converter.ToOperations(ch.Operations())
+		if err != nil {
+			return err
+		}
+		for _, pbOp := range pbOps {
+			c.bytes += pbOp.Size()
+		}
+	}
+
+	return nil
+}
+
+// loadDocument returns the cached document of the given docInfo that applies
+// the changes up to the given server seq. If the document is not cached, it
+// is built from the last snapshot.
+func loadDocument(
+	ctx context.Context,
+	be *backend.Backend,
+	docInfo *db.DocInfo,
+	serverSeq uint64,
+) (*cachedDocument, error) {
+	cacheKey := docInfo.ID.String()
+
+	var cached *cachedDocument
+	if value, ok := be.DocCache.Get(cacheKey); ok {
+		cached = value.(*cachedDocument)
+	}
+
+	docKey, err := docInfo.GetKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: The cached document can be ahead of the given server
+	// seq when the changes past the server seq of the document are deleted
+	// by the repair, so it is built again.
+	if cached == nil || cached.doc.Checkpoint().ServerSeq > serverSeq {
+		snapshotInfo, err := be.DB.FindLastSnapshotInfo(ctx, docInfo.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		doc, err := document.NewInternalDocumentFromSnapshot(
+			docKey.Collection,
+			docKey.Document,
+			snapshotInfo.ServerSeq,
+			snapshotInfo.Snapshot,
+		)
+		if err != nil {
+			return nil, err
+		}
+		cached = &cachedDocument{doc: doc, bytes: len(snapshotInfo.Snapshot)}
+	}
+
+	if cached.doc.Checkpoint().ServerSeq < serverSeq {
+		changes, err := be.DB.FindChangeInfosBetweenServerSeqs(
+			ctx,
+			docInfo.ID,
+			cached.doc.Checkpoint().ServerSeq+1,
+			serverSeq,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := cached.doc.ApplyChangePack(change.NewPack(
+			docKey,
+			checkpoint.Initial.NextServerSeq(serverSeq),
+			changes,
+			nil,
+		)); err != nil {
+			be.DocCache.Remove(cacheKey)
+			return nil, err
+		}
+		if err := cached.addBytes(changes); err != nil {
+			be.DocCache.Remove(cacheKey)
+			return nil, err
+		}
+	}
+
+	be.DocCache.Add(cacheKey, cached)
+	return cached, nil
+}
+
 // pushChanges returns the changes excluding already saved in DB.
 func pushChanges(
 	clientInfo *db.ClientInfo,
@@ -177,7 +582,9 @@ func pushChanges(
 			c.SetServerSeq(serverSeq)
 			pushedChanges = append(pushedChanges, c)
 		} else {
-			log.Logger.Warnf("change is rejected: %d vs %d ", c.ID().ClientSeq(), cp.ClientSeq)
+			// NOTE: The change was already pushed, but the client did not
+			// receive the response and sent it again.
+			log.Logger.Debugf("change is already pushed: %d vs %d ", c.ID().ClientSeq(), cp.ClientSeq)
 		}
 
 		cp = cp.SyncClientSeq(c.ClientSeq())
@@ -213,15 +620,6 @@ func pullPack(
 		return nil, err
 	}
 
-	if initialServerSeq < requestPack.Checkpoint.ServerSeq {
-		return nil, fmt.Errorf(
-			"server seq(initial %d, request pack %d): %w",
-			initialServerSeq,
-			requestPack.Checkpoint.ServerSeq,
-			ErrInvalidServerSeq,
-		)
-	}
-
 	if initialServerSeq-requestPack.Checkpoint.ServerSeq < be.Config.SnapshotThreshold {
 		pulledCP, pulledChanges, err := pullChanges(ctx, be, clientInfo, docInfo, requestPack, pushedCP, initialServerSeq)
 		if err != nil {
@@ -425,3 +823,58 @@ func storeSnapshot(
 	be.Metrics.ObservePushPullSnapshotDurationSeconds(gotime.Since(start).Seconds())
 	return nil
 }
+
+// RepairDocuments deletes the changes stored past the server seq of their
+// documents. They are left when the agent stops while storing changes without
+// a transaction, and the clients send them again because they did not receive
+// the response.
+func RepairDocuments(ctx context.Context, be *backend.Backend) error {
+	docInfos, err := be.DB.FindDocInfosWithOrphanedChanges(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, docInfo := range docInfos {
+		if err := repairDocument(ctx, be, docInfo); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func repairDocument(ctx context.Context, be *backend.Backend, docInfo *db.DocInfo) error {
+	docKey, err := docInfo.GetKey()
+	if err != nil {
+		return err
+	}
+
+	// NOTE: Another agent may be storing changes of the document, so the
+	// changes are deleted while holding the lock of PushPull.
+	locker, err := be.Coordinator.NewLocker(ctx, NewPushPullKey(docKey))
+	if err != nil {
+		return err
+	}
+	if err := locker.Lock(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if err := locker.Unlock(ctx); err != nil {
+			log.Logger.Error(err)
+		}
+	}()
+
+	deleted, err := be.DB.DeleteOrphanedChangeInfos(ctx, docInfo.ID)
+	if err != nil {
+		return err
+	}
+
+	log.Logger.Infof(
+		"REPAIR: deleted %d changes of '%s' past serverSeq %d",
+		deleted,
+		docInfo.Key,
+		docInfo.ServerSeq,
+	)
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/yorkie-team/yorkie/internal/log"
 	"github.com/yorkie-team/yorkie/pkg/document/time"
 	"github.com/yorkie-team/yorkie/yorkie/backend"
+	"github.com/yorkie-team/yorkie/yorkie/packs"
 )
 
 // clusterServer is a normal server that processes the broadcast by the agent.
@@ -45,3 +46,72 @@ func (s *clusterServer) BroadcastEvent(
 
 	return &api.BroadcastEventResponse{}, nil
 }
+
+// AttachDocument attaches the given document to the client. It is forwarded
+// from other agents, since this agent owns the document.
+func (s *clusterServer) AttachDocument(
+	ctx context.Context,
+	req *api.AttachDocumentRequest,
+) (*api.AttachDocumentResponse, error) {
+	if err := packs.CheckPackBytes(s.backend, req.ChangePack.Size()); err != nil {
+		return nil, err
+	}
+
+	pack, err := converter.FromChangePack(req.ChangePack)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachDocument(ctx, s.backend, req.ClientId, pack)
+}
+
+// DetachDocument detaches the given document from the client. It is forwarded
+// from other agents, since this agent owns the document.
+func (s *clusterServer) DetachDocument(
+	ctx context.Context,
+	req *api.DetachDocumentRequest,
+) (*api.DetachDocumentResponse, error) {
+	if err := packs.CheckPackBytes(s.backend, req.ChangePack.Size()); err != nil {
+		return nil, err
+	}
+
+	pack, err := converter.FromChangePack(req.ChangePack)
+	if err != nil {
+		return nil, err
+	}
+
+	return detachDocument(ctx, s.backend, req.ClientId, pack)
+}
+
+// PushPull stores the changes sent by the client and delivers the changes
+// accumulated in the agent to the client. It is forwarded from other agents,
+// since this agent owns the document.
+func (s *clusterServer) PushPull(
+	ctx context.Context,
+	req *api.PushPullRequest,
+) (*api.PushPullResponse, error) {
+	if err := packs.CheckPackBytes(s.backend, req.ChangePack.Size()); err != nil {
+		return nil, err
+	}
+
+	pack, err := converter.FromChangePack(req.ChangePack)
+	if err != nil {
+		return nil, err
+	}
+
+	return pushPull(ctx, s.backend, req.ClientId, pack)
+}
+
+// RevertDocument reverts the document to the state at the given server seq.
+// It is forwarded from other agents, since this agent owns the document.
+func (s *clusterServer) RevertDocument(
+	ctx context.Context,
+	req *api.RevertDocumentRequest,
+) (*api.RevertDocumentResponse, error) {
+	docKey, err := converter.FromDocumentKey(req.DocumentKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return revertDocument(ctx, s.backend, req.ClientId, docKey, req.ServerSeq)
+}
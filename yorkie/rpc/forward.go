@@ -0,0 +1,145 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/yorkie-team/yorkie/api"
+	"github.com/yorkie-team/yorkie/internal/log"
+	"github.com/yorkie-team/yorkie/yorkie/backend"
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+)
+
+// errForwardUnavailable is returned when the request could not be delivered
+// to the owner of the document. In this case, the request is processed by this
+// agent, since the lock of the document still guarantees the consistency.
+var errForwardUnavailable = errors.New("owner of document is unavailable")
+
+// forwardAttachDocument forwards the given request to the owner of the document.
+func forwardAttachDocument(
+	ctx context.Context,
+	be *backend.Backend,
+	owner *sync.AgentInfo,
+	req *api.AttachDocumentRequest,
+) (*api.AttachDocumentResponse, error) {
+	client, err := be.Coordinator.ClusterClient(owner)
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, errForwardUnavailable
+	}
+
+	resp, err := client.AttachDocument(forwardContext(ctx), req)
+	if err != nil {
+		return nil, toForwardError(owner, err)
+	}
+
+	return resp, nil
+}
+
+// forwardPushPull forwards the given request to the owner of the document.
+func forwardPushPull(
+	ctx context.Context,
+	be *backend.Backend,
+	owner *sync.AgentInfo,
+	req *api.PushPullRequest,
+) (*api.PushPullResponse, error) {
+	client, err := be.Coordinator.ClusterClient(owner)
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, errForwardUnavailable
+	}
+
+	resp, err := client.PushPull(forwardContext(ctx), req)
+	if err != nil {
+		return nil, toForwardError(owner, err)
+	}
+
+	return resp, nil
+}
+
+// forwardDetachDocument forwards the given request to the owner of the document.
+func forwardDetachDocument(
+	ctx context.Context,
+	be *backend.Backend,
+	owner *sync.AgentInfo,
+	req *api.DetachDocumentRequest,
+) (*api.DetachDocumentResponse, error) {
+	client, err := be.Coordinator.ClusterClient(owner)
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, errForwardUnavailable
+	}
+
+	resp, err := client.DetachDocument(forwardContext(ctx), req)
+	if err != nil {
+		return nil, toForwardError(owner, err)
+	}
+
+	return resp, nil
+}
+
+// forwardRevertDocument forwards the given request to the owner of the document.
+func forwardRevertDocument(
+	ctx context.Context,
+	be *backend.Backend,
+	owner *sync.AgentInfo,
+	req *api.RevertDocumentRequest,
+) (*api.RevertDocumentResponse, error) {
+	client, err := be.Coordinator.ClusterClient(owner)
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, errForwardUnavailable
+	}
+
+	resp, err := client.RevertDocument(forwardContext(ctx), req)
+	if err != nil {
+		return nil, toForwardError(owner, err)
+	}
+
+	return resp, nil
+}
+
+// forwardContext returns the context to forward the request with the metadata
+// of the incoming request such as the authorization token. The cluster secret
+// sent by the client is removed, since it is attached by the cluster client.
+func forwardContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	md = md.Copy()
+	delete(md, sync.ClusterSecretKey)
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// toForwardError returns errForwardUnavailable if the request was not
+// delivered to the owner. Otherwise, it returns the error of the owner as it is.
+func toForwardError(owner *sync.AgentInfo, err error) error {
+	if status.Code(err) == codes.Unavailable {
+		log.Logger.Warnf("fail to forward to %s: %s", owner.ClusterAddr, err.Error())
+		return errForwardUnavailable
+	}
+
+	return err
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interceptors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/yorkie-team/yorkie/internal/log"
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+)
+
+// ClusterAuthInterceptor is a interceptor that authenticates the agents
+// calling the Cluster service.
+type ClusterAuthInterceptor struct {
+	coordinator sync.Coordinator
+}
+
+// NewClusterAuthInterceptor creates a new instance of ClusterAuthInterceptor.
+func NewClusterAuthInterceptor(coordinator sync.Coordinator) *ClusterAuthInterceptor {
+	return &ClusterAuthInterceptor{
+		coordinator: coordinator,
+	}
+}
+
+// Unary creates a unary server interceptor for agent authentication.
+func (i *ClusterAuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if err := i.coordinator.AuthenticateAgent(ctx); err != nil {
+			log.Logger.Warnf("RPC : %q => %q", info.FullMethod, err)
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		return handler(ctx, req)
+	}
+}
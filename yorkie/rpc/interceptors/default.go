@@ -21,16 +21,23 @@ import (
 	"errors"
 	gotime "time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/yorkie-team/yorkie/api/converter"
 	"github.com/yorkie-team/yorkie/internal/log"
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/pkg/document/schema"
 	"github.com/yorkie-team/yorkie/pkg/document/time"
+	"github.com/yorkie-team/yorkie/pkg/types"
 	"github.com/yorkie-team/yorkie/yorkie/auth"
+	"github.com/yorkie-team/yorkie/yorkie/backend"
 	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
 	"github.com/yorkie-team/yorkie/yorkie/clients"
+	"github.com/yorkie-team/yorkie/yorkie/documents"
 	"github.com/yorkie-team/yorkie/yorkie/packs"
 )
 
@@ -87,16 +94,30 @@ func (i *DefaultInterceptor) Stream() grpc.StreamServerInterceptor {
 // occurs while executing logic in API handler, gRPC status.error should be
 // returned so that the client can know more about the status of the request.
 func toStatusError(err error) error {
+	// NOTE: The error returned by another agent for a forwarded
+	// request is already a status error.
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
 	if errors.Is(err, auth.ErrNotAllowed) {
 		return status.Error(codes.Unauthenticated, err.Error())
 	}
 
 	if errors.Is(err, converter.ErrPackRequired) ||
+		errors.Is(err, converter.ErrDocumentKeyRequired) ||
 		errors.Is(err, converter.ErrCheckpointRequired) ||
+		errors.Is(err, converter.ErrChangeIDRequired) ||
+		errors.Is(err, converter.ErrInvalidOperation) ||
+		errors.Is(err, documents.ErrServerSeqOutOfRange) ||
 		errors.Is(err, time.ErrInvalidHexString) ||
+		errors.Is(err, json.ErrInvalidValue) ||
 		errors.Is(err, db.ErrInvalidID) ||
 		errors.Is(err, clients.ErrInvalidClientID) ||
-		errors.Is(err, clients.ErrInvalidClientKey) {
+		errors.Is(err, clients.ErrInvalidClientKey) ||
+		errors.Is(err, clients.ErrReservedClientKey) ||
+		errors.Is(err, packs.ErrInvalidChange) ||
+		errors.Is(err, schema.ErrViolation) {
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
 
@@ -113,13 +134,87 @@ func toStatusError(err error) error {
 		return status.Error(codes.NotFound, err.Error())
 	}
 
+	if errors.Is(err, packs.ErrClientSeqGap) {
+		return preconditionError(err, types.ClientSeqGap)
+	}
+	if errors.Is(err, packs.ErrChangesOutOfOrder) {
+		return preconditionError(err, types.ChangesOutOfOrder)
+	}
+	if errors.Is(err, packs.ErrInvalidServerSeq) {
+		return preconditionError(err, types.InvalidServerSeq)
+	}
+
+	if errors.Is(err, sync.ErrSlowConsumer) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	if limit, ok := limitOf(err); ok {
+		return quotaError(err, limit)
+	}
+
 	if err == db.ErrClientNotActivated ||
 		err == db.ErrDocumentNotAttached ||
 		err == db.ErrDocumentAlreadyAttached ||
-		errors.Is(err, packs.ErrInvalidServerSeq) ||
 		errors.Is(err, db.ErrConflictOnUpdate) {
 		return status.Error(codes.FailedPrecondition, err.Error())
 	}
 
 	return status.Error(codes.Internal, err.Error())
 }
+
+// preconditionError returns a status.Error of FailedPrecondition that has the
+// given type of the violated precondition in its details.
+func preconditionError(err error, typ types.PreconditionType) error {
+	st, detailErr := status.New(codes.FailedPrecondition, err.Error()).WithDetails(
+		&errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{{
+				Type:        string(typ),
+				Description: err.Error(),
+			}},
+		},
+	)
+	if detailErr != nil {
+		log.Logger.Error(detailErr)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return st.Err()
+}
+
+// limitOf returns the name of the limit of the backend exceeded by the request
+// that returns the given error.
+func limitOf(err error) (string, bool) {
+	switch {
+	case errors.Is(err, packs.ErrTooManyChanges):
+		return backend.LimitChangesPerPack, true
+	case errors.Is(err, packs.ErrPackTooLarge):
+		return backend.LimitPackBytes, true
+	case errors.Is(err, packs.ErrDocumentTooLarge):
+		return backend.LimitDocumentBytes, true
+	case errors.Is(err, clients.ErrTooManyAttachedDocuments):
+		return backend.LimitAttachedDocumentsPerClient, true
+	case errors.Is(err, sync.ErrTooManyWatchers):
+		return backend.LimitWatchersPerDocument, true
+	}
+
+	return "", false
+}
+
+// quotaError returns a status.Error of ResourceExhausted that has the name of
+// the exceeded limit in its details.
+func quotaError(err error, limit string) error {
+	st, detailErr := status.New(codes.ResourceExhausted, err.Error()).WithDetails(
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{
+				Subject:     limit,
+				Description: err.Error(),
+			}},
+		},
+	)
+	if detailErr != nil {
+		log.Logger.Error(detailErr)
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interceptors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"path"
+	gotime "time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/yorkie-team/yorkie/internal/log"
+	"github.com/yorkie-team/yorkie/yorkie/auth"
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync/memory"
+	"github.com/yorkie-team/yorkie/yorkie/metrics"
+)
+
+// RateLimitInterceptor is a interceptor for rate limiting. The requests are
+// limited by the token bucket of each pair of the RPC method and the client.
+// The client is identified by the token verified by the webhook or the address
+// of the peer.
+type RateLimitInterceptor struct {
+	limiter  sync.RateLimiter
+	fallback sync.RateLimiter
+	limits   map[string]sync.RateLimit
+	metrics  metrics.Metrics
+}
+
+// NewRateLimitInterceptor creates a new instance of RateLimitInterceptor. The
+// given limits are keyed by the full name of the RPC method such as
+// "/api.Yorkie/PushPull".
+func NewRateLimitInterceptor(
+	limiter sync.RateLimiter,
+	limits map[string]sync.RateLimit,
+	met metrics.Metrics,
+) *RateLimitInterceptor {
+	return &RateLimitInterceptor{
+		limiter:  limiter,
+		fallback: memory.NewRateLimiter(),
+		limits:   limits,
+		metrics:  met,
+	}
+}
+
+// Unary creates a unary server interceptor for rate limiting.
+func (i *RateLimitInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if err := i.allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Stream creates a stream server interceptor for rate limiting.
+func (i *RateLimitInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := i.allow(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// allow takes a token for the request of the given method. It returns a
+// status error of ResourceExhausted if the rate limit is exceeded.
+func (i *RateLimitInterceptor) allow(ctx context.Context, method string) error {
+	limit, ok := i.limits[method]
+	if !ok {
+		return nil
+	}
+
+	name := path.Base(method)
+	key := name + "/" + subjectOf(ctx)
+	wait, err := i.limiter.Allow(ctx, key, limit)
+	if err != nil {
+		// NOTE: If the limiter fails, such as when the bucket shared by the
+		// agents keeps conflicting or the coordinator is unavailable, the
+		// request is limited by the bucket of this agent. So the failure
+		// neither stops the service nor lifts the limit.
+		log.Logger.Error(err)
+		if wait, err = i.fallback.Allow(ctx, key, limit); err != nil {
+			return err
+		}
+	}
+	if wait == 0 {
+		return nil
+	}
+
+	i.metrics.IncRateLimitRejections(name)
+	return rateLimitError(name, wait)
+}
+
+// subjectOf returns the subject of the rate limit for the given request. The
+// token is used only if AuthInterceptor has put it into the context, because
+// then the request is rejected unless the webhook allows the token. Otherwise,
+// the client ID and the authorization header are asserted by the request
+// itself and a client can evade the limit by changing them, so the address of
+// the peer is used instead.
+func subjectOf(ctx context.Context) string {
+	if token := auth.TokenFromCtx(ctx); len(token) > 0 {
+		// NOTE: The token is hashed not to expose it in the keys of the
+		// coordinator.
+		hash := sha256.Sum256([]byte(token))
+		return "token/" + hex.EncodeToString(hash[:])
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "peer/" + host
+	}
+
+	return "unknown"
+}
+
+// rateLimitError returns a status.Error of ResourceExhausted that has the
+// name of the method and the duration to wait before retrying in its details.
+func rateLimitError(method string, wait gotime.Duration) error {
+	msg := fmt.Sprintf("rate limit of %s exceeded, retry after %s", method, wait)
+	st, detailErr := status.New(codes.ResourceExhausted, msg).WithDetails(
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{
+				Subject:     method,
+				Description: msg,
+			}},
+		},
+		&errdetails.RetryInfo{
+			RetryDelay: ptypes.DurationProto(wait),
+		},
+	)
+	if detailErr != nil {
+		log.Logger.Error(detailErr)
+		return status.Error(codes.ResourceExhausted, msg)
+	}
+
+	return st.Err()
+}
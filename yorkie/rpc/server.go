@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"reflect"
 
 	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpcprometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
@@ -31,6 +32,8 @@ import (
 	"github.com/yorkie-team/yorkie/api"
 	"github.com/yorkie-team/yorkie/internal/log"
 	"github.com/yorkie-team/yorkie/yorkie/backend"
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync/memory"
 	"github.com/yorkie-team/yorkie/yorkie/rpc/interceptors"
 )
 
@@ -39,28 +42,100 @@ type Config struct {
 	Port     int
 	CertFile string
 	KeyFile  string
+
+	// ClusterPort is the port of the internal listener that serves the
+	// Cluster service to other agents. If it is zero, the Cluster service is
+	// not served.
+	ClusterPort int
+
+	// RateLimits is the rate limits of the methods of the Yorkie service by
+	// the name of the method such as "PushPull". The limit is in "rate:burst"
+	// format such as "10:20", which allows 10 requests per second and bursts
+	// of 20 requests for each client.
+	RateLimits map[string]string
+
+	// RateLimitShared is whether the rate limits are shared by the agents in
+	// the cluster through the coordinator. If it is false, each agent limits
+	// the requests to itself.
+	RateLimitShared bool
+}
+
+// Validate validates this config.
+func (c *Config) Validate() error {
+	if c.ClusterPort != 0 && c.ClusterPort == c.Port {
+		return fmt.Errorf("cluster port should differ from rpc port: %d", c.Port)
+	}
+
+	_, err := c.ParseRateLimits()
+	return err
 }
 
+// ParseRateLimits returns the rate limits of this config keyed by the full
+// name of the method such as "/api.Yorkie/PushPull".
+func (c *Config) ParseRateLimits() (map[string]sync.RateLimit, error) {
+	methods := make(map[string]bool)
+	yorkieServer := reflect.TypeOf((*api.YorkieServer)(nil)).Elem()
+	for i := 0; i < yorkieServer.NumMethod(); i++ {
+		methods[yorkieServer.Method(i).Name] = true
+	}
+
+	limits := make(map[string]sync.RateLimit)
+	for method, value := range c.RateLimits {
+		if !methods[method] {
+			return nil, fmt.Errorf("rate limit of unknown method: %s", method)
+		}
+
+		limit, err := sync.ParseRateLimit(value)
+		if err != nil {
+			return nil, fmt.Errorf("rate limit of %s: %w", method, err)
+		}
+		limits[yorkieServiceName+method] = limit
+	}
+
+	return limits, nil
+}
+
+// yorkieServiceName is the prefix of the full name of the methods of the
+// Yorkie service.
+const yorkieServiceName = "/api.Yorkie/"
+
 // Server is a normal server that processes the logic requested by the client.
 type Server struct {
 	conf                *Config
 	grpcServer          *grpc.Server
+	clusterGRPCServer   *grpc.Server
 	yorkieServiceCancel context.CancelFunc
 }
 
 // NewServer creates a new instance of Server.
 func NewServer(conf *Config, be *backend.Backend) (*Server, error) {
+	recoveryInterceptor := interceptors.NewRecoveryInterceptor()
 	authInterceptor := interceptors.NewAuthInterceptor(be.Config.AuthorizationWebhookURL)
 	defaultInterceptor := interceptors.NewDefaultInterceptor()
 
+	rateLimits, err := conf.ParseRateLimits()
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, err
+	}
+	var rateLimiter sync.RateLimiter = memory.NewRateLimiter()
+	if conf.RateLimitShared {
+		rateLimiter = be.Coordinator
+	}
+	rateLimitInterceptor := interceptors.NewRateLimitInterceptor(rateLimiter, rateLimits, be.Metrics)
+
 	opts := []grpc.ServerOption{
 		grpc.UnaryInterceptor(grpcmiddleware.ChainUnaryServer(
+			recoveryInterceptor.Unary(),
 			authInterceptor.Unary(),
+			rateLimitInterceptor.Unary(),
 			defaultInterceptor.Unary(),
 			grpcprometheus.UnaryServerInterceptor,
 		)),
 		grpc.StreamInterceptor(grpcmiddleware.ChainStreamServer(
+			recoveryInterceptor.Stream(),
 			authInterceptor.Stream(),
+			rateLimitInterceptor.Stream(),
 			defaultInterceptor.Stream(),
 			grpcprometheus.StreamServerInterceptor,
 		)),
@@ -80,43 +155,73 @@ func NewServer(conf *Config, be *backend.Backend) (*Server, error) {
 	grpcServer := grpc.NewServer(opts...)
 	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
 	api.RegisterYorkieServer(grpcServer, newYorkieServer(yorkieServiceCtx, be))
-	api.RegisterClusterServer(grpcServer, newClusterServer(be))
 	grpcprometheus.Register(grpcServer)
 
+	// NOTE: The Cluster service is served on a separate listener so that it is
+	// not exposed to clients with the Yorkie service, and only the agents of
+	// the cluster are allowed to call it.
+	var clusterGRPCServer *grpc.Server
+	if conf.ClusterPort != 0 {
+		clusterAuthInterceptor := interceptors.NewClusterAuthInterceptor(be.Coordinator)
+		clusterGRPCServer = grpc.NewServer(grpc.UnaryInterceptor(grpcmiddleware.ChainUnaryServer(
+			recoveryInterceptor.Unary(),
+			clusterAuthInterceptor.Unary(),
+			defaultInterceptor.Unary(),
+			grpcprometheus.UnaryServerInterceptor,
+		)))
+		api.RegisterClusterServer(clusterGRPCServer, newClusterServer(be))
+		grpcprometheus.Register(clusterGRPCServer)
+	}
+
 	return &Server{
 		conf:                conf,
 		grpcServer:          grpcServer,
+		clusterGRPCServer:   clusterGRPCServer,
 		yorkieServiceCancel: yorkieServiceCancel,
 	}, nil
 }
 
 // Start starts this server by opening the rpc port.
 func (s *Server) Start() error {
-	return s.listenAndServeGRPC()
+	if err := s.listenAndServeGRPC(s.grpcServer, s.conf.Port, "API"); err != nil {
+		return err
+	}
+
+	if s.clusterGRPCServer != nil {
+		return s.listenAndServeGRPC(s.clusterGRPCServer, s.conf.ClusterPort, "cluster API")
+	}
+
+	return nil
 }
 
 // Shutdown shuts down this server.
 func (s *Server) Shutdown(graceful bool) {
 	s.yorkieServiceCancel()
 
-	if graceful {
-		s.grpcServer.GracefulStop()
-	} else {
-		s.grpcServer.Stop()
+	for _, grpcServer := range []*grpc.Server{s.grpcServer, s.clusterGRPCServer} {
+		if grpcServer == nil {
+			continue
+		}
+
+		if graceful {
+			grpcServer.GracefulStop()
+		} else {
+			grpcServer.Stop()
+		}
 	}
 }
 
-func (s *Server) listenAndServeGRPC() error {
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.conf.Port))
+func (s *Server) listenAndServeGRPC(grpcServer *grpc.Server, port int, name string) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		log.Logger.Error(err)
 		return err
 	}
 
 	go func() {
-		log.Logger.Infof("serving API on %d", s.conf.Port)
+		log.Logger.Infof("serving %s on %d", name, port)
 
-		if err := s.grpcServer.Serve(lis); err != nil {
+		if err := grpcServer.Serve(lis); err != nil {
 			if err != grpc.ErrServerStopped {
 				log.Logger.Error(err)
 			}
@@ -5,21 +5,40 @@ import (
 	"encoding/hex"
 	"fmt"
 	"log"
+	"net"
 	"os"
+	"strings"
 	"testing"
+	gotime "time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
 	"github.com/yorkie-team/yorkie/api"
+	"github.com/yorkie-team/yorkie/api/converter"
+	"github.com/yorkie-team/yorkie/pkg/document"
+	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/checkpoint"
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/pkg/document/operation"
+	"github.com/yorkie-team/yorkie/pkg/document/proxy"
+	"github.com/yorkie-team/yorkie/pkg/document/schema"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+	"github.com/yorkie-team/yorkie/pkg/types"
 	"github.com/yorkie-team/yorkie/test/helper"
+	"github.com/yorkie-team/yorkie/yorkie/auth"
 	"github.com/yorkie-team/yorkie/yorkie/backend"
-	"github.com/yorkie-team/yorkie/yorkie/backend/db/mongo"
-	"github.com/yorkie-team/yorkie/yorkie/backend/sync/etcd"
+	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
+	"github.com/yorkie-team/yorkie/yorkie/documents"
 	"github.com/yorkie-team/yorkie/yorkie/metrics/prometheus"
 	"github.com/yorkie-team/yorkie/yorkie/rpc"
+	"github.com/yorkie-team/yorkie/yorkie/rpc/interceptors"
 )
 
 var (
@@ -27,9 +46,11 @@ var (
 	emptyClientID, _   = hex.DecodeString("")
 	invalidClientID, _ = hex.DecodeString("invalid")
 
-	testRPCServer *rpc.Server
-	testRPCAddr   = fmt.Sprintf("localhost:%d", helper.RPCPort)
-	testClient    api.YorkieClient
+	testBackend     *backend.Backend
+	testRPCServer   *rpc.Server
+	testRPCAddr     = fmt.Sprintf("localhost:%d", helper.RPCPort)
+	testClusterAddr = fmt.Sprintf("localhost:%d", helper.RPCClusterPort)
+	testClient      api.YorkieClient
 
 	invalidChangePack = &api.ChangePack{
 		DocumentKey: &api.DocumentKey{
@@ -40,23 +61,19 @@ var (
 )
 
 func TestMain(m *testing.M) {
-	be, err := backend.New(&backend.Config{
+	var err error
+	testBackend, err = backend.New(&backend.Config{
+		DBType:            backend.MemoryDB,
 		SnapshotThreshold: helper.SnapshotThreshold,
-	}, &mongo.Config{
-		ConnectionURI:        helper.MongoConnectionURI,
-		YorkieDatabase:       helper.TestDBName(),
-		ConnectionTimeoutSec: helper.MongoConnectionTimeoutSec,
-		PingTimeoutSec:       helper.MongoPingTimeoutSec,
-	}, &etcd.Config{
-		Endpoints: helper.ETCDEndpoints,
-	}, testRPCAddr, prometheus.NewMetrics())
+	}, nil, nil, nil, testClusterAddr, prometheus.NewMetrics())
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	testRPCServer, err = rpc.NewServer(&rpc.Config{
-		Port: helper.RPCPort,
-	}, be)
+		Port:        helper.RPCPort,
+		ClusterPort: helper.RPCClusterPort,
+	}, testBackend)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -73,7 +90,7 @@ func TestMain(m *testing.M) {
 
 	code := m.Run()
 
-	if err := be.Close(); err != nil {
+	if err := testBackend.Close(); err != nil {
 		log.Fatal(err)
 	}
 	testRPCServer.Shutdown(true)
@@ -101,6 +118,12 @@ func TestRPCServerBackend(t *testing.T) {
 		)
 		assert.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
 
+		_, err = testClient.ActivateClient(
+			context.Background(),
+			&api.ActivateClientRequest{ClientKey: documents.SystemClientKey},
+		)
+		assert.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
 		_, err = testClient.DeactivateClient(
 			context.Background(),
 			&api.DeactivateClientRequest{ClientId: emptyClientID},
@@ -351,4 +374,812 @@ func TestRPCServerBackend(t *testing.T) {
 		)
 		assert.Equal(t, codes.FailedPrecondition, status.Convert(err).Code())
 	})
+	t.Run("push/pull checkpoint validation test", func(t *testing.T) {
+		activateResp, err := testClient.ActivateClient(
+			context.Background(),
+			&api.ActivateClientRequest{ClientKey: t.Name()},
+		)
+		assert.NoError(t, err)
+
+		newPack := func(serverSeq uint64, clientSeqs ...uint32) *api.ChangePack {
+			pack := &api.ChangePack{
+				DocumentKey: &api.DocumentKey{
+					Collection: t.Name(), Document: t.Name(),
+				},
+				Checkpoint: &api.Checkpoint{ServerSeq: serverSeq},
+			}
+			for _, clientSeq := range clientSeqs {
+				pack.Checkpoint.ClientSeq = clientSeq
+				pack.Changes = append(pack.Changes, &api.Change{
+					Id: &api.ChangeID{
+						ClientSeq: clientSeq,
+						Lamport:   uint64(clientSeq),
+						ActorId:   activateResp.ClientId,
+					},
+				})
+			}
+			return pack
+		}
+		assertPrecondition := func(err error, typ types.PreconditionType) {
+			st := status.Convert(err)
+			assert.Equal(t, codes.FailedPrecondition, st.Code())
+			if assert.Len(t, st.Details(), 1) {
+				failure := st.Details()[0].(*errdetails.PreconditionFailure)
+				assert.Equal(t, string(typ), failure.Violations[0].Type)
+			}
+		}
+
+		_, err = testClient.AttachDocument(
+			context.Background(),
+			&api.AttachDocumentRequest{
+				ClientId:   activateResp.ClientId,
+				ChangePack: newPack(0, 1, 2),
+			},
+		)
+		assert.NoError(t, err)
+
+		// try to push changes that are not sorted by client seq.
+		outOfOrderPack := newPack(2, 4, 3)
+		outOfOrderPack.Checkpoint.ClientSeq = 4
+		_, err = testClient.PushPull(
+			context.Background(),
+			&api.PushPullRequest{
+				ClientId:   activateResp.ClientId,
+				ChangePack: outOfOrderPack,
+			},
+		)
+		assertPrecondition(err, types.ChangesOutOfOrder)
+
+		// try to push changes with missing changes in the middle.
+		_, err = testClient.PushPull(
+			context.Background(),
+			&api.PushPullRequest{
+				ClientId:   activateResp.ClientId,
+				ChangePack: newPack(2, 3, 5),
+			},
+		)
+		assertPrecondition(err, types.ClientSeqGap)
+
+		// try to push changes with missing changes after the checkpoint.
+		_, err = testClient.PushPull(
+			context.Background(),
+			&api.PushPullRequest{
+				ClientId:   activateResp.ClientId,
+				ChangePack: newPack(2, 4, 5),
+			},
+		)
+		assertPrecondition(err, types.ClientSeqGap)
+
+		// try to push/pull with the server seq ahead of the document.
+		_, err = testClient.PushPull(
+			context.Background(),
+			&api.PushPullRequest{
+				ClientId:   activateResp.ClientId,
+				ChangePack: newPack(10, 3),
+			},
+		)
+		assertPrecondition(err, types.InvalidServerSeq)
+
+		// changes that were already pushed can be sent again with new ones.
+		resp, err := testClient.PushPull(
+			context.Background(),
+			&api.PushPullRequest{
+				ClientId:   activateResp.ClientId,
+				ChangePack: newPack(2, 2, 3),
+			},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(3), resp.ChangePack.Checkpoint.ClientSeq)
+		assert.Equal(t, uint64(3), resp.ChangePack.Checkpoint.ServerSeq)
+
+		// try to push changes with missing changes after a fresh checkpoint.
+		_, err = testClient.DetachDocument(
+			context.Background(),
+			&api.DetachDocumentRequest{
+				ClientId:   activateResp.ClientId,
+				ChangePack: newPack(3, 4),
+			},
+		)
+		assert.NoError(t, err)
+		emptyPack := newPack(0)
+		_, err = testClient.AttachDocument(
+			context.Background(),
+			&api.AttachDocumentRequest{ClientId: activateResp.ClientId, ChangePack: emptyPack},
+		)
+		assert.NoError(t, err)
+		_, err = testClient.PushPull(
+			context.Background(),
+			&api.PushPullRequest{
+				ClientId:   activateResp.ClientId,
+				ChangePack: newPack(4, 5),
+			},
+		)
+		assertPrecondition(err, types.ClientSeqGap)
+
+		// the document can be attached again with the changes that follow the
+		// changes pushed before detaching.
+		_, err = testClient.DetachDocument(
+			context.Background(),
+			&api.DetachDocumentRequest{ClientId: activateResp.ClientId, ChangePack: emptyPack},
+		)
+		assert.NoError(t, err)
+		_, err = testClient.AttachDocument(
+			context.Background(),
+			&api.AttachDocumentRequest{
+				ClientId:   activateResp.ClientId,
+				ChangePack: newPack(4, 5),
+			},
+		)
+		assert.NoError(t, err)
+		resp, err = testClient.PushPull(
+			context.Background(),
+			&api.PushPullRequest{
+				ClientId:   activateResp.ClientId,
+				ChangePack: newPack(5, 6),
+			},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(6), resp.ChangePack.Checkpoint.ClientSeq)
+	})
+	t.Run("push/pull change validation test", func(t *testing.T) {
+		activateResp, err := testClient.ActivateClient(
+			context.Background(),
+			&api.ActivateClientRequest{ClientKey: t.Name()},
+		)
+		assert.NoError(t, err)
+		otherResp, err := testClient.ActivateClient(
+			context.Background(),
+			&api.ActivateClientRequest{ClientKey: t.Name() + "-other"},
+		)
+		assert.NoError(t, err)
+		actorID, err := time.ActorIDFromBytes(activateResp.ClientId)
+		assert.NoError(t, err)
+		otherID, err := time.ActorIDFromBytes(otherResp.ClientId)
+		assert.NoError(t, err)
+
+		doc := document.New(t.Name(), t.Name())
+		doc.SetActor(actorID)
+		assert.NoError(t, doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetNewArray("list")
+			root.SetNewText("text").Edit(0, 0, "A")
+			return nil
+		}))
+		pbPack, err := converter.ToChangePack(doc.CreateChangePack())
+		assert.NoError(t, err)
+		_, err = testClient.AttachDocument(
+			context.Background(),
+			&api.AttachDocumentRequest{ClientId: activateResp.ClientId, ChangePack: pbPack},
+		)
+		assert.NoError(t, err)
+
+		listCreatedAt := doc.RootObject().Get("list").CreatedAt()
+		textCreatedAt := doc.RootObject().Get("text").CreatedAt()
+		pushPull := func(ops ...operation.Operation) error {
+			c := change.New(change.NewID(2, 10, actorID), "", ops)
+			pbPack, err := converter.ToChangePack(change.NewPack(
+				doc.Key(),
+				checkpoint.New(1, 2),
+				[]*change.Change{c},
+				nil,
+			))
+			assert.NoError(t, err)
+
+			_, err = testClient.PushPull(
+				context.Background(),
+				&api.PushPullRequest{ClientId: activateResp.ClientId, ChangePack: pbPack},
+			)
+			return err
+		}
+
+		// 01. operations or elements of another actor.
+		ticket := time.NewTicket(10, 1, otherID)
+		err = pushPull(operation.NewSet(time.InitialTicket, "k", json.NewPrimitive(1, ticket), ticket))
+		assert.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+		otherTicket := ticket
+		ticket = time.NewTicket(10, 1, actorID)
+		err = pushPull(operation.NewSet(time.InitialTicket, "k", json.NewPrimitive(1, otherTicket), ticket))
+		assert.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+		// 02. Set into an Array.
+		err = pushPull(operation.NewSet(listCreatedAt, "k", json.NewPrimitive(1, ticket), ticket))
+		assert.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+		// 03. Edit with the position of a non-existent node.
+		pos := json.NewRGATreeSplitNodePos(json.NewRGATreeSplitNodeID(time.NewTicket(9, 9, actorID), 0), 0)
+		err = pushPull(operation.NewEdit(textCreatedAt, pos, pos, nil, "B", ticket))
+		assert.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+		// 04. malformed changes without a ticket or a value.
+		err = pushPull(operation.NewSet(time.InitialTicket, "k", json.NewPrimitive(1, ticket), nil))
+		assert.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+		c := change.New(change.NewID(2, 10, actorID), "", []operation.Operation{
+			operation.NewSet(time.InitialTicket, "k", json.NewPrimitive(1, ticket), ticket),
+		})
+		pbPack, err = converter.ToChangePack(change.NewPack(doc.Key(), checkpoint.New(1, 2), []*change.Change{c}, nil))
+		assert.NoError(t, err)
+		pbPack.Changes[0].Operations[0].GetSet().Value = nil
+		_, err = testClient.PushPull(
+			context.Background(),
+			&api.PushPullRequest{ClientId: activateResp.ClientId, ChangePack: pbPack},
+		)
+		assert.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+		// 05. Set violating the schema of the collection.
+		s, err := schema.NewJSONSchema([]byte(`{"properties": {"k": {"type": "integer"}}}`))
+		assert.NoError(t, err)
+		testBackend.Schemas.Register(t.Name(), s)
+		err = pushPull(operation.NewSet(time.InitialTicket, "k", json.NewPrimitive("1", ticket), ticket))
+		assert.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+		// 06. the valid change is stored after the rejected ones.
+		err = pushPull(operation.NewSet(time.InitialTicket, "k", json.NewPrimitive(1, ticket), ticket))
+		assert.NoError(t, err)
+	})
+
+	t.Run("limits test", func(t *testing.T) {
+		// NOTE: The limits are set in the config of the backend shared by the
+		// tests, so the config is restored even if this test fails.
+		conf := testBackend.Config
+		original := *conf
+		t.Cleanup(func() { *conf = original })
+		assertQuotaFailure := func(err error, limit string) {
+			st := status.Convert(err)
+			assert.Equal(t, codes.ResourceExhausted, st.Code())
+			if assert.Len(t, st.Details(), 1) {
+				failure := st.Details()[0].(*errdetails.QuotaFailure)
+				assert.Equal(t, limit, failure.Violations[0].Subject)
+			}
+		}
+
+		activateResp, err := testClient.ActivateClient(
+			context.Background(),
+			&api.ActivateClientRequest{ClientKey: t.Name()},
+		)
+		assert.NoError(t, err)
+		actorID, err := time.ActorIDFromBytes(activateResp.ClientId)
+		assert.NoError(t, err)
+
+		newDoc := func(name string) *document.Document {
+			doc := document.New(t.Name(), name)
+			doc.SetActor(actorID)
+			assert.NoError(t, doc.Update(func(root *proxy.ObjectProxy) error {
+				root.SetString("k1", "v1")
+				return nil
+			}))
+			assert.NoError(t, doc.Update(func(root *proxy.ObjectProxy) error {
+				root.SetString("k2", "v2")
+				return nil
+			}))
+			return doc
+		}
+		packOf := func(doc *document.Document) *api.ChangePack {
+			pbPack, err := converter.ToChangePack(doc.CreateChangePack())
+			assert.NoError(t, err)
+			return pbPack
+		}
+		attach := func(doc *document.Document) error {
+			_, err := testClient.AttachDocument(
+				context.Background(),
+				&api.AttachDocumentRequest{ClientId: activateResp.ClientId, ChangePack: packOf(doc)},
+			)
+			return err
+		}
+
+		// 01. changes per pack and pack bytes.
+		d1 := newDoc("d1")
+		conf.MaxChangesPerPack = 1
+		assertQuotaFailure(attach(d1), backend.LimitChangesPerPack)
+		conf.MaxChangesPerPack = 0
+
+		conf.MaxPackBytes = 10
+		assertQuotaFailure(attach(d1), backend.LimitPackBytes)
+		conf.MaxPackBytes = 0
+		assert.NoError(t, attach(d1))
+
+		// 02. document bytes.
+		conf.MaxDocumentBytes = 256
+		assert.NoError(t, d1.Update(func(root *proxy.ObjectProxy) error {
+			root.SetString("k3", strings.Repeat("a", 256))
+			return nil
+		}))
+		_, err = testClient.PushPull(
+			context.Background(),
+			&api.PushPullRequest{ClientId: activateResp.ClientId, ChangePack: packOf(d1)},
+		)
+		assertQuotaFailure(err, backend.LimitDocumentBytes)
+		conf.MaxDocumentBytes = 0
+
+		// 03. attached documents per client.
+		conf.MaxAttachedDocumentsPerClient = 1
+		d2 := newDoc("d2")
+		assertQuotaFailure(attach(d2), backend.LimitAttachedDocumentsPerClient)
+		_, err = testClient.DetachDocument(
+			context.Background(),
+			&api.DetachDocumentRequest{ClientId: activateResp.ClientId, ChangePack: packOf(d1)},
+		)
+		assert.NoError(t, err)
+		assert.NoError(t, attach(d2))
+		conf.MaxAttachedDocumentsPerClient = 0
+
+		// 04. watchers per document.
+		conf.MaxWatchersPerDocument = 1
+		watch := func() (api.Yorkie_WatchDocumentsClient, context.CancelFunc) {
+			ctx, cancel := context.WithCancel(context.Background())
+			stream, err := testClient.WatchDocuments(ctx, &api.WatchDocumentsRequest{
+				Client:       &api.Client{Id: activateResp.ClientId},
+				DocumentKeys: []*api.DocumentKey{converter.ToDocumentKey(d2.Key())},
+			})
+			assert.NoError(t, err)
+			return stream, cancel
+		}
+		stream1, cancel1 := watch()
+		defer cancel1()
+		_, err = stream1.Recv()
+		assert.NoError(t, err)
+
+		stream2, cancel2 := watch()
+		defer cancel2()
+		_, err = stream2.Recv()
+		assertQuotaFailure(err, backend.LimitWatchersPerDocument)
+		conf.MaxWatchersPerDocument = 0
+	})
+
+	t.Run("document history test", func(t *testing.T) {
+		activateResp, err := testClient.ActivateClient(
+			context.Background(),
+			&api.ActivateClientRequest{ClientKey: t.Name()},
+		)
+		assert.NoError(t, err)
+
+		docKey := &api.DocumentKey{Collection: t.Name(), Document: t.Name()}
+		pack := &api.ChangePack{
+			DocumentKey: docKey,
+			Checkpoint:  &api.Checkpoint{ServerSeq: 0, ClientSeq: 3},
+		}
+		for clientSeq := uint32(1); clientSeq <= 3; clientSeq++ {
+			pack.Changes = append(pack.Changes, &api.Change{
+				Id: &api.ChangeID{
+					ClientSeq: clientSeq,
+					Lamport:   uint64(clientSeq),
+					ActorId:   activateResp.ClientId,
+				},
+				Message: fmt.Sprintf("change %d", clientSeq),
+			})
+		}
+		_, err = testClient.AttachDocument(
+			context.Background(),
+			&api.AttachDocumentRequest{
+				ClientId:   activateResp.ClientId,
+				ChangePack: pack,
+			},
+		)
+		assert.NoError(t, err)
+
+		historyResp, err := testClient.GetDocumentHistory(
+			context.Background(),
+			&api.GetDocumentHistoryRequest{
+				ClientId:    activateResp.ClientId,
+				DocumentKey: docKey,
+				PageSize:    2,
+			},
+		)
+		assert.NoError(t, err)
+		assert.Len(t, historyResp.Changes, 2)
+		assert.Equal(t, uint64(1), historyResp.Changes[0].ServerSeq)
+		assert.Equal(t, "change 1", historyResp.Changes[0].Message)
+		assert.Equal(t, activateResp.ClientId, historyResp.Changes[0].ActorId)
+		assert.NotNil(t, historyResp.Changes[0].CreatedAt)
+		assert.Equal(t, uint64(3), historyResp.NextServerSeq)
+
+		historyResp, err = testClient.GetDocumentHistory(
+			context.Background(),
+			&api.GetDocumentHistoryRequest{
+				ClientId:      activateResp.ClientId,
+				DocumentKey:   docKey,
+				FromServerSeq: historyResp.NextServerSeq,
+				PageSize:      2,
+			},
+		)
+		assert.NoError(t, err)
+		assert.Len(t, historyResp.Changes, 1)
+		assert.Equal(t, "change 3", historyResp.Changes[0].Message)
+		assert.Equal(t, uint64(0), historyResp.NextServerSeq)
+
+		atResp, err := testClient.GetDocumentAt(
+			context.Background(),
+			&api.GetDocumentAtRequest{
+				ClientId:    activateResp.ClientId,
+				DocumentKey: docKey,
+				ServerSeq:   2,
+			},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), atResp.ServerSeq)
+		assert.NotEmpty(t, atResp.Snapshot)
+
+		// try to get the document past its server seq
+		_, err = testClient.GetDocumentAt(
+			context.Background(),
+			&api.GetDocumentAtRequest{
+				ClientId:    activateResp.ClientId,
+				DocumentKey: docKey,
+				ServerSeq:   10,
+			},
+		)
+		assert.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+		// try to get the history without the document key
+		_, err = testClient.GetDocumentHistory(
+			context.Background(),
+			&api.GetDocumentHistoryRequest{ClientId: activateResp.ClientId},
+		)
+		assert.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+		// try to get the history of the document that does not exist
+		_, err = testClient.GetDocumentHistory(
+			context.Background(),
+			&api.GetDocumentHistoryRequest{
+				ClientId:    activateResp.ClientId,
+				DocumentKey: &api.DocumentKey{Collection: t.Name(), Document: "not-exists"},
+			},
+		)
+		assert.Equal(t, codes.NotFound, status.Convert(err).Code())
+	})
+
+	t.Run("revert document test", func(t *testing.T) {
+		activateResp, err := testClient.ActivateClient(
+			context.Background(),
+			&api.ActivateClientRequest{ClientKey: t.Name()},
+		)
+		assert.NoError(t, err)
+
+		docKey := &api.DocumentKey{Collection: t.Name(), Document: t.Name()}
+		_, err = testClient.AttachDocument(
+			context.Background(),
+			&api.AttachDocumentRequest{
+				ClientId: activateResp.ClientId,
+				ChangePack: &api.ChangePack{
+					DocumentKey: docKey,
+					Checkpoint:  &api.Checkpoint{ServerSeq: 0, ClientSeq: 1},
+					Changes: []*api.Change{{
+						Id: &api.ChangeID{
+							ClientSeq: 1,
+							Lamport:   1,
+							ActorId:   activateResp.ClientId,
+						},
+					}},
+				},
+			},
+		)
+		assert.NoError(t, err)
+
+		// the document does not change, so nothing is pushed
+		revertResp, err := testClient.RevertDocument(
+			context.Background(),
+			&api.RevertDocumentRequest{
+				ClientId:    activateResp.ClientId,
+				DocumentKey: docKey,
+				ServerSeq:   0,
+			},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), revertResp.ServerSeq)
+
+		// try to revert the document past its server seq
+		_, err = testClient.RevertDocument(
+			context.Background(),
+			&api.RevertDocumentRequest{
+				ClientId:    activateResp.ClientId,
+				DocumentKey: docKey,
+				ServerSeq:   10,
+			},
+		)
+		assert.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+	})
+
+	t.Run("revert document twice test", func(t *testing.T) {
+		activateResp, err := testClient.ActivateClient(
+			context.Background(),
+			&api.ActivateClientRequest{ClientKey: t.Name()},
+		)
+		assert.NoError(t, err)
+		actorID, err := time.ActorIDFromBytes(activateResp.ClientId)
+		assert.NoError(t, err)
+
+		doc := document.New(t.Name(), t.Name())
+		doc.SetActor(actorID)
+		assert.NoError(t, doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetInteger("k", 1)
+			return nil
+		}))
+		assert.NoError(t, doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetInteger("k", 2)
+			return nil
+		}))
+		pbPack, err := converter.ToChangePack(doc.CreateChangePack())
+		assert.NoError(t, err)
+		_, err = testClient.AttachDocument(
+			context.Background(),
+			&api.AttachDocumentRequest{ClientId: activateResp.ClientId, ChangePack: pbPack},
+		)
+		assert.NoError(t, err)
+
+		revert := func(serverSeq uint64) uint64 {
+			resp, err := testClient.RevertDocument(
+				context.Background(),
+				&api.RevertDocumentRequest{
+					ClientId:    activateResp.ClientId,
+					DocumentKey: converter.ToDocumentKey(doc.Key()),
+					ServerSeq:   serverSeq,
+				},
+			)
+			assert.NoError(t, err)
+			return resp.ServerSeq
+		}
+		assert.Equal(t, uint64(3), revert(1))
+		assert.Equal(t, uint64(4), revert(2))
+
+		// the system client pushes the reverts with consecutive client seqs.
+		clientInfo, err := testBackend.DB.FindClientInfoByID(
+			context.Background(),
+			db.IDFromBytes(activateResp.ClientId),
+		)
+		assert.NoError(t, err)
+		docInfo, err := testBackend.DB.FindDocInfoByKey(
+			context.Background(),
+			clientInfo,
+			doc.Key().BSONKey(),
+			false,
+		)
+		assert.NoError(t, err)
+		changes, err := testBackend.DB.FindChangeInfosBetweenServerSeqs(
+			context.Background(),
+			docInfo.ID,
+			3,
+			4,
+		)
+		assert.NoError(t, err)
+		assert.Len(t, changes, 2)
+		assert.Equal(t, changes[0].ID().Actor(), changes[1].ID().Actor())
+		assert.Equal(t, uint32(1), changes[0].ClientSeq())
+		assert.Equal(t, uint32(2), changes[1].ClientSeq())
+
+		atResp, err := testClient.GetDocumentAt(
+			context.Background(),
+			&api.GetDocumentAtRequest{
+				ClientId:    activateResp.ClientId,
+				DocumentKey: converter.ToDocumentKey(doc.Key()),
+				ServerSeq:   4,
+			},
+		)
+		assert.NoError(t, err)
+		root, err := converter.BytesToObject(atResp.Snapshot)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"k":2}`, root.Marshal())
+	})
+
+	t.Run("revert document validation test", func(t *testing.T) {
+		activateResp, err := testClient.ActivateClient(
+			context.Background(),
+			&api.ActivateClientRequest{ClientKey: t.Name()},
+		)
+		assert.NoError(t, err)
+		actorID, err := time.ActorIDFromBytes(activateResp.ClientId)
+		assert.NoError(t, err)
+
+		doc := document.New(t.Name(), t.Name())
+		doc.SetActor(actorID)
+		assert.NoError(t, doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetString("k", strings.Repeat("a", 256))
+			return nil
+		}))
+		assert.NoError(t, doc.Update(func(root *proxy.ObjectProxy) error {
+			root.SetInteger("k", 1)
+			return nil
+		}))
+		pbPack, err := converter.ToChangePack(doc.CreateChangePack())
+		assert.NoError(t, err)
+		_, err = testClient.AttachDocument(
+			context.Background(),
+			&api.AttachDocumentRequest{ClientId: activateResp.ClientId, ChangePack: pbPack},
+		)
+		assert.NoError(t, err)
+
+		revert := func() error {
+			_, err := testClient.RevertDocument(
+				context.Background(),
+				&api.RevertDocumentRequest{
+					ClientId:    activateResp.ClientId,
+					DocumentKey: converter.ToDocumentKey(doc.Key()),
+					ServerSeq:   1,
+				},
+			)
+			return err
+		}
+
+		// 01. the reverted document exceeds the size limit.
+		testBackend.Config.MaxDocumentBytes = 256
+		t.Cleanup(func() { testBackend.Config.MaxDocumentBytes = 0 })
+		assert.Equal(t, codes.ResourceExhausted, status.Convert(revert()).Code())
+		testBackend.Config.MaxDocumentBytes = 0
+
+		// 02. the reverted document violates the schema of the collection.
+		s, err := schema.NewJSONSchema([]byte(`{"properties": {"k": {"type": "integer"}}}`))
+		assert.NoError(t, err)
+		testBackend.Schemas.Register(t.Name(), s)
+		assert.Equal(t, codes.InvalidArgument, status.Convert(revert()).Code())
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Run("rate limit config test", func(t *testing.T) {
+		conf := &rpc.Config{RateLimits: map[string]string{"PushPull": "10:20"}}
+		assert.NoError(t, conf.Validate())
+
+		conf = &rpc.Config{RateLimits: map[string]string{"Unknown": "10:20"}}
+		assert.Error(t, conf.Validate())
+
+		conf = &rpc.Config{RateLimits: map[string]string{"PushPull": "0"}}
+		assert.Error(t, conf.Validate())
+	})
+
+	t.Run("rate limit test", func(t *testing.T) {
+		port := helper.RPCPort + 1
+		server, err := rpc.NewServer(&rpc.Config{
+			Port:       port,
+			RateLimits: map[string]string{"PushPull": "0.1:1"},
+		}, testBackend)
+		assert.NoError(t, err)
+		assert.NoError(t, server.Start())
+		defer server.Shutdown(true)
+
+		conn, err := grpc.Dial(fmt.Sprintf("localhost:%d", port), grpc.WithInsecure())
+		assert.NoError(t, err)
+		defer func() {
+			assert.NoError(t, conn.Close())
+		}()
+		client := api.NewYorkieClient(conn)
+
+		activateResp, err := client.ActivateClient(
+			context.Background(),
+			&api.ActivateClientRequest{ClientKey: t.Name()},
+		)
+		assert.NoError(t, err)
+		req := &api.PushPullRequest{ClientId: activateResp.ClientId, ChangePack: invalidChangePack}
+
+		// 01. the first request passes the rate limit and fails in the handler.
+		_, err = client.PushPull(context.Background(), req)
+		assert.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+		// 02. the next request is rejected with the delay to retry.
+		_, err = client.PushPull(context.Background(), req)
+		st := status.Convert(err)
+		assert.Equal(t, codes.ResourceExhausted, st.Code())
+		if assert.Len(t, st.Details(), 2) {
+			failure := st.Details()[0].(*errdetails.QuotaFailure)
+			assert.Equal(t, "PushPull", failure.Violations[0].Subject)
+
+			retryInfo := st.Details()[1].(*errdetails.RetryInfo)
+			assert.Greater(t, retryInfo.RetryDelay.Seconds, int64(0))
+		}
+
+		// 03. the requests of other clients from the same peer are limited
+		// without the auth webhook, but the requests of other methods are not.
+		otherResp, err := client.ActivateClient(
+			context.Background(),
+			&api.ActivateClientRequest{ClientKey: t.Name() + "-other"},
+		)
+		assert.NoError(t, err)
+		_, err = client.PushPull(context.Background(), &api.PushPullRequest{
+			ClientId:   otherResp.ClientId,
+			ChangePack: invalidChangePack,
+		})
+		assert.Equal(t, codes.ResourceExhausted, status.Convert(err).Code())
+
+		_, err = client.DeactivateClient(
+			context.Background(),
+			&api.DeactivateClientRequest{ClientId: activateResp.ClientId},
+		)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rate limit subject and fallback test", func(t *testing.T) {
+		interceptor := interceptors.NewRateLimitInterceptor(
+			failingRateLimiter{},
+			map[string]sync.RateLimit{"/api.Yorkie/PushPull": {RatePerSec: 0.1, Burst: 1}},
+			testBackend.Metrics,
+		)
+		info := &grpc.UnaryServerInfo{FullMethod: "/api.Yorkie/PushPull"}
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		}
+		peerCtx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1234},
+		})
+		withToken := func(token string) context.Context {
+			return metadata.NewIncomingContext(peerCtx, metadata.Pairs("authorization", token))
+		}
+
+		// 01. the request is limited by the bucket of this agent if the
+		// limiter fails.
+		_, err := interceptor.Unary()(withToken("token-1"), &api.PushPullRequest{ClientId: []byte{1}}, info, handler)
+		assert.NoError(t, err)
+
+		// 02. the request of the same peer is limited even if it has another
+		// token and client ID, because the token is not verified.
+		_, err = interceptor.Unary()(withToken("token-2"), &api.PushPullRequest{ClientId: []byte{2}}, info, handler)
+		assert.Equal(t, codes.ResourceExhausted, status.Convert(err).Code())
+
+		// 03. the request with the token verified by the auth interceptor is
+		// limited by the bucket of the token.
+		verified := auth.CtxWithToken(withToken("token-3"), "token-3")
+		_, err = interceptor.Unary()(verified, &api.PushPullRequest{ClientId: []byte{3}}, info, handler)
+		assert.NoError(t, err)
+		_, err = interceptor.Unary()(verified, &api.PushPullRequest{ClientId: []byte{3}}, info, handler)
+		assert.Equal(t, codes.ResourceExhausted, status.Convert(err).Code())
+	})
+}
+
+// failingRateLimiter is a sync.RateLimiter that always fails.
+type failingRateLimiter struct{}
+
+// Allow returns an error.
+func (l failingRateLimiter) Allow(
+	ctx context.Context,
+	key string,
+	limit sync.RateLimit,
+) (gotime.Duration, error) {
+	return 0, fmt.Errorf("%s: rate limiter unavailable", key)
+}
+
+func TestRecovery(t *testing.T) {
+	t.Run("recovery test", func(t *testing.T) {
+		interceptor := interceptors.NewRecoveryInterceptor()
+		info := &grpc.UnaryServerInfo{FullMethod: "/api.Yorkie/PushPull"}
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			panic("malformed request")
+		}
+
+		_, err := interceptor.Unary()(context.Background(), &api.PushPullRequest{}, info, handler)
+		assert.Equal(t, codes.Internal, status.Convert(err).Code())
+	})
+}
+
+func TestClusterServer(t *testing.T) {
+	t.Run("cluster service on separate port test", func(t *testing.T) {
+		conn, err := grpc.Dial(testRPCAddr, grpc.WithInsecure())
+		assert.NoError(t, err)
+		defer func() {
+			assert.NoError(t, conn.Close())
+		}()
+
+		// the Cluster service should not be served with the Yorkie service.
+		_, err = api.NewClusterClient(conn).BroadcastEvent(
+			context.Background(),
+			&api.BroadcastEventRequest{},
+		)
+		assert.Equal(t, codes.Unimplemented, status.Convert(err).Code())
+	})
+
+	t.Run("unauthenticated agent test", func(t *testing.T) {
+		conn, err := grpc.Dial(testClusterAddr, grpc.WithInsecure())
+		assert.NoError(t, err)
+		defer func() {
+			assert.NoError(t, conn.Close())
+		}()
+
+		// the memory coordinator has no other agent to be authenticated.
+		_, err = api.NewClusterClient(conn).PushPull(
+			context.Background(),
+			&api.PushPullRequest{ChangePack: invalidChangePack},
+		)
+		assert.Equal(t, codes.Unauthenticated, status.Convert(err).Code())
+	})
+
+	t.Run("cluster port validation test", func(t *testing.T) {
+		conf := &rpc.Config{Port: helper.RPCPort, ClusterPort: helper.RPCPort}
+		assert.Error(t, conf.Validate())
+	})
 }
@@ -18,17 +18,20 @@ package rpc
 
 import (
 	"context"
+	"fmt"
 	gotime "time"
 
 	"github.com/yorkie-team/yorkie/api"
 	"github.com/yorkie-team/yorkie/api/converter"
 	"github.com/yorkie-team/yorkie/internal/log"
+	"github.com/yorkie-team/yorkie/pkg/document/change"
 	"github.com/yorkie-team/yorkie/pkg/document/key"
 	"github.com/yorkie-team/yorkie/pkg/types"
 	"github.com/yorkie-team/yorkie/yorkie/auth"
 	"github.com/yorkie-team/yorkie/yorkie/backend"
 	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
 	"github.com/yorkie-team/yorkie/yorkie/clients"
+	"github.com/yorkie-team/yorkie/yorkie/documents"
 	"github.com/yorkie-team/yorkie/yorkie/packs"
 )
 
@@ -101,6 +104,10 @@ func (s *yorkieServer) AttachDocument(
 	ctx context.Context,
 	req *api.AttachDocumentRequest,
 ) (*api.AttachDocumentResponse, error) {
+	if err := packs.CheckPackBytes(s.backend, req.ChangePack.Size()); err != nil {
+		return nil, err
+	}
+
 	pack, err := converter.FromChangePack(req.ChangePack)
 	if err != nil {
 		return nil, err
@@ -113,193 +120,197 @@ func (s *yorkieServer) AttachDocument(
 		return nil, err
 	}
 
-	if pack.HasChanges() {
-		locker, err := s.backend.Coordinator.NewLocker(
-			ctx,
-			packs.NewPushPullKey(pack.DocumentKey),
-		)
-		if err != nil {
-			return nil, err
+	if owner, local := s.backend.Coordinator.Owner(pack.DocumentKey); !local {
+		resp, err := forwardAttachDocument(ctx, s.backend, owner, req)
+		if err != errForwardUnavailable {
+			return resp, err
 		}
-
-		if err := locker.Lock(ctx); err != nil {
-			return nil, err
-		}
-		defer func() {
-			if err := locker.Unlock(ctx); err != nil {
-				log.Logger.Error(err)
-			}
-		}()
 	}
 
-	clientInfo, docInfo, err := clients.FindClientAndDocument(
-		ctx,
-		s.backend,
-		req.ClientId,
-		pack,
-		true,
-	)
-	if err != nil {
-		return nil, err
-	}
-	if err := clientInfo.AttachDocument(docInfo.ID); err != nil {
+	return attachDocument(ctx, s.backend, req.ClientId, pack)
+}
+
+// DetachDocument detaches the given document to the client.
+func (s *yorkieServer) DetachDocument(
+	ctx context.Context,
+	req *api.DetachDocumentRequest,
+) (*api.DetachDocumentResponse, error) {
+	if err := packs.CheckPackBytes(s.backend, req.ChangePack.Size()); err != nil {
 		return nil, err
 	}
 
-	pulled, err := packs.PushPull(ctx, s.backend, clientInfo, docInfo, pack)
+	pack, err := converter.FromChangePack(req.ChangePack)
 	if err != nil {
 		return nil, err
 	}
 
-	pbChangePack, err := converter.ToChangePack(pulled)
-	if err != nil {
+	if err := auth.VerifyAccess(ctx, s.backend, &types.AccessInfo{
+		Method:     types.DetachDocument,
+		Attributes: auth.AccessAttributes(pack),
+	}); err != nil {
 		return nil, err
 	}
 
-	return &api.AttachDocumentResponse{
-		ChangePack: pbChangePack,
-	}, nil
+	if owner, local := s.backend.Coordinator.Owner(pack.DocumentKey); !local {
+		resp, err := forwardDetachDocument(ctx, s.backend, owner, req)
+		if err != errForwardUnavailable {
+			return resp, err
+		}
+	}
+
+	return detachDocument(ctx, s.backend, req.ClientId, pack)
 }
 
-// DetachDocument detaches the given document to the client.
-func (s *yorkieServer) DetachDocument(
+// PushPull stores the changes sent by the client and delivers the changes
+// accumulated in the agent to the client.
+func (s *yorkieServer) PushPull(
 	ctx context.Context,
-	req *api.DetachDocumentRequest,
-) (*api.DetachDocumentResponse, error) {
+	req *api.PushPullRequest,
+) (*api.PushPullResponse, error) {
+	start := gotime.Now()
+	if err := packs.CheckPackBytes(s.backend, req.ChangePack.Size()); err != nil {
+		return nil, err
+	}
+
 	pack, err := converter.FromChangePack(req.ChangePack)
 	if err != nil {
 		return nil, err
 	}
 
 	if err := auth.VerifyAccess(ctx, s.backend, &types.AccessInfo{
-		Method:     types.DetachDocument,
+		Method:     types.PushPull,
 		Attributes: auth.AccessAttributes(pack),
 	}); err != nil {
 		return nil, err
 	}
 
-	if pack.HasChanges() {
-		locker, err := s.backend.Coordinator.NewLocker(
-			ctx,
-			packs.NewPushPullKey(pack.DocumentKey),
-		)
-		if err != nil {
-			return nil, err
+	if owner, local := s.backend.Coordinator.Owner(pack.DocumentKey); !local {
+		resp, err := forwardPushPull(ctx, s.backend, owner, req)
+		if err != errForwardUnavailable {
+			return resp, err
 		}
+	}
 
-		if err := locker.Lock(ctx); err != nil {
-			return nil, err
-		}
-		defer func() {
-			if err := locker.Unlock(ctx); err != nil {
-				log.Logger.Error(err)
-			}
-		}()
+	resp, err := pushPull(ctx, s.backend, req.ClientId, pack)
+	if err != nil {
+		return nil, err
 	}
 
-	clientInfo, docInfo, err := clients.FindClientAndDocument(
-		ctx,
-		s.backend,
-		req.ClientId,
-		pack,
-		false,
-	)
+	s.backend.Metrics.SetPushPullSentChanges(len(resp.ChangePack.Changes))
+	s.backend.Metrics.ObservePushPullResponseSeconds(gotime.Since(start).Seconds())
+
+	return resp, nil
+}
+
+// GetDocumentHistory returns a page of the summaries of the changes of the
+// given document.
+func (s *yorkieServer) GetDocumentHistory(
+	ctx context.Context,
+	req *api.GetDocumentHistoryRequest,
+) (*api.GetDocumentHistoryResponse, error) {
+	docKey, err := converter.FromDocumentKey(req.DocumentKey)
 	if err != nil {
 		return nil, err
 	}
-	if err := clientInfo.EnsureDocumentAttached(docInfo.ID); err != nil {
+
+	if err := auth.VerifyAccess(ctx, s.backend, &types.AccessInfo{
+		Method:     types.GetDocumentHistory,
+		Attributes: []types.AccessAttribute{{Key: docKey.BSONKey(), Verb: types.Read}},
+	}); err != nil {
 		return nil, err
 	}
-	if err := clientInfo.DetachDocument(docInfo.ID); err != nil {
+
+	_, docInfo, err := clients.FindClientAndDocument(ctx, s.backend, req.ClientId, docKey, false)
+	if err != nil {
 		return nil, err
 	}
 
-	pulled, err := packs.PushPull(ctx, s.backend, clientInfo, docInfo, pack)
+	summaries, next, err := documents.FindChangeSummaries(
+		ctx,
+		s.backend,
+		docInfo,
+		req.FromServerSeq,
+		req.PageSize,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	pbChangePack, err := converter.ToChangePack(pulled)
+	pbSummaries, err := converter.ToChangeSummaries(summaries)
 	if err != nil {
 		return nil, err
 	}
 
-	return &api.DetachDocumentResponse{
-		ChangePack: pbChangePack,
+	return &api.GetDocumentHistoryResponse{
+		Changes:       pbSummaries,
+		NextServerSeq: next,
 	}, nil
 }
 
-// PushPull stores the changes sent by the client and delivers the changes
-// accumulated in the agent to the client.
-func (s *yorkieServer) PushPull(
+// GetDocumentAt returns the snapshot of the given document as it was at the
+// given server seq.
+func (s *yorkieServer) GetDocumentAt(
 	ctx context.Context,
-	req *api.PushPullRequest,
-) (*api.PushPullResponse, error) {
-	start := gotime.Now()
-	pack, err := converter.FromChangePack(req.ChangePack)
+	req *api.GetDocumentAtRequest,
+) (*api.GetDocumentAtResponse, error) {
+	docKey, err := converter.FromDocumentKey(req.DocumentKey)
 	if err != nil {
 		return nil, err
 	}
 
 	if err := auth.VerifyAccess(ctx, s.backend, &types.AccessInfo{
-		Method:     types.PushPull,
-		Attributes: auth.AccessAttributes(pack),
+		Method:     types.GetDocumentAt,
+		Attributes: []types.AccessAttribute{{Key: docKey.BSONKey(), Verb: types.Read}},
 	}); err != nil {
 		return nil, err
 	}
 
-	if pack.HasChanges() {
-		s.backend.Metrics.SetPushPullReceivedChanges(len(pack.Changes))
-
-		locker, err := s.backend.Coordinator.NewLocker(
-			ctx,
-			packs.NewPushPullKey(pack.DocumentKey),
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := locker.Lock(ctx); err != nil {
-			log.Logger.Error(err)
-			return nil, err
-		}
-		defer func() {
-			if err := locker.Unlock(ctx); err != nil {
-				log.Logger.Error(err)
-			}
-		}()
+	_, docInfo, err := clients.FindClientAndDocument(ctx, s.backend, req.ClientId, docKey, false)
+	if err != nil {
+		return nil, err
 	}
 
-	clientInfo, docInfo, err := clients.FindClientAndDocument(
-		ctx,
-		s.backend,
-		req.ClientId,
-		pack,
-		false,
-	)
+	doc, err := documents.BuildDocumentAt(ctx, s.backend, docInfo, req.ServerSeq)
 	if err != nil {
 		return nil, err
 	}
-	if err := clientInfo.EnsureDocumentAttached(docInfo.ID); err != nil {
+
+	snapshot, err := converter.ObjectToBytes(doc.RootObject())
+	if err != nil {
 		return nil, err
 	}
 
-	pulled, err := packs.PushPull(ctx, s.backend, clientInfo, docInfo, pack)
+	return &api.GetDocumentAtResponse{
+		ServerSeq: req.ServerSeq,
+		Snapshot:  snapshot,
+	}, nil
+}
+
+// RevertDocument reverts the document to the state at the given server seq.
+func (s *yorkieServer) RevertDocument(
+	ctx context.Context,
+	req *api.RevertDocumentRequest,
+) (*api.RevertDocumentResponse, error) {
+	docKey, err := converter.FromDocumentKey(req.DocumentKey)
 	if err != nil {
 		return nil, err
 	}
 
-	pbChangePack, err := converter.ToChangePack(pulled)
-	if err != nil {
+	if err := auth.VerifyAccess(ctx, s.backend, &types.AccessInfo{
+		Method:     types.RevertDocument,
+		Attributes: []types.AccessAttribute{{Key: docKey.BSONKey(), Verb: types.ReadWrite}},
+	}); err != nil {
 		return nil, err
 	}
 
-	s.backend.Metrics.SetPushPullSentChanges(len(pbChangePack.Changes))
-	s.backend.Metrics.ObservePushPullResponseSeconds(gotime.Since(start).Seconds())
+	if owner, local := s.backend.Coordinator.Owner(docKey); !local {
+		resp, err := forwardRevertDocument(ctx, s.backend, owner, req)
+		if err != errForwardUnavailable {
+			return resp, err
+		}
+	}
 
-	return &api.PushPullResponse{
-		ChangePack: pbChangePack,
-	}, nil
+	return revertDocument(ctx, s.backend, req.ClientId, docKey, req.ServerSeq)
 }
 
 // WatchDocuments connects the stream to deliver events from the given documents
@@ -358,7 +369,12 @@ func (s *yorkieServer) WatchDocuments(
 		case <-stream.Context().Done():
 			s.unwatchDocs(docKeys, subscription)
 			return nil
-		case event := <-subscription.Events():
+		case event, ok := <-subscription.Events():
+			if !ok {
+				s.unwatchDocs(docKeys, subscription)
+				return sync.ErrSlowConsumer
+			}
+
 			eventType, err := converter.ToDocEventType(event.Type)
 			if err != nil {
 				return err
@@ -395,6 +411,22 @@ func (s *yorkieServer) watchDocs(
 		return nil, nil, err
 	}
 
+	if limit := s.backend.Config.MaxWatchersPerDocument; limit > 0 {
+		for _, docKey := range docKeys {
+			if watchers := len(peersMap[docKey.BSONKey()]); watchers > limit {
+				s.backend.Coordinator.Unsubscribe(docKeys, subscription)
+				s.backend.Metrics.IncLimitRejections(backend.LimitWatchersPerDocument)
+				return nil, nil, fmt.Errorf(
+					"%d watchers of %s(limit %d): %w",
+					watchers,
+					docKey.BSONKey(),
+					limit,
+					sync.ErrTooManyWatchers,
+				)
+			}
+		}
+	}
+
 	s.backend.Coordinator.Publish(
 		ctx,
 		subscription.Subscriber().ID,
@@ -424,3 +456,224 @@ func (s *yorkieServer) unwatchDocs(
 		},
 	)
 }
+
+// attachDocument attaches the document of the given pack to the client. It is
+// used by both yorkieServer and clusterServer.
+func attachDocument(
+	ctx context.Context,
+	be *backend.Backend,
+	clientID []byte,
+	pack *change.Pack,
+) (*api.AttachDocumentResponse, error) {
+	if pack.HasChanges() {
+		locker, err := be.Coordinator.NewLocker(
+			ctx,
+			packs.NewPushPullKey(pack.DocumentKey),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := locker.Lock(ctx); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err := locker.Unlock(ctx); err != nil {
+				log.Logger.Error(err)
+			}
+		}()
+	}
+
+	clientInfo, docInfo, err := clients.FindClientAndDocument(
+		ctx,
+		be,
+		clientID,
+		pack.DocumentKey,
+		true,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := clients.AttachDocument(be, clientInfo, docInfo.ID); err != nil {
+		return nil, err
+	}
+	if err := clientInfo.UpdateCheckpoint(docInfo.ID, packs.AttachCheckpoint(pack)); err != nil {
+		return nil, err
+	}
+
+	pulled, err := packs.PushPull(ctx, be, clientInfo, docInfo, pack)
+	if err != nil {
+		return nil, err
+	}
+
+	pbChangePack, err := converter.ToChangePack(pulled)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.AttachDocumentResponse{
+		ChangePack: pbChangePack,
+	}, nil
+}
+
+// detachDocument detaches the document of the given pack from the client. It
+// is used by both yorkieServer and clusterServer.
+func detachDocument(
+	ctx context.Context,
+	be *backend.Backend,
+	clientID []byte,
+	pack *change.Pack,
+) (*api.DetachDocumentResponse, error) {
+	if pack.HasChanges() {
+		locker, err := be.Coordinator.NewLocker(
+			ctx,
+			packs.NewPushPullKey(pack.DocumentKey),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := locker.Lock(ctx); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err := locker.Unlock(ctx); err != nil {
+				log.Logger.Error(err)
+			}
+		}()
+	}
+
+	clientInfo, docInfo, err := clients.FindClientAndDocument(
+		ctx,
+		be,
+		clientID,
+		pack.DocumentKey,
+		false,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := clientInfo.EnsureDocumentAttached(docInfo.ID); err != nil {
+		return nil, err
+	}
+
+	// NOTE: Detaching resets the checkpoint of the client, but the changes of
+	// the pack follow the checkpoint before detaching.
+	cp := clientInfo.Checkpoint(docInfo.ID)
+	if err := clientInfo.DetachDocument(docInfo.ID); err != nil {
+		return nil, err
+	}
+	if err := clientInfo.UpdateCheckpoint(docInfo.ID, cp); err != nil {
+		return nil, err
+	}
+
+	pulled, err := packs.PushPull(ctx, be, clientInfo, docInfo, pack)
+	if err != nil {
+		return nil, err
+	}
+
+	pbChangePack, err := converter.ToChangePack(pulled)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.DetachDocumentResponse{
+		ChangePack: pbChangePack,
+	}, nil
+}
+
+// pushPull stores the changes of the given pack and returns the changes
+// accumulated in the agent. It is used by both yorkieServer and clusterServer.
+func pushPull(
+	ctx context.Context,
+	be *backend.Backend,
+	clientID []byte,
+	pack *change.Pack,
+) (*api.PushPullResponse, error) {
+	if pack.HasChanges() {
+		be.Metrics.SetPushPullReceivedChanges(len(pack.Changes))
+
+		locker, err := be.Coordinator.NewLocker(
+			ctx,
+			packs.NewPushPullKey(pack.DocumentKey),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := locker.Lock(ctx); err != nil {
+			log.Logger.Error(err)
+			return nil, err
+		}
+		defer func() {
+			if err := locker.Unlock(ctx); err != nil {
+				log.Logger.Error(err)
+			}
+		}()
+	}
+
+	clientInfo, docInfo, err := clients.FindClientAndDocument(
+		ctx,
+		be,
+		clientID,
+		pack.DocumentKey,
+		false,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := clientInfo.EnsureDocumentAttached(docInfo.ID); err != nil {
+		return nil, err
+	}
+
+	pulled, err := packs.PushPull(ctx, be, clientInfo, docInfo, pack)
+	if err != nil {
+		return nil, err
+	}
+
+	pbChangePack, err := converter.ToChangePack(pulled)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.PushPullResponse{
+		ChangePack: pbChangePack,
+	}, nil
+}
+
+// revertDocument reverts the given document to the state at the given server
+// seq. It is used by both yorkieServer and clusterServer.
+func revertDocument(
+	ctx context.Context,
+	be *backend.Backend,
+	clientID []byte,
+	docKey *key.Key,
+	serverSeq uint64,
+) (*api.RevertDocumentResponse, error) {
+	locker, err := be.Coordinator.NewLocker(ctx, packs.NewPushPullKey(docKey))
+	if err != nil {
+		return nil, err
+	}
+	if err := locker.Lock(ctx); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := locker.Unlock(ctx); err != nil {
+			log.Logger.Error(err)
+		}
+	}()
+
+	_, docInfo, err := clients.FindClientAndDocument(ctx, be, clientID, docKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	revertedSeq, err := documents.Revert(ctx, be, docInfo, serverSeq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.RevertDocumentResponse{
+		ServerSeq: revertedSeq,
+	}, nil
+}
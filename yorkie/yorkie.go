@@ -17,12 +17,15 @@
 package yorkie
 
 import (
+	"context"
 	gosync "sync"
 
 	"github.com/yorkie-team/yorkie/internal/log"
+	"github.com/yorkie-team/yorkie/pkg/document/schema"
 	"github.com/yorkie-team/yorkie/yorkie/backend"
 	"github.com/yorkie-team/yorkie/yorkie/backend/sync"
 	"github.com/yorkie-team/yorkie/yorkie/metrics/prometheus"
+	"github.com/yorkie-team/yorkie/yorkie/packs"
 	"github.com/yorkie-team/yorkie/yorkie/rpc"
 )
 
@@ -48,14 +51,19 @@ func New(conf *Config) (*Yorkie, error) {
 	be, err := backend.New(
 		conf.Backend,
 		conf.Mongo,
+		conf.Bolt,
 		conf.ETCD,
-		conf.RPCAddr(),
+		conf.ClusterAddr(),
 		met,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := packs.RepairDocuments(context.Background(), be); err != nil {
+		return nil, err
+	}
+
 	metricsServer, err := prometheus.NewServer(conf.Metrics, met)
 	if err != nil {
 		return nil, err
@@ -123,6 +131,13 @@ func (r *Yorkie) RPCAddr() string {
 	return r.conf.RPCAddr()
 }
 
+// RegisterSchema sets the schema of the given collection. The changes that
+// make a document of the collection violate the schema are rejected. It
+// replaces the schema loaded from the configuration.
+func (r *Yorkie) RegisterSchema(collection string, s schema.Schema) {
+	r.backend.Schemas.Register(collection, s)
+}
+
 // Members returns the members of this cluster.
 func (r *Yorkie) Members() map[string]*sync.AgentInfo {
 	return r.backend.Members()